@@ -6,14 +6,17 @@ package main
 
 import (
 	"context"
+	"io"
 	"log"
 	"os"
 	"os/signal"
 	"syscall"
 	"time"
 
+	"github.com/telemetryflow/order-service/internal/app"
 	"github.com/telemetryflow/order-service/internal/infrastructure/config"
-	"github.com/telemetryflow/order-service/internal/infrastructure/http"
+	"github.com/telemetryflow/order-service/internal/infrastructure/http/middleware"
+	"github.com/telemetryflow/order-service/internal/infrastructure/outbox"
 	"github.com/telemetryflow/order-service/internal/infrastructure/persistence"
 	"github.com/telemetryflow/order-service/telemetry"
 )
@@ -46,8 +49,84 @@ func main() {
 		}
 	}()
 
-	// Create HTTP server
-	server := http.NewServer(cfg, db)
+	// Run a subcommand instead of the server
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "migrate":
+			if err := runMigrate(context.Background(), db, os.Args[2:]); err != nil {
+				log.Fatalf("Migration failed: %v", err)
+			}
+		case "schema":
+			if err := runSchema(context.Background(), db, os.Args[2:]); err != nil {
+				log.Fatalf("Schema check failed: %v", err)
+			}
+		default:
+			log.Fatalf("Unknown command %q", os.Args[1])
+		}
+		return
+	}
+
+	// Apply pending migrations
+	if cfg.Database.AutoMigrate {
+		if err := migrateUp(context.Background(), db); err != nil {
+			log.Fatalf("Failed to migrate database: %v", err)
+		}
+	}
+
+	// Verify the schema matches the entities
+	if cfg.Database.StrictSchema {
+		if err := checkSchema(context.Background(), db); err != nil {
+			log.Fatalf("Schema check failed: %v", err)
+		}
+	}
+
+	// Wire repositories, handlers and the HTTP server
+	container := app.New(cfg, db)
+	server := container.Server
+
+	// Start outbox relay
+	relayCtx, stopRelay := context.WithCancel(context.Background())
+	relayDone := make(chan struct{})
+	if cfg.Outbox.Enabled {
+		publisher, err := outbox.NewPublisher(cfg.Outbox)
+		if err != nil {
+			log.Fatalf("Failed to create outbox publisher: %v", err)
+		}
+		if closer, ok := publisher.(io.Closer); ok {
+			defer func() {
+				if err := closer.Close(); err != nil {
+					log.Printf("Failed to close outbox publisher: %v", err)
+				}
+			}()
+		}
+
+		relay := outbox.NewRelay(persistence.NewOutboxStore(db), container.UnitOfWork, publisher, cfg.Outbox)
+		go func() {
+			defer close(relayDone)
+			relay.Run(relayCtx)
+		}()
+	} else {
+		close(relayDone)
+	}
+
+	// Track read replica health
+	monitorCtx, stopMonitor := context.WithCancel(context.Background())
+	defer stopMonitor()
+	if replicas := persistence.Replicas(db); replicas != nil {
+		go replicas.Monitor(monitorCtx, cfg.Database.ReplicaCheckInterval)
+	}
+
+	// Delete expired idempotency keys
+	cleanupCtx, stopCleanup := context.WithCancel(context.Background())
+	cleanupDone := make(chan struct{})
+	if cfg.Idempotency.CleanupInterval > 0 {
+		go func() {
+			defer close(cleanupDone)
+			middleware.CleanupIdempotencyKeys(cleanupCtx, persistence.NewIdempotencyStore(db), cfg.Idempotency.CleanupInterval)
+		}()
+	} else {
+		close(cleanupDone)
+	}
 
 	// Start server in goroutine
 	go func() {
@@ -73,5 +152,10 @@ func main() {
 		log.Printf("Server forced to shutdown: %v", err)
 	}
 
+	stopRelay()
+	<-relayDone
+	stopCleanup()
+	<-cleanupDone
+
 	log.Println("Server exited")
 }
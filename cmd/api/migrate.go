@@ -0,0 +1,120 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/telemetryflow/order-service/internal/infrastructure/migration"
+	"github.com/telemetryflow/order-service/internal/infrastructure/persistence"
+	"github.com/telemetryflow/order-service/migrations"
+	"gorm.io/gorm"
+)
+
+const migrateUsage = "usage: migrate up | down [steps] | status | goto <version>"
+
+// newMigrator creates a migrator for the embedded migrations
+func newMigrator(db *gorm.DB) (*migration.Migrator, error) {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, err
+	}
+	all, err := migration.Load(migrations.FS)
+	if err != nil {
+		return nil, err
+	}
+	return migration.NewMigrator(migration.NewPostgresStore(sqlDB), all), nil
+}
+
+// migrateUp applies pending migrations. The SQL migrations are written for
+// PostgreSQL, so SQLite databases are migrated with GORM AutoMigrate instead.
+func migrateUp(ctx context.Context, db *gorm.DB) error {
+	if db.Dialector.Name() == persistence.DriverSQLite {
+		return persistence.AutoMigrate(db.WithContext(ctx), persistence.Models()...)
+	}
+
+	migrator, err := newMigrator(db)
+	if err != nil {
+		return err
+	}
+	steps, err := migrator.Up(ctx)
+	if err != nil {
+		return err
+	}
+	log.Printf("Applied %d migration(s)", len(steps))
+	return nil
+}
+
+// runMigrate executes the migrate subcommand
+func runMigrate(ctx context.Context, db *gorm.DB, args []string) error {
+	if len(args) == 0 {
+		return errors.New(migrateUsage)
+	}
+	if db.Dialector.Name() == persistence.DriverSQLite {
+		if args[0] != "up" {
+			return fmt.Errorf("only migrate up is supported for %s", persistence.DriverSQLite)
+		}
+		return migrateUp(ctx, db)
+	}
+
+	migrator, err := newMigrator(db)
+	if err != nil {
+		return err
+	}
+
+	var steps []migration.Step
+	switch args[0] {
+	case "up":
+		steps, err = migrator.Up(ctx)
+	case "down":
+		n := 1
+		if len(args) > 1 {
+			if n, err = strconv.Atoi(args[1]); err != nil {
+				return fmt.Errorf("invalid steps %q: %w", args[1], err)
+			}
+		}
+		steps, err = migrator.Down(ctx, n)
+	case "goto":
+		if len(args) < 2 {
+			return errors.New(migrateUsage)
+		}
+		version, parseErr := strconv.ParseInt(args[1], 10, 64)
+		if parseErr != nil {
+			return fmt.Errorf("invalid version %q: %w", args[1], parseErr)
+		}
+		steps, err = migrator.Goto(ctx, version)
+	case "status":
+		return printStatus(ctx, migrator)
+	default:
+		return fmt.Errorf("unknown migrate command %q; %s", args[0], migrateUsage)
+	}
+
+	fmt.Printf("%d migration(s) applied\n", len(steps))
+	return err
+}
+
+// printStatus writes the migration status table to stdout
+func printStatus(ctx context.Context, migrator *migration.Migrator) error {
+	statuses, err := migrator.Status(ctx)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSION\tNAME\tSTATUS\tAPPLIED AT")
+	for _, s := range statuses {
+		state, appliedAt := "pending", ""
+		if s.Applied {
+			state, appliedAt = "applied", s.AppliedAt.Format("2006-01-02 15:04:05 MST")
+		}
+		if s.Missing {
+			state = "applied (not in this build)"
+		}
+		fmt.Fprintf(w, "%06d\t%s\t%s\t%s\n", s.Version, s.Name, state, appliedAt)
+	}
+	return w.Flush()
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/telemetryflow/order-service/internal/domain/entity"
+	"github.com/telemetryflow/order-service/internal/infrastructure/schema"
+	"gorm.io/gorm"
+)
+
+const schemaUsage = "usage: schema check"
+
+// entities are the models whose tables are checked for drift
+var entities = []interface{}{&entity.Order{}, &entity.Orderitem{}}
+
+// runSchema executes the schema subcommand
+func runSchema(ctx context.Context, db *gorm.DB, args []string) error {
+	if len(args) == 0 || args[0] != "check" {
+		return errors.New(schemaUsage)
+	}
+
+	diffs, err := schema.Check(ctx, db, entities...)
+	if err != nil {
+		return err
+	}
+	if len(diffs) == 0 {
+		fmt.Println("Schema matches the entities")
+		return nil
+	}
+	for _, d := range diffs {
+		fmt.Println(d)
+	}
+	return fmt.Errorf("schema differs from the entities in %d place(s)", len(diffs))
+}
+
+// checkSchema logs every difference between the schema and the entities
+// and fails if there are any. Databases whose schema cannot be inspected
+// are not checked.
+func checkSchema(ctx context.Context, db *gorm.DB) error {
+	diffs, err := schema.Check(ctx, db, entities...)
+	if errors.Is(err, schema.ErrUnsupported) {
+		log.Printf("Skipping schema check: %v", err)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	for _, d := range diffs {
+		log.Printf("Schema drift: %s", d)
+	}
+	if len(diffs) > 0 {
+		return fmt.Errorf("schema differs from the entities in %d place(s)", len(diffs))
+	}
+	return nil
+}
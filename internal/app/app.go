@@ -0,0 +1,105 @@
+// Package app is the composition root of Order-Service. It builds the
+// repositories, application handlers and HTTP server from configuration
+// and a database connection.
+//
+// Generated by TelemetryFlow RESTful API Generator
+// Copyright (c) 2024-2026 DevOpsCorner Indonesia. All rights reserved.
+package app
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"log"
+
+	"github.com/telemetryflow/order-service/internal/application/bus"
+	"github.com/telemetryflow/order-service/internal/application/eventbus"
+	"github.com/telemetryflow/order-service/internal/application/handler"
+	"github.com/telemetryflow/order-service/internal/domain/repository"
+	"github.com/telemetryflow/order-service/internal/infrastructure/config"
+	"github.com/telemetryflow/order-service/internal/infrastructure/http"
+	httphandler "github.com/telemetryflow/order-service/internal/infrastructure/http/handler"
+	"github.com/telemetryflow/order-service/internal/infrastructure/http/middleware"
+	"github.com/telemetryflow/order-service/internal/infrastructure/persistence"
+	"github.com/telemetryflow/order-service/pkg/pagination"
+	"gorm.io/gorm"
+)
+
+// Container holds the application's wired dependencies
+type Container struct {
+	Config *config.Config
+	DB     *gorm.DB
+
+	// Persistence
+	UnitOfWork          repository.UnitOfWork
+	OrderRepository     repository.OrderRepository
+	OrderitemRepository repository.OrderitemRepository
+	OutboxRepository    repository.OutboxRepository
+
+	// Application
+	Cursors                 *pagination.Codec
+	Events                  *eventbus.Dispatcher
+	CommandBus              *bus.CommandBus
+	QueryBus                *bus.QueryBus
+	OrderCommandHandler     *handler.OrderCommandHandler
+	OrderQueryHandler       *handler.OrderQueryHandler
+	OrderitemCommandHandler *handler.OrderitemCommandHandler
+	OrderitemQueryHandler   *handler.OrderitemQueryHandler
+
+	// HTTP
+	OrderHandler     *httphandler.OrderHandler
+	OrderitemHandler *httphandler.OrderitemHandler
+	Server           *http.Server
+}
+
+// New builds a Container. Event subscribers can be registered on
+// Container.Events before the server starts.
+func New(cfg *config.Config, db *gorm.DB) *Container {
+	c := &Container{
+		Config: cfg,
+		DB:     db,
+	}
+
+	c.UnitOfWork = persistence.NewUnitOfWork(db)
+	c.OrderRepository = persistence.NewOrderRepository(db)
+	c.OrderitemRepository = persistence.NewOrderitemRepository(db)
+	c.OutboxRepository = persistence.NewOutboxRepository(db)
+
+	c.Cursors = pagination.NewCodec(cursorSecret(cfg.Pagination))
+	c.Events = eventbus.NewDispatcher()
+	c.OrderCommandHandler = handler.NewOrderCommandHandler(c.OrderRepository, c.OrderitemRepository, c.UnitOfWork, c.OutboxRepository, c.Events)
+	c.OrderQueryHandler = handler.NewOrderQueryHandler(c.OrderRepository, c.Cursors)
+	c.OrderitemCommandHandler = handler.NewOrderitemCommandHandler(c.OrderitemRepository, c.OrderRepository, c.UnitOfWork, c.OutboxRepository, c.Events)
+	c.OrderitemQueryHandler = handler.NewOrderitemQueryHandler(c.OrderitemRepository, c.OrderRepository, c.Cursors)
+
+	// Behaviors run outermost first: a dispatch is traced, measured and
+	// logged even when it is rejected
+	authorize := bus.Authorization(bus.RequireRole(middleware.RoleAdmin, middleware.RoleUser))
+	c.CommandBus = bus.NewCommandBus(bus.Tracing(), bus.Metrics(), bus.Logging(), authorize, bus.Validation(), bus.Transaction(c.UnitOfWork))
+	c.QueryBus = bus.NewQueryBus(bus.Tracing(), bus.Metrics(), bus.Logging(), authorize, bus.Validation())
+	c.OrderCommandHandler.Register(c.CommandBus)
+	c.OrderitemCommandHandler.Register(c.CommandBus)
+	c.OrderQueryHandler.Register(c.QueryBus)
+	c.OrderitemQueryHandler.Register(c.QueryBus)
+
+	c.OrderHandler = httphandler.NewOrderHandler(c.CommandBus, c.QueryBus)
+	c.OrderitemHandler = httphandler.NewOrderitemHandler(c.CommandBus, c.QueryBus)
+	c.Server = http.NewServer(cfg, db, c.OrderHandler, c.OrderitemHandler)
+
+	return c
+}
+
+// cursorSecret returns the configured pagination cursor secret. Without one,
+// cursors are signed with a random key generated for this process, so they
+// stop working on restart and are not accepted by other instances.
+func cursorSecret(cfg config.PaginationConfig) string {
+	if cfg.CursorSecret != "" {
+		return cfg.CursorSecret
+	}
+
+	log.Printf("PAGINATION_CURSOR_SECRET is not set; signing list cursors with a random per-process key")
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(key)
+}
@@ -0,0 +1,148 @@
+// Package bus provides the standard behaviors of the command and query buses.
+package bus
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/telemetryflow/order-service/internal/domain/repository"
+	"github.com/telemetryflow/order-service/telemetry/logs"
+	"github.com/telemetryflow/order-service/telemetry/metrics"
+	"github.com/telemetryflow/order-service/telemetry/traces"
+)
+
+// ErrForbidden is returned by Authorization when the caller may not
+// dispatch a message
+var ErrForbidden = errors.New("access forbidden")
+
+// Validatable is implemented by messages that validate themselves.
+// Validate may also normalize the message, e.g. default pagination.
+type Validatable interface {
+	Validate() error
+}
+
+// Validation rejects messages whose Validate method fails, before they
+// reach their handler
+func Validation() Behavior {
+	return func(ctx context.Context, msg Message, next Next) error {
+		if v, ok := msg.Body.(Validatable); ok {
+			if err := v.Validate(); err != nil {
+				return err
+			}
+		}
+		return next(ctx)
+	}
+}
+
+// Tracing runs every dispatch in an internal span named after the message,
+// e.g. "command.CreateOrderCommand". The rest of the pipeline and the handler
+// run with the span's context, so their spans are nested beneath it.
+func Tracing() Behavior {
+	return func(ctx context.Context, msg Message, next Next) error {
+		spanCtx, end := traces.StartInternalSpanContext(ctx, string(msg.Kind)+"."+msg.Name, map[string]interface{}{
+			"bus.kind":    string(msg.Kind),
+			"bus.message": msg.Name,
+		})
+		err := next(spanCtx)
+		end(err)
+		return err
+	}
+}
+
+// Metrics records the duration and outcome of every dispatch
+func Metrics() Behavior {
+	return func(ctx context.Context, msg Message, next Next) error {
+		start := time.Now()
+		err := next(ctx)
+		metrics.RecordBusDispatch(string(msg.Kind), msg.Name, time.Since(start).Seconds(), err == nil)
+		return err
+	}
+}
+
+// Logging logs every dispatch at debug level, and failed ones as warnings
+func Logging() Behavior {
+	return func(ctx context.Context, msg Message, next Next) error {
+		start := time.Now()
+		err := next(ctx)
+
+		attrs := map[string]interface{}{
+			"kind":        string(msg.Kind),
+			"message":     msg.Name,
+			"duration_ms": time.Since(start).Milliseconds(),
+		}
+		if err != nil {
+			logs.Warn("Dispatch failed", logs.Merge(attrs, logs.WithError(err)))
+		} else {
+			logs.Debug("Dispatched", attrs)
+		}
+		return err
+	}
+}
+
+// Transaction runs every command handler in a unit of work, so that
+// everything it changes is committed or rolled back together. Units of work
+// started by the handler join it, and events it registers with
+// repository.AfterCommit are only published once it has committed.
+// Queries are passed through.
+func Transaction(uow repository.UnitOfWork) Behavior {
+	return func(ctx context.Context, msg Message, next Next) error {
+		if msg.Kind != KindCommand {
+			return next(ctx)
+		}
+		return uow.Do(ctx, func(ctx context.Context) error {
+			return next(ctx)
+		})
+	}
+}
+
+// Policy decides whether the caller in ctx may dispatch msg, returning
+// an error when it may not
+type Policy func(ctx context.Context, msg Message) error
+
+// Authorization rejects the messages that policy does not allow
+func Authorization(policy Policy) Behavior {
+	return func(ctx context.Context, msg Message, next Next) error {
+		if err := policy(ctx, msg); err != nil {
+			return err
+		}
+		return next(ctx)
+	}
+}
+
+// RequireRole returns a Policy allowing callers with one of roles.
+// Messages dispatched without an Actor are rejected with ErrForbidden.
+func RequireRole(roles ...string) Policy {
+	return func(ctx context.Context, _ Message) error {
+		actor, ok := ActorFrom(ctx)
+		if !ok {
+			return ErrForbidden
+		}
+		for _, role := range roles {
+			if actor.Role == role {
+				return nil
+			}
+		}
+		return ErrForbidden
+	}
+}
+
+// Actor is the authenticated caller dispatching a message
+type Actor struct {
+	UserID string
+	Role   string
+}
+
+// actorKey is the context key under which the Actor is stored
+type actorKey struct{}
+
+// WithActor returns a copy of ctx carrying actor
+func WithActor(ctx context.Context, actor Actor) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+// ActorFrom returns the Actor carried by ctx
+func ActorFrom(ctx context.Context) (Actor, bool) {
+	actor, ok := ctx.Value(actorKey{}).(Actor)
+	return actor, ok
+}
@@ -0,0 +1,193 @@
+// Package bus provides the command and query buses of the application.
+//
+// Handlers are registered for the concrete type of the message they handle,
+// and messages are dispatched with the result type the caller expects:
+//
+//	bus.RegisterCommand(commands, h.HandleOrderCreate)
+//	result, err := bus.Send[command.CommandResult](ctx, commands, cmd)
+//
+// Every dispatch runs through the bus's behaviors (validation, tracing,
+// metrics, logging, transactions, authorization) before reaching the
+// handler. Buses are safe for concurrent use.
+package bus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/telemetryflow/order-service/internal/domain/repository"
+)
+
+// Kind distinguishes commands from queries
+type Kind string
+
+// Message kinds
+const (
+	KindCommand Kind = "command"
+	KindQuery   Kind = "query"
+)
+
+var (
+	// ErrNoHandler is returned when no handler is registered for a message type
+	ErrNoHandler = errors.New("no handler registered")
+
+	// ErrResultType is returned when the registered handler does not return
+	// the result type requested by the caller
+	ErrResultType = errors.New("handler result type mismatch")
+)
+
+// Handler handles messages of type M and returns a result of type R
+type Handler[M, R any] func(ctx context.Context, msg M) (R, error)
+
+// Void adapts a handler that only returns an error. Dispatch its messages
+// with Exec.
+func Void[M any](fn func(ctx context.Context, msg M) error) Handler[M, struct{}] {
+	return func(ctx context.Context, msg M) (struct{}, error) {
+		return struct{}{}, fn(ctx, msg)
+	}
+}
+
+// Message describes a dispatched message to behaviors
+type Message struct {
+	Kind Kind
+	// Name is the name of the message type, e.g. "CreateOrderCommand"
+	Name string
+	// Body is the message itself
+	Body interface{}
+}
+
+// Next continues a dispatch with the next behavior, or the handler
+type Next func(ctx context.Context) error
+
+// Behavior runs around every dispatch of a bus. It calls next to continue,
+// possibly with a derived context, or returns an error to stop the dispatch.
+type Behavior func(ctx context.Context, msg Message, next Next) error
+
+// bus holds the handlers and behaviors shared by CommandBus and QueryBus
+type bus struct {
+	kind      Kind
+	behaviors []Behavior
+
+	mu       sync.RWMutex
+	handlers map[reflect.Type]interface{}
+}
+
+func newBus(kind Kind, behaviors []Behavior) bus {
+	return bus{
+		kind:      kind,
+		behaviors: behaviors,
+		handlers:  make(map[reflect.Type]interface{}),
+	}
+}
+
+// CommandBus dispatches commands to their handlers
+type CommandBus struct {
+	bus
+}
+
+// NewCommandBus creates a command bus. Behaviors run in the order given,
+// the first one outermost.
+func NewCommandBus(behaviors ...Behavior) *CommandBus {
+	return &CommandBus{bus: newBus(KindCommand, behaviors)}
+}
+
+// QueryBus dispatches queries to their handlers. The reads of query
+// handlers may be served from a read replica (see repository.WithReplicaReads).
+type QueryBus struct {
+	bus
+}
+
+// NewQueryBus creates a query bus. Behaviors run in the order given, the
+// first one outermost.
+func NewQueryBus(behaviors ...Behavior) *QueryBus {
+	return &QueryBus{bus: newBus(KindQuery, behaviors)}
+}
+
+// RegisterCommand registers h for commands of type C. It panics when C
+// already has a handler.
+func RegisterCommand[C, R any](b *CommandBus, h Handler[C, R]) {
+	register(&b.bus, h)
+}
+
+// RegisterQuery registers h for queries of type Q. It panics when Q already
+// has a handler.
+func RegisterQuery[Q, R any](b *QueryBus, h Handler[Q, R]) {
+	register(&b.bus, h)
+}
+
+// Send dispatches cmd and returns the result of its handler
+func Send[R, C any](ctx context.Context, b *CommandBus, cmd C) (R, error) {
+	return dispatch[C, R](ctx, &b.bus, cmd)
+}
+
+// Exec dispatches cmd to a handler registered with Void
+func Exec[C any](ctx context.Context, b *CommandBus, cmd C) error {
+	_, err := dispatch[C, struct{}](ctx, &b.bus, cmd)
+	return err
+}
+
+// Ask dispatches q and returns the result of its handler
+func Ask[R, Q any](ctx context.Context, b *QueryBus, q Q) (R, error) {
+	return dispatch[Q, R](repository.WithReplicaReads(ctx), &b.bus, q)
+}
+
+// register stores h under the type of M
+func register[M, R any](b *bus, h Handler[M, R]) {
+	key := typeOf[M]()
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if _, ok := b.handlers[key]; ok {
+		panic(fmt.Sprintf("bus: %s %s already has a handler", b.kind, key))
+	}
+	b.handlers[key] = h
+}
+
+// dispatch runs msg through the behaviors of b and its handler
+func dispatch[M, R any](ctx context.Context, b *bus, msg M) (R, error) {
+	var result R
+	key := typeOf[M]()
+
+	b.mu.RLock()
+	registered, ok := b.handlers[key]
+	b.mu.RUnlock()
+	if !ok {
+		return result, fmt.Errorf("%w for %s %s", ErrNoHandler, b.kind, key)
+	}
+	h, ok := registered.(Handler[M, R])
+	if !ok {
+		return result, fmt.Errorf("%w: %s %s does not return %s", ErrResultType, b.kind, key, typeOf[R]())
+	}
+
+	m := Message{Kind: b.kind, Name: messageName(key), Body: msg}
+	next := func(ctx context.Context) error {
+		var err error
+		result, err = h(ctx, msg)
+		return err
+	}
+	for i := len(b.behaviors) - 1; i >= 0; i-- {
+		behavior, inner := b.behaviors[i], next
+		next = func(ctx context.Context) error {
+			return behavior(ctx, m, inner)
+		}
+	}
+
+	err := next(ctx)
+	return result, err
+}
+
+// typeOf returns the reflect.Type of T, including interface types
+func typeOf[T any]() reflect.Type {
+	return reflect.TypeOf((*T)(nil)).Elem()
+}
+
+// messageName returns the name of a message type without pointers
+func messageName(t reflect.Type) string {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t.Name()
+}
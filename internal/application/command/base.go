@@ -3,8 +3,12 @@ package command
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/telemetryflow/order-service/internal/domain"
+	"github.com/telemetryflow/order-service/internal/domain/entity"
+	"github.com/telemetryflow/order-service/pkg/validator"
 )
 
 // Command represents a write operation
@@ -40,11 +44,28 @@ func (c *DeleteCommand) Validate() error {
 	return nil
 }
 
-// CommandResult represents the result of a command execution
+// CommandResult represents the result of a command execution.
+// Commands changing an entity report its ID, version and timestamps after
+// the change, so that callers can address and re-read it.
 type CommandResult struct {
-	ID      uuid.UUID `json:"id,omitempty"`
-	Success bool      `json:"success"`
-	Message string    `json:"message,omitempty"`
+	ID        uuid.UUID `json:"id,omitempty"`
+	Version   int64     `json:"version,omitempty"`
+	CreatedAt time.Time `json:"created_at,omitempty"`
+	UpdatedAt time.Time `json:"updated_at,omitempty"`
+	Success   bool      `json:"success"`
+	Message   string    `json:"message,omitempty"`
+}
+
+// NewEntityResult creates a success result describing the persisted state
+// of an entity
+func NewEntityResult(base *entity.Base) CommandResult {
+	return CommandResult{
+		ID:        base.ID,
+		Version:   base.Version,
+		CreatedAt: base.CreatedAt,
+		UpdatedAt: base.UpdatedAt,
+		Success:   true,
+	}
 }
 
 // NewSuccessResult creates a success result
@@ -71,8 +92,21 @@ var (
 	ErrNotFound      = &CommandError{Code: "NOT_FOUND", Message: "Resource not found"}
 	ErrAlreadyExists = &CommandError{Code: "ALREADY_EXISTS", Message: "Resource already exists"}
 	ErrUnauthorized  = &CommandError{Code: "UNAUTHORIZED", Message: "Unauthorized access"}
+
+	// ErrPreconditionFailed is returned when a command's expected version
+	// does not match the current version of the resource
+	ErrPreconditionFailed = &CommandError{Code: "PRECONDITION_FAILED", Message: "Resource version does not match"}
 )
 
+// validationError returns the field errors as a *validator.ValidationError,
+// the same error request validation returns, or nil if there are none
+func validationError(fields domain.FieldErrors) error {
+	if len(fields) == 0 {
+		return nil
+	}
+	return &validator.ValidationError{Message: ErrValidation.Message, Errors: fields}
+}
+
 // CommandError represents a command execution error
 type CommandError struct {
 	Code    string `json:"code"`
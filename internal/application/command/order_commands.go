@@ -2,54 +2,124 @@
 package command
 
 import (
+	"fmt"
+
 	"github.com/google/uuid"
+	"github.com/telemetryflow/order-service/internal/domain"
 	"github.com/telemetryflow/order-service/internal/domain/entity"
 )
 
-// CreateOrderCommand represents the create order command
+// CreateOrderCommand represents the create order command.
+// Total is optional; when set it must match the server-computed total.
+// Currency defaults to domain.DefaultCurrency.
+// Items are persisted in the same transaction as the order.
+// Status is optional; new orders always start as pending.
 type CreateOrderCommand struct {
-	CustomerID uuid.UUID `json:"customer_id" validate:"required"`
-	Total      float64   `json:"total" validate:"required"`
-	Status     string    `json:"status" validate:"required"`
+	CustomerID uuid.UUID         `json:"customer_id" validate:"required"`
+	Currency   string            `json:"currency" validate:"omitempty,len=3"`
+	Discount   domain.Money      `json:"discount"`
+	Tax        domain.Money      `json:"tax"`
+	Shipping   domain.Money      `json:"shipping"`
+	Total      *domain.Money     `json:"total"`
+	Status     string            `json:"status"`
+	Tags       []string          `json:"tags"`
+	Notes      string            `json:"notes"`
+	Items      []CreateOrderItem `json:"items" validate:"dive"`
+}
+
+// CreateOrderItem represents an item created together with its order
+type CreateOrderItem struct {
+	ProductID uuid.UUID    `json:"product_id" validate:"required"`
+	Quantity  int          `json:"quantity" validate:"gte=1"`
+	Price     domain.Money `json:"price"`
 }
 
-// Validate validates the create command
+// Validate validates the create command.
+// Field errors are returned as a *validator.ValidationError.
 func (c *CreateOrderCommand) Validate() error {
-	// Add validation logic
-	return nil
+	fields := domain.FieldErrors{}
+	if c.CustomerID == uuid.Nil {
+		fields.Add("customer_id", "This field is required")
+	}
+	if c.Status != "" && c.Status != entity.OrderStatusPending {
+		fields.Add("status", "New orders must be pending; use the transition endpoints")
+	}
+	if c.Currency != "" {
+		if err := domain.ValidateCurrency(c.Currency); err != nil {
+			fields.Add("currency", "Must be a 3-letter ISO-4217 currency code")
+		}
+	}
+	validateCharges(fields, c.Discount, c.Tax, c.Shipping)
+	for i, item := range c.Items {
+		validateItem(fields, fmt.Sprintf("items[%d].", i), item.ProductID, item.Quantity, item.Price)
+	}
+	return validationError(fields)
 }
 
 // ToEntity converts the command to an entity
 func (c *CreateOrderCommand) ToEntity() *entity.Order {
-	return entity.NewOrder(c.CustomerID, c.Total, c.Status)
+	currency := c.Currency
+	if currency == "" {
+		currency = domain.DefaultCurrency
+	}
+	e := entity.NewOrder(c.CustomerID, amountOrZero(c.Total).WithCurrency(currency), entity.OrderStatusPending)
+	e.Discount = c.Discount.WithCurrency(currency)
+	e.Tax = c.Tax.WithCurrency(currency)
+	e.Shipping = c.Shipping.WithCurrency(currency)
+	e.Annotate(c.Tags, c.Notes)
+	for _, item := range c.Items {
+		e.Items = append(e.Items, *entity.NewOrderitem(e.ID, item.ProductID, item.Quantity, item.Price.WithCurrency(currency)))
+	}
+	return e
 }
 
-// UpdateOrderCommand represents the update order command
+// UpdateOrderCommand represents the update order command.
+// Total is optional; when set it must match the server-computed total.
+// Version is the version the caller expects; zero skips the check.
 type UpdateOrderCommand struct {
-	ID         uuid.UUID `json:"id" validate:"required"`
-	CustomerID uuid.UUID `json:"customer_id" validate:"required"`
-	Total      float64   `json:"total" validate:"required"`
-	Status     string    `json:"status" validate:"required"`
+	ID         uuid.UUID     `json:"id" validate:"required"`
+	Version    int64         `json:"version"`
+	CustomerID uuid.UUID     `json:"customer_id" validate:"required"`
+	Discount   domain.Money  `json:"discount"`
+	Tax        domain.Money  `json:"tax"`
+	Shipping   domain.Money  `json:"shipping"`
+	Total      *domain.Money `json:"total"`
+	Status     string        `json:"status" validate:"required"`
+	Tags       []string      `json:"tags"`
+	Notes      string        `json:"notes"`
 }
 
-// Validate validates the update command
+// Validate validates the update command.
+// Field errors are returned as a *validator.ValidationError.
 func (c *UpdateOrderCommand) Validate() error {
 	if c.ID == uuid.Nil {
 		return ErrInvalidID
 	}
-	return nil
+	fields := domain.FieldErrors{}
+	if c.CustomerID == uuid.Nil {
+		fields.Add("customer_id", "This field is required")
+	}
+	validateStatus(fields, c.Status)
+	validateCharges(fields, c.Discount, c.Tax, c.Shipping)
+	return validationError(fields)
 }
 
 // ToEntity converts the command to an entity
 func (c *UpdateOrderCommand) ToEntity() *entity.Order {
-	e := entity.NewOrder(c.CustomerID, c.Total, c.Status)
+	e := entity.NewOrder(c.CustomerID, amountOrZero(c.Total), c.Status)
 	e.ID = c.ID
+	e.Discount = c.Discount
+	e.Tax = c.Tax
+	e.Shipping = c.Shipping
+	e.Annotate(c.Tags, c.Notes)
 	return e
 }
 
-// DeleteOrderCommand represents the delete order command
+// DeleteOrderCommand represents the delete order command.
+// Version is the version the caller expects; zero skips the check.
 type DeleteOrderCommand struct {
-	ID uuid.UUID `json:"id" validate:"required"`
+	ID      uuid.UUID `json:"id" validate:"required"`
+	Version int64     `json:"version"`
 }
 
 // Validate validates the delete command
@@ -59,3 +129,94 @@ func (c *DeleteOrderCommand) Validate() error {
 	}
 	return nil
 }
+
+// ConfirmOrderCommand represents the confirm order command
+type ConfirmOrderCommand struct {
+	ID uuid.UUID `json:"id" validate:"required"`
+}
+
+// Validate validates the confirm command
+func (c *ConfirmOrderCommand) Validate() error {
+	if c.ID == uuid.Nil {
+		return ErrInvalidID
+	}
+	return nil
+}
+
+// PayOrderCommand represents the pay order command
+type PayOrderCommand struct {
+	ID uuid.UUID `json:"id" validate:"required"`
+}
+
+// Validate validates the pay command
+func (c *PayOrderCommand) Validate() error {
+	if c.ID == uuid.Nil {
+		return ErrInvalidID
+	}
+	return nil
+}
+
+// ShipOrderCommand represents the ship order command
+type ShipOrderCommand struct {
+	ID uuid.UUID `json:"id" validate:"required"`
+}
+
+// Validate validates the ship command
+func (c *ShipOrderCommand) Validate() error {
+	if c.ID == uuid.Nil {
+		return ErrInvalidID
+	}
+	return nil
+}
+
+// DeliverOrderCommand represents the deliver order command
+type DeliverOrderCommand struct {
+	ID uuid.UUID `json:"id" validate:"required"`
+}
+
+// Validate validates the deliver command
+func (c *DeliverOrderCommand) Validate() error {
+	if c.ID == uuid.Nil {
+		return ErrInvalidID
+	}
+	return nil
+}
+
+// CancelOrderCommand represents the cancel order command
+type CancelOrderCommand struct {
+	ID uuid.UUID `json:"id" validate:"required"`
+}
+
+// Validate validates the cancel command
+func (c *CancelOrderCommand) Validate() error {
+	if c.ID == uuid.Nil {
+		return ErrInvalidID
+	}
+	return nil
+}
+
+// validateStatus records an error unless status is a known order status
+func validateStatus(fields domain.FieldErrors, status string) {
+	if status == "" {
+		fields.Add("status", "This field is required")
+	} else if !entity.IsValidOrderStatus(status) {
+		fields.Add("status", "Must be a known order status")
+	}
+}
+
+// amountOrZero returns the optional amount m, or zero if it is not set
+func amountOrZero(m *domain.Money) domain.Money {
+	if m == nil {
+		return domain.Money{}
+	}
+	return *m
+}
+
+// validateCharges records an error for each negative order charge
+func validateCharges(fields domain.FieldErrors, discount, tax, shipping domain.Money) {
+	for field, charge := range map[string]domain.Money{"discount": discount, "tax": tax, "shipping": shipping} {
+		if charge.IsNegative() {
+			fields.Add(field, "Must not be negative")
+		}
+	}
+}
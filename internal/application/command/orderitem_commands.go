@@ -3,21 +3,27 @@ package command
 
 import (
 	"github.com/google/uuid"
+	"github.com/telemetryflow/order-service/internal/domain"
 	"github.com/telemetryflow/order-service/internal/domain/entity"
 )
 
 // CreateOrderitemCommand represents the create orderitem command
 type CreateOrderitemCommand struct {
-	OrderID   uuid.UUID `json:"order_id" validate:"required"`
-	ProductID uuid.UUID `json:"product_id" validate:"required"`
-	Quantity  int       `json:"quantity" validate:"required"`
-	Price     float64   `json:"price" validate:"required"`
+	OrderID   uuid.UUID    `json:"order_id" validate:"required"`
+	ProductID uuid.UUID    `json:"product_id" validate:"required"`
+	Quantity  int          `json:"quantity" validate:"gte=1"`
+	Price     domain.Money `json:"price"`
 }
 
-// Validate validates the create command
+// Validate validates the create command.
+// Field errors are returned as a *validator.ValidationError.
 func (c *CreateOrderitemCommand) Validate() error {
-	// Add validation logic
-	return nil
+	fields := domain.FieldErrors{}
+	if c.OrderID == uuid.Nil {
+		fields.Add("order_id", "This field is required")
+	}
+	validateItem(fields, "", c.ProductID, c.Quantity, c.Price)
+	return validationError(fields)
 }
 
 // ToEntity converts the command to an entity
@@ -25,21 +31,32 @@ func (c *CreateOrderitemCommand) ToEntity() *entity.Orderitem {
 	return entity.NewOrderitem(c.OrderID, c.ProductID, c.Quantity, c.Price)
 }
 
-// UpdateOrderitemCommand represents the update orderitem command
+// UpdateOrderitemCommand represents the update orderitem command.
+// Version is the version the caller expects; zero skips the check.
+// ParentOrderID, when set, is the order the item must currently belong to;
+// items of other orders are not found.
 type UpdateOrderitemCommand struct {
-	ID        uuid.UUID `json:"id" validate:"required"`
-	OrderID   uuid.UUID `json:"order_id" validate:"required"`
-	ProductID uuid.UUID `json:"product_id" validate:"required"`
-	Quantity  int       `json:"quantity" validate:"required"`
-	Price     float64   `json:"price" validate:"required"`
+	ID            uuid.UUID    `json:"id" validate:"required"`
+	Version       int64        `json:"version"`
+	ParentOrderID uuid.UUID    `json:"parent_order_id"`
+	OrderID       uuid.UUID    `json:"order_id" validate:"required"`
+	ProductID     uuid.UUID    `json:"product_id" validate:"required"`
+	Quantity      int          `json:"quantity" validate:"gte=1"`
+	Price         domain.Money `json:"price"`
 }
 
-// Validate validates the update command
+// Validate validates the update command.
+// Field errors are returned as a *validator.ValidationError.
 func (c *UpdateOrderitemCommand) Validate() error {
 	if c.ID == uuid.Nil {
 		return ErrInvalidID
 	}
-	return nil
+	fields := domain.FieldErrors{}
+	if c.OrderID == uuid.Nil {
+		fields.Add("order_id", "This field is required")
+	}
+	validateItem(fields, "", c.ProductID, c.Quantity, c.Price)
+	return validationError(fields)
 }
 
 // ToEntity converts the command to an entity
@@ -49,9 +66,14 @@ func (c *UpdateOrderitemCommand) ToEntity() *entity.Orderitem {
 	return e
 }
 
-// DeleteOrderitemCommand represents the delete orderitem command
+// DeleteOrderitemCommand represents the delete orderitem command.
+// Version is the version the caller expects; zero skips the check.
+// ParentOrderID, when set, is the order the item must belong to; items of
+// other orders are not found.
 type DeleteOrderitemCommand struct {
-	ID uuid.UUID `json:"id" validate:"required"`
+	ID            uuid.UUID `json:"id" validate:"required"`
+	Version       int64     `json:"version"`
+	ParentOrderID uuid.UUID `json:"parent_order_id"`
 }
 
 // Validate validates the delete command
@@ -61,3 +83,17 @@ func (c *DeleteOrderitemCommand) Validate() error {
 	}
 	return nil
 }
+
+// validateItem records the errors of an item's product, quantity and price
+// under prefix
+func validateItem(fields domain.FieldErrors, prefix string, productID uuid.UUID, quantity int, price domain.Money) {
+	if productID == uuid.Nil {
+		fields.Add(prefix+"product_id", "This field is required")
+	}
+	if quantity < 1 {
+		fields.Add(prefix+"quantity", "Must be greater than or equal to 1")
+	}
+	if price.IsNegative() {
+		fields.Add(prefix+"price", "Must not be negative")
+	}
+}
@@ -5,26 +5,50 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/telemetryflow/order-service/internal/domain"
 	"github.com/telemetryflow/order-service/internal/domain/entity"
 )
 
 // OrderResponse represents the order API response
 type OrderResponse struct {
-	ID         uuid.UUID `json:"id"`
-	CustomerID uuid.UUID `json:"customer_id"`
-	Total      float64   `json:"total"`
-	Status     string    `json:"status"`
-	CreatedAt  time.Time `json:"created_at"`
-	UpdatedAt  time.Time `json:"updated_at"`
+	ID         uuid.UUID           `json:"id"`
+	Version    int64               `json:"version"`
+	CustomerID uuid.UUID           `json:"customer_id"`
+	Currency   string              `json:"currency"`
+	Subtotal   domain.Money        `json:"subtotal"`
+	Discount   domain.Money        `json:"discount"`
+	Tax        domain.Money        `json:"tax"`
+	Shipping   domain.Money        `json:"shipping"`
+	Total      domain.Money        `json:"total"`
+	Status     string              `json:"status"`
+	Tags       []string            `json:"tags"`
+	Notes      string              `json:"notes"`
+	Items      []OrderitemResponse `json:"items,omitempty"`
+	CreatedAt  time.Time           `json:"created_at"`
+	UpdatedAt  time.Time           `json:"updated_at"`
 }
 
-// FromOrder converts entity to response DTO
+// FromOrder converts entity to response DTO.
+// Items are included when they are loaded on the entity.
 func FromOrder(e *entity.Order) OrderResponse {
+	var items []OrderitemResponse
+	if len(e.Items) > 0 {
+		items = FromOrderitems(e.Items)
+	}
 	return OrderResponse{
 		ID:         e.ID,
+		Version:    e.Version,
 		CustomerID: e.CustomerID,
+		Currency:   e.Currency,
+		Subtotal:   e.Subtotal,
+		Discount:   e.Discount,
+		Tax:        e.Tax,
+		Shipping:   e.Shipping,
 		Total:      e.Total,
 		Status:     e.Status,
+		Tags:       e.Tags,
+		Notes:      e.Notes,
+		Items:      items,
 		CreatedAt:  e.CreatedAt,
 		UpdatedAt:  e.UpdatedAt,
 	}
@@ -39,18 +63,60 @@ func FromOrders(entities []entity.Order) []OrderResponse {
 	return responses
 }
 
-// CreateOrderRequest represents the create order request
+// CreateOrderRequest represents the create order request.
+// Total is optional and is checked against the server-computed total.
+// Items are created atomically with the order.
 type CreateOrderRequest struct {
-	CustomerID uuid.UUID `json:"customer_id" validate:"required"`
-	Total      float64   `json:"total" validate:"required"`
-	Status     string    `json:"status" validate:"required"`
+	CustomerID uuid.UUID                `json:"customer_id" validate:"required"`
+	Currency   string                   `json:"currency" validate:"omitempty,len=3"`
+	Discount   domain.Money             `json:"discount"`
+	Tax        domain.Money             `json:"tax"`
+	Shipping   domain.Money             `json:"shipping"`
+	Total      *domain.Money            `json:"total"`
+	Status     string                   `json:"status"`
+	Tags       []string                 `json:"tags" validate:"max=20,dive,max=50"`
+	Notes      string                   `json:"notes" validate:"max=2000"`
+	Items      []CreateOrderItemRequest `json:"items" validate:"dive"`
+}
+
+// CreateOrderItemRequest represents an item in the create order request
+type CreateOrderItemRequest struct {
+	ProductID uuid.UUID    `json:"product_id" validate:"required"`
+	Quantity  int          `json:"quantity" validate:"gte=1"`
+	Price     domain.Money `json:"price"`
 }
 
-// UpdateOrderRequest represents the update order request
+// UpdateOrderRequest represents the update order request.
+// Total is optional and is checked against the server-computed total.
 type UpdateOrderRequest struct {
-	CustomerID uuid.UUID `json:"customer_id" validate:"required"`
-	Total      float64   `json:"total" validate:"required"`
-	Status     string    `json:"status" validate:"required"`
+	CustomerID uuid.UUID     `json:"customer_id" validate:"required"`
+	Discount   domain.Money  `json:"discount"`
+	Tax        domain.Money  `json:"tax"`
+	Shipping   domain.Money  `json:"shipping"`
+	Total      *domain.Money `json:"total"`
+	Status     string        `json:"status" validate:"required"`
+	Tags       []string      `json:"tags" validate:"max=20,dive,max=50"`
+	Notes      string        `json:"notes" validate:"max=2000"`
+}
+
+// UpdateOrderRequestFrom returns the update request that leaves the order
+// unchanged, which PATCH documents are applied to. Total is left unset, so
+// it is only verified when the patch sets it. Tags is never null, so patches
+// can append to it.
+func UpdateOrderRequestFrom(r *OrderResponse) UpdateOrderRequest {
+	tags := r.Tags
+	if tags == nil {
+		tags = []string{}
+	}
+	return UpdateOrderRequest{
+		CustomerID: r.CustomerID,
+		Discount:   r.Discount,
+		Tax:        r.Tax,
+		Shipping:   r.Shipping,
+		Status:     r.Status,
+		Tags:       tags,
+		Notes:      r.Notes,
+	}
 }
 
 // OrderToResponse converts entity pointer to response DTO pointer
@@ -69,3 +135,20 @@ type OrderListResponse struct {
 	Offset int              `json:"offset"`
 	Limit  int              `json:"limit"`
 }
+
+// OrderSearchResult represents an order matching a full-text search
+type OrderSearchResult struct {
+	*OrderResponse
+	Rank float64 `json:"rank"`
+	// Highlights maps matched fields to fragments with the matched terms
+	// enclosed in <mark> and </mark>. Fragments are HTML-escaped.
+	Highlights map[string]string `json:"highlights"`
+}
+
+// OrderSearchResponse represents the search orders API response
+type OrderSearchResponse struct {
+	Data   []*OrderSearchResult `json:"data"`
+	Total  int                  `json:"total"`
+	Offset int                  `json:"offset"`
+	Limit  int                  `json:"limit"`
+}
@@ -5,24 +5,27 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/telemetryflow/order-service/internal/domain"
 	"github.com/telemetryflow/order-service/internal/domain/entity"
 )
 
 // OrderitemResponse represents the orderitem API response
 type OrderitemResponse struct {
-	ID        uuid.UUID `json:"id"`
-	OrderID   uuid.UUID `json:"order_id"`
-	ProductID uuid.UUID `json:"product_id"`
-	Quantity  int       `json:"quantity"`
-	Price     float64   `json:"price"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID        uuid.UUID    `json:"id"`
+	Version   int64        `json:"version"`
+	OrderID   uuid.UUID    `json:"order_id"`
+	ProductID uuid.UUID    `json:"product_id"`
+	Quantity  int          `json:"quantity"`
+	Price     domain.Money `json:"price"`
+	CreatedAt time.Time    `json:"created_at"`
+	UpdatedAt time.Time    `json:"updated_at"`
 }
 
 // FromOrderitem converts entity to response DTO
 func FromOrderitem(e *entity.Orderitem) OrderitemResponse {
 	return OrderitemResponse{
 		ID:        e.ID,
+		Version:   e.Version,
 		OrderID:   e.OrderID,
 		ProductID: e.ProductID,
 		Quantity:  e.Quantity,
@@ -43,18 +46,37 @@ func FromOrderitems(entities []entity.Orderitem) []OrderitemResponse {
 
 // CreateOrderitemRequest represents the create orderitem request
 type CreateOrderitemRequest struct {
-	OrderID   uuid.UUID `json:"order_id" validate:"required"`
-	ProductID uuid.UUID `json:"product_id" validate:"required"`
-	Quantity  int       `json:"quantity" validate:"required"`
-	Price     float64   `json:"price" validate:"required"`
+	OrderID   uuid.UUID    `json:"order_id" validate:"required"`
+	ProductID uuid.UUID    `json:"product_id" validate:"required"`
+	Quantity  int          `json:"quantity" validate:"gte=1"`
+	Price     domain.Money `json:"price"`
 }
 
 // UpdateOrderitemRequest represents the update orderitem request
 type UpdateOrderitemRequest struct {
-	OrderID   uuid.UUID `json:"order_id" validate:"required"`
-	ProductID uuid.UUID `json:"product_id" validate:"required"`
-	Quantity  int       `json:"quantity" validate:"required"`
-	Price     float64   `json:"price" validate:"required"`
+	OrderID   uuid.UUID    `json:"order_id" validate:"required"`
+	ProductID uuid.UUID    `json:"product_id" validate:"required"`
+	Quantity  int          `json:"quantity" validate:"gte=1"`
+	Price     domain.Money `json:"price"`
+}
+
+// UpdateOrderitemRequestFrom returns the update request that leaves the
+// item unchanged, which PATCH documents are applied to
+func UpdateOrderitemRequestFrom(r *OrderitemResponse) UpdateOrderitemRequest {
+	return UpdateOrderitemRequest{
+		OrderID:   r.OrderID,
+		ProductID: r.ProductID,
+		Quantity:  r.Quantity,
+		Price:     r.Price,
+	}
+}
+
+// OrderItemLineRequest represents the create and update requests of an
+// item nested under its order, which is given by the path
+type OrderItemLineRequest struct {
+	ProductID uuid.UUID    `json:"product_id" validate:"required"`
+	Quantity  int          `json:"quantity" validate:"gte=1"`
+	Price     domain.Money `json:"price"`
 }
 
 // OrderitemToResponse converts entity pointer to response DTO pointer
@@ -0,0 +1,11 @@
+// Package dto contains the keyset pagination DTO.
+package dto
+
+// CursorPage is a page of a keyset-paginated listing. The cursors are
+// empty when there is no page in that direction.
+type CursorPage[T any] struct {
+	Data       []T    `json:"data"`
+	Limit      int    `json:"limit"`
+	NextCursor string `json:"next_cursor,omitempty"`
+	PrevCursor string `json:"prev_cursor,omitempty"`
+}
@@ -0,0 +1,85 @@
+// Package eventbus provides in-process publication of domain events.
+package eventbus
+
+import (
+	"context"
+	"log"
+	"reflect"
+	"sync"
+
+	"github.com/telemetryflow/order-service/internal/domain/event"
+)
+
+// Publisher publishes domain events to interested subscribers
+type Publisher interface {
+	// Publish delivers events to their subscribers. Command handlers call it
+	// after the changes that raised the events have been committed.
+	Publish(ctx context.Context, events ...event.Event)
+}
+
+// HandlerFunc handles a domain event
+type HandlerFunc func(ctx context.Context, e event.Event) error
+
+// ErrorHandler is called when a subscriber fails
+type ErrorHandler func(ctx context.Context, e event.Event, err error)
+
+// Dispatcher is an in-process Publisher that calls subscribers synchronously,
+// in registration order. A failing subscriber does not stop the others.
+type Dispatcher struct {
+	mu       sync.RWMutex
+	handlers map[reflect.Type][]HandlerFunc
+	all      []HandlerFunc
+	onError  ErrorHandler
+}
+
+// NewDispatcher creates a new Dispatcher that logs subscriber errors
+func NewDispatcher() *Dispatcher {
+	return &Dispatcher{
+		handlers: make(map[reflect.Type][]HandlerFunc),
+		onError: func(_ context.Context, e event.Event, err error) {
+			log.Printf("event subscriber for %s failed: %v", e.EventName(), err)
+		},
+	}
+}
+
+// OnError replaces the handler for subscriber errors
+func (d *Dispatcher) OnError(fn ErrorHandler) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.onError = fn
+}
+
+// Subscribe registers fn for events of the concrete type E
+func Subscribe[E event.Event](d *Dispatcher, fn func(ctx context.Context, e E) error) {
+	key := reflect.TypeOf((*E)(nil)).Elem()
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.handlers[key] = append(d.handlers[key], func(ctx context.Context, e event.Event) error {
+		return fn(ctx, e.(E))
+	})
+}
+
+// SubscribeAll registers fn for every event
+func (d *Dispatcher) SubscribeAll(fn HandlerFunc) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.all = append(d.all, fn)
+}
+
+// Publish delivers each event to its typed subscribers, then to the
+// subscribers registered for all events
+func (d *Dispatcher) Publish(ctx context.Context, events ...event.Event) {
+	for _, e := range events {
+		d.mu.RLock()
+		handlers := append(append([]HandlerFunc(nil), d.handlers[reflect.TypeOf(e)]...), d.all...)
+		onError := d.onError
+		d.mu.RUnlock()
+
+		for _, handle := range handlers {
+			if err := handle(ctx, e); err != nil {
+				onError(ctx, e, err)
+			}
+		}
+	}
+}
@@ -6,36 +6,222 @@ package handler
 
 import (
 	"context"
+	"errors"
 
+	"github.com/google/uuid"
+	"github.com/telemetryflow/order-service/internal/application/bus"
 	"github.com/telemetryflow/order-service/internal/application/command"
+	"github.com/telemetryflow/order-service/internal/application/eventbus"
+	"github.com/telemetryflow/order-service/internal/domain"
+	"github.com/telemetryflow/order-service/internal/domain/entity"
+	"github.com/telemetryflow/order-service/internal/domain/event"
 	"github.com/telemetryflow/order-service/internal/domain/repository"
 )
 
 // OrderCommandHandler handles commands for Order entity
 type OrderCommandHandler struct {
-	repo repository.OrderRepository
+	repo     repository.OrderRepository
+	itemRepo repository.OrderitemRepository
+	uow      repository.UnitOfWork
+	outbox   repository.OutboxRepository
+	events   eventbus.Publisher
 }
 
-// NewOrderCommandHandler creates a new Order command handler
-func NewOrderCommandHandler(repo repository.OrderRepository) *OrderCommandHandler {
+// NewOrderCommandHandler creates a new Order command handler.
+// Domain events raised by the order are written to the outbox in the same
+// transaction and published in-process after a successful commit.
+func NewOrderCommandHandler(repo repository.OrderRepository, itemRepo repository.OrderitemRepository, uow repository.UnitOfWork, outbox repository.OutboxRepository, events eventbus.Publisher) *OrderCommandHandler {
 	return &OrderCommandHandler{
-		repo: repo,
+		repo:     repo,
+		itemRepo: itemRepo,
+		uow:      uow,
+		outbox:   outbox,
+		events:   events,
 	}
 }
 
-// HandleOrderCreate handles create order command
-func (h *OrderCommandHandler) HandleOrderCreate(ctx context.Context, cmd *command.CreateOrderCommand) error {
-	entity := cmd.ToEntity()
-	return h.repo.Create(ctx, entity)
+// Register registers the handler's commands on b
+func (h *OrderCommandHandler) Register(b *bus.CommandBus) {
+	bus.RegisterCommand(b, h.HandleOrderCreate)
+	bus.RegisterCommand(b, h.HandleOrderUpdate)
+	bus.RegisterCommand(b, bus.Void(h.HandleOrderDelete))
+	bus.RegisterCommand(b, bus.Void(h.HandleOrderConfirm))
+	bus.RegisterCommand(b, bus.Void(h.HandleOrderPay))
+	bus.RegisterCommand(b, bus.Void(h.HandleOrderShip))
+	bus.RegisterCommand(b, bus.Void(h.HandleOrderDeliver))
+	bus.RegisterCommand(b, bus.Void(h.HandleOrderCancel))
 }
 
-// HandleOrderUpdate handles update order command
-func (h *OrderCommandHandler) HandleOrderUpdate(ctx context.Context, cmd *command.UpdateOrderCommand) error {
-	entity := cmd.ToEntity()
-	return h.repo.Update(ctx, entity)
+// HandleOrderCreate handles create order command.
+// The order and its items are validated and persisted atomically.
+func (h *OrderCommandHandler) HandleOrderCreate(ctx context.Context, cmd *command.CreateOrderCommand) (command.CommandResult, error) {
+	if err := cmd.Validate(); err != nil {
+		return command.CommandResult{}, err
+	}
+
+	order := cmd.ToEntity()
+	if err := order.SetCharges(cmd.Discount, cmd.Tax, cmd.Shipping); err != nil {
+		return command.CommandResult{}, err
+	}
+	if err := order.VerifyTotal(cmd.Total); err != nil {
+		return command.CommandResult{}, err
+	}
+	if err := order.Validate(); err != nil {
+		return command.CommandResult{}, err
+	}
+
+	events := order.PullEvents()
+	err := h.uow.Do(ctx, func(ctx context.Context) error {
+		if err := h.repo.Create(ctx, order); err != nil {
+			return err
+		}
+		if len(order.Items) > 0 {
+			if err := h.itemRepo.CreateBatch(ctx, order.Items); err != nil {
+				return err
+			}
+		}
+		return h.appendEvents(ctx, events)
+	})
+	if err != nil {
+		return command.CommandResult{}, err
+	}
+	return command.NewEntityResult(&order.Base), nil
 }
 
-// HandleOrderDelete handles delete order command
+// HandleOrderUpdate handles update order command.
+// A status change is only applied if the order state machine allows it,
+// and the total is recomputed from the order items. The result carries
+// the new version of the order.
+func (h *OrderCommandHandler) HandleOrderUpdate(ctx context.Context, cmd *command.UpdateOrderCommand) (command.CommandResult, error) {
+	order, err := h.repo.FindWithItems(ctx, cmd.ID)
+	if err != nil {
+		return command.CommandResult{}, commandLookupError(err)
+	}
+	if err := checkVersion(order.Version, cmd.Version); err != nil {
+		return command.CommandResult{}, err
+	}
+
+	if cmd.Status != order.Status {
+		if err := order.TransitionTo(cmd.Status); err != nil {
+			return command.CommandResult{}, err
+		}
+	}
+
+	order.Update(cmd.CustomerID, order.Total, order.Status)
+	order.Annotate(cmd.Tags, cmd.Notes)
+	if err := order.SetCharges(cmd.Discount, cmd.Tax, cmd.Shipping); err != nil {
+		return command.CommandResult{}, err
+	}
+	if err := order.VerifyTotal(cmd.Total); err != nil {
+		return command.CommandResult{}, err
+	}
+	if err := order.Validate(); err != nil {
+		return command.CommandResult{}, err
+	}
+	if err := h.save(ctx, order); err != nil {
+		return command.CommandResult{}, err
+	}
+	return command.NewEntityResult(&order.Base), nil
+}
+
+// HandleOrderDelete handles delete order command.
+// When cmd.Version is set, the order must still have that version.
+// The deletion is recorded as an OrderDeleted event.
 func (h *OrderCommandHandler) HandleOrderDelete(ctx context.Context, cmd *command.DeleteOrderCommand) error {
-	return h.repo.Delete(ctx, cmd.ID)
+	return h.uow.Do(ctx, func(ctx context.Context) error {
+		order, err := h.repo.FindByID(ctx, cmd.ID)
+		if err != nil {
+			return commandLookupError(err)
+		}
+		if err := checkVersion(order.Version, cmd.Version); err != nil {
+			return err
+		}
+
+		order.Remove()
+		if err := h.repo.Delete(ctx, cmd.ID); err != nil {
+			return err
+		}
+		return h.appendEvents(ctx, order.PullEvents())
+	})
+}
+
+// HandleOrderConfirm handles confirm order command
+func (h *OrderCommandHandler) HandleOrderConfirm(ctx context.Context, cmd *command.ConfirmOrderCommand) error {
+	return h.transition(ctx, cmd.ID, (*entity.Order).Confirm)
+}
+
+// HandleOrderPay handles pay order command
+func (h *OrderCommandHandler) HandleOrderPay(ctx context.Context, cmd *command.PayOrderCommand) error {
+	return h.transition(ctx, cmd.ID, (*entity.Order).Pay)
+}
+
+// HandleOrderShip handles ship order command
+func (h *OrderCommandHandler) HandleOrderShip(ctx context.Context, cmd *command.ShipOrderCommand) error {
+	return h.transition(ctx, cmd.ID, (*entity.Order).Ship)
+}
+
+// HandleOrderDeliver handles deliver order command
+func (h *OrderCommandHandler) HandleOrderDeliver(ctx context.Context, cmd *command.DeliverOrderCommand) error {
+	return h.transition(ctx, cmd.ID, (*entity.Order).Deliver)
+}
+
+// HandleOrderCancel handles cancel order command
+func (h *OrderCommandHandler) HandleOrderCancel(ctx context.Context, cmd *command.CancelOrderCommand) error {
+	return h.transition(ctx, cmd.ID, (*entity.Order).Cancel)
+}
+
+// transition loads an order, applies a status transition and persists it
+func (h *OrderCommandHandler) transition(ctx context.Context, id uuid.UUID, apply func(*entity.Order) error) error {
+	order, err := h.repo.FindByID(ctx, id)
+	if err != nil {
+		return commandLookupError(err)
+	}
+
+	if err := apply(order); err != nil {
+		return err
+	}
+
+	return h.save(ctx, order)
+}
+
+// save persists an order together with the events it raised and
+// publishes them after commit
+func (h *OrderCommandHandler) save(ctx context.Context, order *entity.Order) error {
+	events := order.PullEvents()
+	return h.uow.Do(ctx, func(ctx context.Context) error {
+		if err := h.repo.Update(ctx, order); err != nil {
+			return err
+		}
+		return h.appendEvents(ctx, events)
+	})
+}
+
+// appendEvents stores events in the outbox of the unit of work in ctx and
+// publishes them once it has committed
+func (h *OrderCommandHandler) appendEvents(ctx context.Context, events []event.Event) error {
+	if err := h.outbox.Append(ctx, events...); err != nil {
+		return err
+	}
+	repository.AfterCommit(ctx, func(ctx context.Context) {
+		h.events.Publish(ctx, events...)
+	})
+	return nil
+}
+
+// checkVersion returns command.ErrPreconditionFailed when expected is set
+// and differs from the current version
+func checkVersion(current, expected int64) error {
+	if expected != 0 && expected != current {
+		return command.ErrPreconditionFailed
+	}
+	return nil
+}
+
+// commandLookupError converts a repository not-found error into
+// command.ErrNotFound and returns any other error unchanged
+func commandLookupError(err error) error {
+	if errors.Is(err, domain.ErrEntityNotFound) {
+		return command.ErrNotFound
+	}
+	return err
 }
@@ -6,36 +6,61 @@ package handler
 
 import (
 	"context"
+	"errors"
 
+	"github.com/telemetryflow/order-service/internal/application/bus"
 	"github.com/telemetryflow/order-service/internal/application/dto"
 	"github.com/telemetryflow/order-service/internal/application/query"
+	"github.com/telemetryflow/order-service/internal/domain"
+	"github.com/telemetryflow/order-service/internal/domain/entity"
 	"github.com/telemetryflow/order-service/internal/domain/repository"
+	"github.com/telemetryflow/order-service/pkg/pagination"
 )
 
-// OrderQueryHandler handles queries for Order entity
+// OrderQueryHandler handles queries for Order entity.
+// Its reads may be served from a read replica when dispatched through the
+// query bus (see bus.Ask).
 type OrderQueryHandler struct {
-	repo repository.OrderRepository
+	repo    repository.OrderRepository
+	cursors *pagination.Codec
 }
 
-// NewOrderQueryHandler creates a new Order query handler
-func NewOrderQueryHandler(repo repository.OrderRepository) *OrderQueryHandler {
+// NewOrderQueryHandler creates a new Order query handler.
+// cursors signs and verifies keyset pagination cursors.
+func NewOrderQueryHandler(repo repository.OrderRepository, cursors *pagination.Codec) *OrderQueryHandler {
 	return &OrderQueryHandler{
-		repo: repo,
+		repo:    repo,
+		cursors: cursors,
 	}
 }
 
+// Register registers the handler's queries on b
+func (h *OrderQueryHandler) Register(b *bus.QueryBus) {
+	bus.RegisterQuery(b, h.HandleOrderGetByID)
+	bus.RegisterQuery(b, h.HandleOrderGetAll)
+	bus.RegisterQuery(b, h.HandleOrderSearch)
+	bus.RegisterQuery(b, func(ctx context.Context, qry *query.GetOrdersPageQuery) (*dto.CursorPage[*dto.OrderResponse], error) {
+		return h.HandleOrderGetPage(ctx, &qry.GetAllOrdersQuery)
+	})
+}
+
 // HandleOrderGetByID handles get order by ID query
 func (h *OrderQueryHandler) HandleOrderGetByID(ctx context.Context, qry *query.GetOrderByIDQuery) (*dto.OrderResponse, error) {
-	entity, err := h.repo.FindByID(ctx, qry.ID)
+	find := h.repo.FindByID
+	if qry.WithItems {
+		find = h.repo.FindWithItems
+	}
+	entity, err := find(ctx, qry.ID)
 	if err != nil {
-		return nil, err
+		return nil, queryLookupError(err)
 	}
 	return dto.OrderToResponse(entity), nil
 }
 
-// HandleOrderGetAll handles get all orders query
+// HandleOrderGetAll handles get all orders query.
+// The query must have been validated.
 func (h *OrderQueryHandler) HandleOrderGetAll(ctx context.Context, qry *query.GetAllOrdersQuery) (*dto.OrderListResponse, error) {
-	entities, total, err := h.repo.FindAll(ctx, qry.Offset, qry.Limit)
+	entities, total, err := h.repo.List(ctx, qry.Filter())
 	if err != nil {
 		return nil, err
 	}
@@ -52,3 +77,57 @@ func (h *OrderQueryHandler) HandleOrderGetAll(ctx context.Context, qry *query.Ge
 		Limit:  qry.Limit,
 	}, nil
 }
+
+// HandleOrderSearch handles search orders query.
+// The query must have been validated.
+func (h *OrderQueryHandler) HandleOrderSearch(ctx context.Context, qry *query.SearchOrdersQuery) (*dto.OrderSearchResponse, error) {
+	hits, total, err := h.repo.Search(ctx, qry.Query, qry.Offset, qry.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]*dto.OrderSearchResult, len(hits))
+	for i := range hits {
+		results[i] = &dto.OrderSearchResult{
+			OrderResponse: dto.OrderToResponse(&hits[i].Order),
+			Rank:          hits[i].Rank,
+			Highlights:    hits[i].Highlights,
+		}
+	}
+
+	return &dto.OrderSearchResponse{
+		Data:   results,
+		Total:  int(total),
+		Offset: qry.Offset,
+		Limit:  qry.Limit,
+	}, nil
+}
+
+// HandleOrderGetPage handles get all orders query in cursor mode.
+// The query must have been validated.
+func (h *OrderQueryHandler) HandleOrderGetPage(ctx context.Context, qry *query.GetAllOrdersQuery) (*dto.CursorPage[*dto.OrderResponse], error) {
+	filter := qry.Filter()
+	page, err := keysetPage(h.cursors, *qry.Cursor, qry.CursorScope(), qry.Limit, filter.Sort.Direction)
+	if err != nil {
+		return nil, err
+	}
+
+	orders, more, err := h.repo.FindPage(ctx, filter, page)
+	if err != nil {
+		return nil, err
+	}
+
+	return cursorPage(h.cursors, page, qry.CursorScope(), orders, more,
+		func(o *entity.Order) *entity.Base { return &o.Base },
+		dto.OrderToResponse,
+	), nil
+}
+
+// queryLookupError converts a repository not-found error into
+// query.ErrNotFound and returns any other error unchanged
+func queryLookupError(err error) error {
+	if errors.Is(err, domain.ErrEntityNotFound) {
+		return query.ErrNotFound
+	}
+	return err
+}
@@ -6,36 +6,233 @@ package handler
 
 import (
 	"context"
+	"errors"
 
+	"github.com/google/uuid"
+	"github.com/telemetryflow/order-service/internal/application/bus"
 	"github.com/telemetryflow/order-service/internal/application/command"
+	"github.com/telemetryflow/order-service/internal/application/eventbus"
+	"github.com/telemetryflow/order-service/internal/domain/entity"
+	"github.com/telemetryflow/order-service/internal/domain/event"
 	"github.com/telemetryflow/order-service/internal/domain/repository"
 )
 
 // OrderitemCommandHandler handles commands for Orderitem entity
 type OrderitemCommandHandler struct {
-	repo repository.OrderitemRepository
+	repo      repository.OrderitemRepository
+	orderRepo repository.OrderRepository
+	uow       repository.UnitOfWork
+	outbox    repository.OutboxRepository
+	events    eventbus.Publisher
 }
 
-// NewOrderitemCommandHandler creates a new Orderitem command handler
-func NewOrderitemCommandHandler(repo repository.OrderitemRepository) *OrderitemCommandHandler {
+// NewOrderitemCommandHandler creates a new Orderitem command handler.
+// Domain events raised by the item are written to the outbox in the same
+// transaction and published in-process after a successful commit.
+func NewOrderitemCommandHandler(repo repository.OrderitemRepository, orderRepo repository.OrderRepository, uow repository.UnitOfWork, outbox repository.OutboxRepository, events eventbus.Publisher) *OrderitemCommandHandler {
 	return &OrderitemCommandHandler{
-		repo: repo,
+		repo:      repo,
+		orderRepo: orderRepo,
+		uow:       uow,
+		outbox:    outbox,
+		events:    events,
 	}
 }
 
-// HandleOrderitemCreate handles create orderitem command
-func (h *OrderitemCommandHandler) HandleOrderitemCreate(ctx context.Context, cmd *command.CreateOrderitemCommand) error {
-	entity := cmd.ToEntity()
-	return h.repo.Create(ctx, entity)
+// Register registers the handler's commands on b
+func (h *OrderitemCommandHandler) Register(b *bus.CommandBus) {
+	bus.RegisterCommand(b, h.HandleOrderitemCreate)
+	bus.RegisterCommand(b, h.HandleOrderitemUpdate)
+	bus.RegisterCommand(b, bus.Void(h.HandleOrderitemDelete))
 }
 
-// HandleOrderitemUpdate handles update orderitem command
-func (h *OrderitemCommandHandler) HandleOrderitemUpdate(ctx context.Context, cmd *command.UpdateOrderitemCommand) error {
-	entity := cmd.ToEntity()
-	return h.repo.Update(ctx, entity)
+// HandleOrderitemCreate handles create orderitem command.
+// The item and the parent order total are saved in one unit of work.
+func (h *OrderitemCommandHandler) HandleOrderitemCreate(ctx context.Context, cmd *command.CreateOrderitemCommand) (command.CommandResult, error) {
+	var item *entity.Orderitem
+	err := h.run(ctx, func(ctx context.Context) (events []event.Event, err error) {
+		item, events, err = h.create(ctx, cmd)
+		return events, err
+	})
+	if err != nil {
+		return command.CommandResult{}, err
+	}
+	return command.NewEntityResult(&item.Base), nil
+}
+
+// HandleOrderitemUpdate handles update orderitem command.
+// When the item moves to another order, both order totals are recalculated
+// in the same unit of work. The result carries the new version of the item.
+func (h *OrderitemCommandHandler) HandleOrderitemUpdate(ctx context.Context, cmd *command.UpdateOrderitemCommand) (command.CommandResult, error) {
+	var item *entity.Orderitem
+	err := h.run(ctx, func(ctx context.Context) (events []event.Event, err error) {
+		item, events, err = h.update(ctx, cmd)
+		return events, err
+	})
+	if err != nil {
+		return command.CommandResult{}, err
+	}
+	return command.NewEntityResult(&item.Base), nil
 }
 
 // HandleOrderitemDelete handles delete orderitem command
 func (h *OrderitemCommandHandler) HandleOrderitemDelete(ctx context.Context, cmd *command.DeleteOrderitemCommand) error {
-	return h.repo.Delete(ctx, cmd.ID)
+	return h.run(ctx, func(ctx context.Context) ([]event.Event, error) {
+		return h.delete(ctx, cmd)
+	})
+}
+
+// run executes work in a unit of work, stores its events in the outbox
+// and publishes them after commit
+func (h *OrderitemCommandHandler) run(ctx context.Context, work func(ctx context.Context) ([]event.Event, error)) error {
+	return h.uow.Do(ctx, func(ctx context.Context) error {
+		events, err := work(ctx)
+		if err != nil {
+			return err
+		}
+		if err := h.outbox.Append(ctx, events...); err != nil {
+			return err
+		}
+		repository.AfterCommit(ctx, func(ctx context.Context) {
+			h.events.Publish(ctx, events...)
+		})
+		return nil
+	})
+}
+
+// create adds an item and recalculates its order total. It returns the
+// item and the events it raised.
+func (h *OrderitemCommandHandler) create(ctx context.Context, cmd *command.CreateOrderitemCommand) (*entity.Orderitem, []event.Event, error) {
+	if err := h.checkOrderEditable(ctx, cmd.OrderID); err != nil {
+		return nil, nil, err
+	}
+
+	item := cmd.ToEntity()
+	if err := item.Validate(); err != nil {
+		return nil, nil, err
+	}
+	if err := h.repo.Create(ctx, item); err != nil {
+		return nil, nil, err
+	}
+	if err := h.recalculateOrderTotal(ctx, item.OrderID); err != nil {
+		return nil, nil, err
+	}
+	return item, item.PullEvents(), nil
+}
+
+// update changes an item and recalculates the affected order totals.
+// It returns the item and the events it raised.
+func (h *OrderitemCommandHandler) update(ctx context.Context, cmd *command.UpdateOrderitemCommand) (*entity.Orderitem, []event.Event, error) {
+	item, err := h.repo.FindByID(ctx, cmd.ID)
+	if err != nil {
+		return nil, nil, commandLookupError(err)
+	}
+	if err := checkParent(item, cmd.ParentOrderID); err != nil {
+		return nil, nil, err
+	}
+	if err := checkVersion(item.Version, cmd.Version); err != nil {
+		return nil, nil, err
+	}
+	previousOrderID := item.OrderID
+
+	if err := h.checkOrderEditable(ctx, previousOrderID); err != nil {
+		return nil, nil, err
+	}
+	if previousOrderID != cmd.OrderID {
+		if err := h.checkOrderEditable(ctx, cmd.OrderID); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	item.Update(cmd.OrderID, cmd.ProductID, cmd.Quantity, cmd.Price)
+	if err := item.Validate(); err != nil {
+		return nil, nil, err
+	}
+	if err := h.repo.Update(ctx, item); err != nil {
+		return nil, nil, err
+	}
+
+	if err := h.recalculateOrderTotal(ctx, item.OrderID); err != nil {
+		return nil, nil, err
+	}
+	if previousOrderID != item.OrderID {
+		if err := h.recalculateOrderTotal(ctx, previousOrderID); err != nil {
+			return nil, nil, err
+		}
+	}
+	return item, item.PullEvents(), nil
+}
+
+// delete removes an item and recalculates its order total
+func (h *OrderitemCommandHandler) delete(ctx context.Context, cmd *command.DeleteOrderitemCommand) ([]event.Event, error) {
+	item, err := h.repo.FindByID(ctx, cmd.ID)
+	if err != nil {
+		return nil, commandLookupError(err)
+	}
+	if err := checkParent(item, cmd.ParentOrderID); err != nil {
+		return nil, err
+	}
+	if err := checkVersion(item.Version, cmd.Version); err != nil {
+		return nil, err
+	}
+	if err := h.checkOrderEditable(ctx, item.OrderID); err != nil {
+		return nil, err
+	}
+
+	item.Remove()
+	if err := h.repo.Delete(ctx, cmd.ID); err != nil {
+		return nil, err
+	}
+	if err := h.recalculateOrderTotal(ctx, item.OrderID); err != nil {
+		return nil, err
+	}
+	return item.PullEvents(), nil
+}
+
+// checkOrderEditable returns command.ErrNotFound when the order does not
+// exist, and a conflict when its items may no longer change
+func (h *OrderitemCommandHandler) checkOrderEditable(ctx context.Context, orderID uuid.UUID) error {
+	order, err := h.orderRepo.FindByID(ctx, orderID)
+	if err != nil {
+		return commandLookupError(err)
+	}
+	return order.CheckItemsEditable()
+}
+
+// checkParent returns command.ErrNotFound when parentID is set and the item
+// belongs to another order
+func checkParent(item *entity.Orderitem, parentID uuid.UUID) error {
+	if parentID != uuid.Nil && item.OrderID != parentID {
+		return command.ErrNotFound
+	}
+	return nil
+}
+
+// recalculateOrderTotal reloads an order with its items and persists the
+// derived total. It fails if the discount then exceeds the subtotal plus
+// tax and shipping. The order is not versioned by the caller, so when
+// another item change of the order commits first, the total is
+// recalculated once more from the committed state.
+func (h *OrderitemCommandHandler) recalculateOrderTotal(ctx context.Context, orderID uuid.UUID) error {
+	err := h.saveOrderTotal(ctx, orderID)
+	if errors.Is(err, repository.ErrVersionConflict) {
+		err = h.saveOrderTotal(ctx, orderID)
+	}
+	return err
+}
+
+// saveOrderTotal persists the total derived from the current items of an order
+func (h *OrderitemCommandHandler) saveOrderTotal(ctx context.Context, orderID uuid.UUID) error {
+	order, err := h.orderRepo.FindWithItems(ctx, orderID)
+	if err != nil {
+		return err
+	}
+
+	if err := order.RecalculateTotal(); err != nil {
+		return err
+	}
+	if err := order.Validate(); err != nil {
+		return err
+	}
+	return h.orderRepo.Update(ctx, order)
 }
@@ -7,32 +7,75 @@ package handler
 import (
 	"context"
 
+	"github.com/google/uuid"
+	"github.com/telemetryflow/order-service/internal/application/bus"
 	"github.com/telemetryflow/order-service/internal/application/dto"
 	"github.com/telemetryflow/order-service/internal/application/query"
+	"github.com/telemetryflow/order-service/internal/domain/entity"
 	"github.com/telemetryflow/order-service/internal/domain/repository"
+	"github.com/telemetryflow/order-service/pkg/pagination"
 )
 
-// OrderitemQueryHandler handles queries for Orderitem entity
+// OrderitemQueryHandler handles queries for Orderitem entity.
+// Its reads may be served from a read replica when dispatched through the
+// query bus (see bus.Ask).
 type OrderitemQueryHandler struct {
-	repo repository.OrderitemRepository
+	repo      repository.OrderitemRepository
+	orderRepo repository.OrderRepository
+	cursors   *pagination.Codec
 }
 
-// NewOrderitemQueryHandler creates a new Orderitem query handler
-func NewOrderitemQueryHandler(repo repository.OrderitemRepository) *OrderitemQueryHandler {
+// NewOrderitemQueryHandler creates a new Orderitem query handler.
+// cursors signs and verifies keyset pagination cursors.
+func NewOrderitemQueryHandler(repo repository.OrderitemRepository, orderRepo repository.OrderRepository, cursors *pagination.Codec) *OrderitemQueryHandler {
 	return &OrderitemQueryHandler{
-		repo: repo,
+		repo:      repo,
+		orderRepo: orderRepo,
+		cursors:   cursors,
 	}
 }
 
+// Register registers the handler's queries on b
+func (h *OrderitemQueryHandler) Register(b *bus.QueryBus) {
+	bus.RegisterQuery(b, h.HandleOrderitemGetByID)
+	bus.RegisterQuery(b, h.HandleOrderitemGetAll)
+	bus.RegisterQuery(b, h.HandleOrderitemGetByOrder)
+	bus.RegisterQuery(b, func(ctx context.Context, qry *query.GetOrderItemsPageQuery) (*dto.CursorPage[*dto.OrderitemResponse], error) {
+		return h.HandleOrderitemGetPage(ctx, &qry.GetAllOrderItemsQuery)
+	})
+}
+
 // HandleOrderitemGetByID handles get orderitem by ID query
 func (h *OrderitemQueryHandler) HandleOrderitemGetByID(ctx context.Context, qry *query.GetOrderitemByIDQuery) (*dto.OrderitemResponse, error) {
 	entity, err := h.repo.FindByID(ctx, qry.ID)
 	if err != nil {
-		return nil, err
+		return nil, queryLookupError(err)
+	}
+	if qry.ParentOrderID != uuid.Nil && entity.OrderID != qry.ParentOrderID {
+		return nil, query.ErrNotFound
 	}
 	return dto.OrderitemToResponse(entity), nil
 }
 
+// HandleOrderitemGetByOrder handles list items of an order query.
+// It returns query.ErrNotFound if the order does not exist.
+func (h *OrderitemQueryHandler) HandleOrderitemGetByOrder(ctx context.Context, qry *query.GetOrderItemsByOrderQuery) ([]*dto.OrderitemResponse, error) {
+	if _, err := h.orderRepo.FindByID(ctx, qry.OrderID); err != nil {
+		return nil, queryLookupError(err)
+	}
+
+	entities, err := h.repo.FindByOrderID(ctx, qry.OrderID)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]*dto.OrderitemResponse, len(entities))
+	for i := range entities {
+		responses[i] = dto.OrderitemToResponse(&entities[i])
+	}
+	return responses, nil
+}
+
 // HandleOrderitemGetAll handles get all orderitems query
 func (h *OrderitemQueryHandler) HandleOrderitemGetAll(ctx context.Context, qry *query.GetAllOrderItemsQuery) (*dto.OrderitemListResponse, error) {
 	entities, total, err := h.repo.FindAll(ctx, qry.Offset, qry.Limit)
@@ -52,3 +95,22 @@ func (h *OrderitemQueryHandler) HandleOrderitemGetAll(ctx context.Context, qry *
 		Limit:  qry.Limit,
 	}, nil
 }
+
+// HandleOrderitemGetPage handles get all orderitems query in cursor mode.
+// Items are listed newest first.
+func (h *OrderitemQueryHandler) HandleOrderitemGetPage(ctx context.Context, qry *query.GetAllOrderItemsQuery) (*dto.CursorPage[*dto.OrderitemResponse], error) {
+	page, err := keysetPage(h.cursors, *qry.Cursor, qry.CursorScope(), qry.Limit, repository.SortDesc)
+	if err != nil {
+		return nil, err
+	}
+
+	items, more, err := h.repo.FindPage(ctx, page)
+	if err != nil {
+		return nil, err
+	}
+
+	return cursorPage(h.cursors, page, qry.CursorScope(), items, more,
+		func(i *entity.Orderitem) *entity.Base { return &i.Base },
+		dto.OrderitemToResponse,
+	), nil
+}
@@ -0,0 +1,59 @@
+// Package handler provides keyset pagination helpers for query handlers.
+package handler
+
+import (
+	"github.com/telemetryflow/order-service/internal/application/dto"
+	"github.com/telemetryflow/order-service/internal/application/query"
+	"github.com/telemetryflow/order-service/internal/domain/entity"
+	"github.com/telemetryflow/order-service/internal/domain/repository"
+	"github.com/telemetryflow/order-service/pkg/pagination"
+)
+
+// keysetPage decodes a cursor token into a page request. An empty token
+// requests the first page. Cursors issued for another scope are invalid.
+func keysetPage(cursors *pagination.Codec, token, scope string, limit int, dir repository.SortDirection) (repository.KeysetPage, error) {
+	page := repository.KeysetPage{Limit: limit, Direction: dir}
+	if token == "" {
+		return page, nil
+	}
+
+	cur, err := cursors.Decode(token)
+	if err != nil || cur.Scope != scope {
+		return page, query.ErrInvalidCursor
+	}
+	pos := &repository.Keyset{CreatedAt: cur.CreatedAt, ID: cur.ID}
+	if cur.Backward {
+		page.Before = pos
+	} else {
+		page.After = pos
+	}
+	return page, nil
+}
+
+// cursorPage builds the response for rows fetched with page. A next cursor
+// is issued when rows follow the page and a previous cursor when the page
+// was not the first one; both are bound to scope.
+func cursorPage[E any, R any](cursors *pagination.Codec, page repository.KeysetPage, scope string, rows []E, more bool, base func(*E) *entity.Base, convert func(*E) R) *dto.CursorPage[R] {
+	result := &dto.CursorPage[R]{
+		Data:  make([]R, len(rows)),
+		Limit: page.Limit,
+	}
+	for i := range rows {
+		result.Data[i] = convert(&rows[i])
+	}
+	if len(rows) == 0 {
+		return result
+	}
+
+	hasNext := more || page.Before != nil
+	hasPrev := page.After != nil || (page.Before != nil && more)
+	if hasNext {
+		last := base(&rows[len(rows)-1])
+		result.NextCursor = cursors.Encode(pagination.Cursor{CreatedAt: last.CreatedAt, ID: last.ID, Scope: scope})
+	}
+	if hasPrev {
+		first := base(&rows[0])
+		result.PrevCursor = cursors.Encode(pagination.Cursor{CreatedAt: first.CreatedAt, ID: first.ID, Backward: true, Scope: scope})
+	}
+	return result
+}
@@ -3,6 +3,7 @@ package query
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/google/uuid"
 )
@@ -87,6 +88,10 @@ var (
 	ErrInvalidID = &QueryError{Code: "INVALID_ID", Message: "Invalid ID provided"}
 	ErrNotFound  = &QueryError{Code: "NOT_FOUND", Message: "Resource not found"}
 	ErrForbidden = &QueryError{Code: "FORBIDDEN", Message: "Access forbidden"}
+
+	// ErrInvalidCursor is returned for pagination cursors that are malformed
+	// or were not issued by this service
+	ErrInvalidCursor = &QueryError{Code: "INVALID_CURSOR", Message: "Invalid pagination cursor"}
 )
 
 // QueryError represents a query execution error
@@ -98,3 +103,8 @@ type QueryError struct {
 func (e *QueryError) Error() string {
 	return e.Message
 }
+
+// invalidFilter returns a VALIDATION_ERROR describing a rejected filter
+func invalidFilter(format string, args ...interface{}) *QueryError {
+	return &QueryError{Code: "VALIDATION_ERROR", Message: fmt.Sprintf(format, args...)}
+}
@@ -2,78 +2,170 @@
 package query
 
 import (
+	"slices"
+	"strings"
+	"time"
+
 	"github.com/google/uuid"
+	"github.com/telemetryflow/order-service/internal/domain"
+	"github.com/telemetryflow/order-service/internal/domain/repository"
+	"github.com/telemetryflow/order-service/pkg/pagination"
+)
+
+// Order relations that GetOrderByIDQuery can expand
+const (
+	OrderExpandItems = "items"
 )
 
-// GetOrderByIDQuery represents the get order by ID query
+// GetOrderByIDQuery represents the get order by ID query.
+// WithItems also loads the order items. Expand is the comma-separated list
+// of relations to load, as given by the expand query parameter.
 type GetOrderByIDQuery struct {
-	ID uuid.UUID `json:"id" validate:"required"`
+	ID        uuid.UUID `json:"id" validate:"required"`
+	Expand    string    `json:"expand" query:"expand"`
+	WithItems bool      `json:"with_items"`
 }
 
-// Validate validates the query
+// Validate validates the query and applies Expand
 func (q *GetOrderByIDQuery) Validate() error {
 	if q.ID == uuid.Nil {
 		return ErrInvalidID
 	}
+	for _, relation := range strings.Split(q.Expand, ",") {
+		switch relation = strings.TrimSpace(relation); relation {
+		case "":
+		case OrderExpandItems:
+			q.WithItems = true
+		default:
+			return invalidFilter("unsupported expand %q", relation)
+		}
+	}
 	return nil
 }
 
-// ListOrdersQuery represents the list orders query
-type ListOrdersQuery struct {
-	Page     int    `json:"page" query:"page"`
-	PageSize int    `json:"page_size" query:"page_size"`
-	SortBy   string `json:"sort_by" query:"sort_by"`
-	SortDir  string `json:"sort_dir" query:"sort_dir"`
-	Search   string `json:"search" query:"search"`
+// GetAllOrdersQuery represents the get all orders query with pagination,
+// filters and sorting. Status may be repeated or comma-separated.
+// When Cursor is set, even to "", keyset pagination is used instead of
+// Offset and the result is not counted.
+type GetAllOrdersQuery struct {
+	Offset int     `json:"offset" query:"offset"`
+	Limit  int     `json:"limit" query:"limit"`
+	Cursor *string `json:"cursor" query:"cursor"`
+
+	Status      []string      `json:"status" query:"status"`
+	CustomerID  *uuid.UUID    `json:"customer_id" query:"customer_id"`
+	CreatedFrom *time.Time    `json:"created_from" query:"created_from"`
+	CreatedTo   *time.Time    `json:"created_to" query:"created_to"`
+	MinTotal    *domain.Money `json:"min_total" query:"min_total"`
+	MaxTotal    *domain.Money `json:"max_total" query:"max_total"`
+	Search      string        `json:"search" query:"search"`
+	SortBy      string        `json:"sort_by" query:"sort_by"`
+	SortDir     string        `json:"sort_dir" query:"sort_dir"`
 }
 
-// Validate validates the query
-func (q *ListOrdersQuery) Validate() error {
-	if q.Page < 1 {
-		q.Page = 1
-	}
-	if q.PageSize < 1 || q.PageSize > 100 {
-		q.PageSize = 10
+// Validate normalizes pagination and sorting and rejects unsupported
+// sort fields and inverted ranges
+func (q *GetAllOrdersQuery) Validate() error {
+	if q.Offset < 0 {
+		q.Offset = 0
 	}
-	if q.SortDir != "asc" && q.SortDir != "desc" {
-		q.SortDir = "desc"
+	if q.Limit < 1 || q.Limit > 100 {
+		q.Limit = 10
 	}
 	if q.SortBy == "" {
-		q.SortBy = "created_at"
+		q.SortBy = repository.OrderSortCreatedAt
+	}
+	if !repository.IsOrderSortField(q.SortBy) {
+		return invalidFilter("unsupported sort_by %q", q.SortBy)
+	}
+	if q.Cursor != nil && q.SortBy != repository.OrderSortCreatedAt {
+		return invalidFilter("cursor pagination only supports sort_by=%s", repository.OrderSortCreatedAt)
+	}
+	if q.SortDir != string(repository.SortAsc) && q.SortDir != string(repository.SortDesc) {
+		q.SortDir = string(repository.SortDesc)
+	}
+
+	var statuses []string
+	for _, s := range q.Status {
+		for _, status := range strings.Split(s, ",") {
+			if status = strings.TrimSpace(status); status != "" {
+				statuses = append(statuses, status)
+			}
+		}
+	}
+	q.Status = statuses
+
+	if q.CreatedFrom != nil && q.CreatedTo != nil && q.CreatedFrom.After(*q.CreatedTo) {
+		return invalidFilter("created_from must not be after created_to")
+	}
+	if q.MinTotal != nil && q.MaxTotal != nil && q.MinTotal.Amount() > q.MaxTotal.Amount() {
+		return invalidFilter("min_total must not exceed max_total")
 	}
 	return nil
 }
 
-// Offset returns the offset for pagination
-func (q *ListOrdersQuery) Offset() int {
-	return (q.Page - 1) * q.PageSize
+// Keyset reports whether the query uses cursor pagination
+func (q *GetAllOrdersQuery) Keyset() bool {
+	return q.Cursor != nil
 }
 
-// GetAllOrdersQuery represents the get all orders query with pagination
-type GetAllOrdersQuery struct {
-	Offset int `json:"offset" query:"offset"`
-	Limit  int `json:"limit" query:"limit"`
-}
+// CursorScope returns the pagination scope of the query: cursors issued for
+// one sort direction and filter are rejected for any other. The query must
+// have been validated.
+func (q *GetAllOrdersQuery) CursorScope() string {
+	statuses := slices.Clone(q.Status)
+	slices.Sort(statuses)
 
-// Validate validates the query
-func (q *GetAllOrdersQuery) Validate() error {
-	if q.Offset < 0 {
-		q.Offset = 0
+	optional := func(set bool, value func() string) string {
+		if !set {
+			return ""
+		}
+		return value()
 	}
-	if q.Limit < 1 || q.Limit > 100 {
-		q.Limit = 10
+	return pagination.Scope(
+		"orders",
+		q.SortDir,
+		strings.Join(slices.Compact(statuses), ","),
+		optional(q.CustomerID != nil, func() string { return q.CustomerID.String() }),
+		optional(q.CreatedFrom != nil, func() string { return q.CreatedFrom.UTC().Format(time.RFC3339Nano) }),
+		optional(q.CreatedTo != nil, func() string { return q.CreatedTo.UTC().Format(time.RFC3339Nano) }),
+		optional(q.MinTotal != nil, func() string { return q.MinTotal.String() }),
+		optional(q.MaxTotal != nil, func() string { return q.MaxTotal.String() }),
+		strings.TrimSpace(q.Search),
+	)
+}
+
+// GetOrdersPageQuery is a GetAllOrdersQuery in cursor mode. It has its own
+// type because its handler returns a cursor page instead of a counted list.
+type GetOrdersPageQuery struct {
+	GetAllOrdersQuery
+}
+
+// Filter returns the repository specification for the query
+func (q *GetAllOrdersQuery) Filter() repository.OrderFilter {
+	return repository.OrderFilter{
+		Statuses:    q.Status,
+		CustomerID:  q.CustomerID,
+		CreatedFrom: q.CreatedFrom,
+		CreatedTo:   q.CreatedTo,
+		MinTotal:    q.MinTotal,
+		MaxTotal:    q.MaxTotal,
+		Search:      q.Search,
+		Sort:        repository.Sort{Field: q.SortBy, Direction: repository.SortDirection(q.SortDir)},
+		Offset:      q.Offset,
+		Limit:       q.Limit,
 	}
-	return nil
 }
 
-// SearchOrdersQuery represents the search orders query
+// SearchOrdersQuery represents the full-text search orders query.
+// Query supports quoted phrases, "or" and "-" to exclude a word.
 type SearchOrdersQuery struct {
-	Query  string `json:"query" query:"query"`
+	Query  string `json:"q" query:"q"`
 	Offset int    `json:"offset" query:"offset"`
 	Limit  int    `json:"limit" query:"limit"`
 }
 
-// Validate validates the query
+// Validate normalizes pagination and requires search text
 func (q *SearchOrdersQuery) Validate() error {
 	if q.Offset < 0 {
 		q.Offset = 0
@@ -81,5 +173,9 @@ func (q *SearchOrdersQuery) Validate() error {
 	if q.Limit < 1 || q.Limit > 100 {
 		q.Limit = 10
 	}
+	q.Query = strings.TrimSpace(q.Query)
+	if q.Query == "" {
+		return invalidFilter("q is required")
+	}
 	return nil
 }
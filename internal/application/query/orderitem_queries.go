@@ -3,11 +3,15 @@ package query
 
 import (
 	"github.com/google/uuid"
+	"github.com/telemetryflow/order-service/pkg/pagination"
 )
 
-// GetOrderitemByIDQuery represents the get orderitem by ID query
+// GetOrderitemByIDQuery represents the get orderitem by ID query.
+// ParentOrderID, when set, is the order the item must belong to; items of
+// other orders are not found.
 type GetOrderitemByIDQuery struct {
-	ID uuid.UUID `json:"id" validate:"required"`
+	ID            uuid.UUID `json:"id" validate:"required"`
+	ParentOrderID uuid.UUID `json:"parent_order_id"`
 }
 
 // Validate validates the query
@@ -18,41 +22,31 @@ func (q *GetOrderitemByIDQuery) Validate() error {
 	return nil
 }
 
-// ListOrderitemsQuery represents the list orderitems query
-type ListOrderitemsQuery struct {
-	Page     int    `json:"page" query:"page"`
-	PageSize int    `json:"page_size" query:"page_size"`
-	SortBy   string `json:"sort_by" query:"sort_by"`
-	SortDir  string `json:"sort_dir" query:"sort_dir"`
-	Search   string `json:"search" query:"search"`
+// GetOrderItemsByOrderQuery represents the list items of an order query
+type GetOrderItemsByOrderQuery struct {
+	OrderID uuid.UUID `json:"order_id" validate:"required"`
 }
 
 // Validate validates the query
-func (q *ListOrderitemsQuery) Validate() error {
-	if q.Page < 1 {
-		q.Page = 1
-	}
-	if q.PageSize < 1 || q.PageSize > 100 {
-		q.PageSize = 10
-	}
-	if q.SortDir != "asc" && q.SortDir != "desc" {
-		q.SortDir = "desc"
-	}
-	if q.SortBy == "" {
-		q.SortBy = "created_at"
+func (q *GetOrderItemsByOrderQuery) Validate() error {
+	if q.OrderID == uuid.Nil {
+		return ErrInvalidID
 	}
 	return nil
 }
 
-// Offset returns the offset for pagination
-func (q *ListOrderitemsQuery) Offset() int {
-	return (q.Page - 1) * q.PageSize
+// GetAllOrderItemsQuery represents the get all orderitems query with pagination.
+// When Cursor is set, even to "", keyset pagination over created_at
+// descending is used instead of Offset and the result is not counted.
+type GetAllOrderItemsQuery struct {
+	Offset int     `json:"offset" query:"offset"`
+	Limit  int     `json:"limit" query:"limit"`
+	Cursor *string `json:"cursor" query:"cursor"`
 }
 
-// GetAllOrderItemsQuery represents the get all orderitems query with pagination
-type GetAllOrderItemsQuery struct {
-	Offset int `json:"offset" query:"offset"`
-	Limit  int `json:"limit" query:"limit"`
+// Keyset reports whether the query uses cursor pagination
+func (q *GetAllOrderItemsQuery) Keyset() bool {
+	return q.Cursor != nil
 }
 
 // Validate validates the query
@@ -66,6 +60,19 @@ func (q *GetAllOrderItemsQuery) Validate() error {
 	return nil
 }
 
+// CursorScope returns the pagination scope of the query, so that cursors
+// of other listings are rejected
+func (q *GetAllOrderItemsQuery) CursorScope() string {
+	return pagination.Scope("order_items")
+}
+
+// GetOrderItemsPageQuery is a GetAllOrderItemsQuery in cursor mode. It has
+// its own type because its handler returns a cursor page instead of a
+// counted list.
+type GetOrderItemsPageQuery struct {
+	GetAllOrderItemsQuery
+}
+
 // SearchOrderItemsQuery represents the search orderitems query
 type SearchOrderItemsQuery struct {
 	Query  string `json:"query" query:"query"`
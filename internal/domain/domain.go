@@ -11,10 +11,15 @@ import (
 	"github.com/google/uuid"
 )
 
-// Common domain errors
+// Common domain errors.
+// Repositories wrap ErrEntityNotFound, ErrEntityConflict and ErrInvalidEntity
+// around storage failures so callers never depend on driver errors.
 var (
-	ErrEntityNotFound = errors.New("entity not found")
-	ErrInvalidEntity  = errors.New("invalid entity")
+	ErrEntityNotFound         = errors.New("entity not found")
+	ErrEntityConflict         = errors.New("entity conflicts with existing data")
+	ErrInvalidEntity          = errors.New("invalid entity")
+	ErrInvalidStateTransition = errors.New("invalid state transition")
+	ErrTotalMismatch          = errors.New("total does not match order items")
 )
 
 // Entity represents a generic domain entity for testing purposes
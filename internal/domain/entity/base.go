@@ -8,11 +8,15 @@ import (
 	"gorm.io/gorm"
 )
 
-// Base contains common fields for all entities
+// Base contains common fields for all entities.
+// Version is incremented on every successful update and is used for
+// optimistic concurrency control. IDs are generated by the application (see
+// BeforeCreate), so entities do not depend on a database UUID function.
 type Base struct {
-	ID        uuid.UUID      `json:"id" gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
-	CreatedAt time.Time      `json:"created_at" gorm:"autoCreateTime"`
-	UpdatedAt time.Time      `json:"updated_at" gorm:"autoUpdateTime"`
+	ID        uuid.UUID      `json:"id" gorm:"type:uuid;primaryKey"`
+	Version   int64          `json:"version" gorm:"not null;default:1"`
+	CreatedAt time.Time      `json:"created_at" gorm:"not null;autoCreateTime"`
+	UpdatedAt time.Time      `json:"updated_at" gorm:"not null;autoUpdateTime"`
 	DeletedAt gorm.DeletedAt `json:"deleted_at,omitempty" gorm:"index"`
 }
 
@@ -21,11 +25,17 @@ func NewBase() Base {
 	now := time.Now()
 	return Base{
 		ID:        uuid.New(),
+		Version:   1,
 		CreatedAt: now,
 		UpdatedAt: now,
 	}
 }
 
+// BaseEntity returns the common fields of the entity embedding b
+func (b *Base) BaseEntity() *Base {
+	return b
+}
+
 // IsDeleted returns true if the entity has been soft-deleted
 func (b *Base) IsDeleted() bool {
 	return b.DeletedAt.Valid
@@ -52,5 +62,8 @@ func (b *Base) BeforeCreate(_ *gorm.DB) error {
 	if b.ID == uuid.Nil {
 		b.ID = uuid.New()
 	}
+	if b.Version == 0 {
+		b.Version = 1
+	}
 	return nil
 }
@@ -2,16 +2,32 @@
 package entity
 
 import (
+	"fmt"
+	"slices"
+	"strings"
+
 	"github.com/google/uuid"
+	"github.com/telemetryflow/order-service/internal/domain"
+	"github.com/telemetryflow/order-service/internal/domain/event"
+	"gorm.io/gorm"
 )
 
 // Order represents the order domain entity
 type Order struct {
 	Base
-	CustomerID uuid.UUID   `json:"customer_id" gorm:"type:uuid;not null;index"`
-	Total      float64     `json:"total" gorm:"type:decimal(15,2);not null;default:0"`
-	Status     string      `json:"status" gorm:"type:varchar(50);not null;default:'pending';index"`
-	Items      []Orderitem `json:"items,omitempty" gorm:"foreignKey:OrderID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	CustomerID uuid.UUID    `json:"customer_id" gorm:"type:uuid;not null;index"`
+	Currency   string       `json:"currency" gorm:"type:char(3);not null;default:'USD'"`
+	Subtotal   domain.Money `json:"subtotal" gorm:"type:decimal(15,2);not null;default:0"`
+	Discount   domain.Money `json:"discount" gorm:"type:decimal(15,2);not null;default:0"`
+	Tax        domain.Money `json:"tax" gorm:"type:decimal(15,2);not null;default:0"`
+	Shipping   domain.Money `json:"shipping" gorm:"type:decimal(15,2);not null;default:0"`
+	Total      domain.Money `json:"total" gorm:"type:decimal(15,2);not null;default:0"`
+	Status     string       `json:"status" gorm:"type:varchar(50);not null;default:'pending';index"`
+	Tags       []string     `json:"tags" gorm:"type:jsonb;not null;default:'[]';serializer:json"`
+	Notes      string       `json:"notes" gorm:"type:text;not null;default:''"`
+	Items      []Orderitem  `json:"items,omitempty" gorm:"foreignKey:OrderID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+
+	events event.Recorder
 }
 
 // TableName returns the table name for GORM
@@ -19,26 +35,184 @@ func (Order) TableName() string {
 	return "orders"
 }
 
-// NewOrder creates a new Order entity
-func NewOrder(customerID uuid.UUID, total float64, status string) *Order {
-	return &Order{
+// NewOrder creates a new Order entity in the currency of total
+// (domain.DefaultCurrency if total has none)
+func NewOrder(customerID uuid.UUID, total domain.Money, status string) *Order {
+	currency := total.Currency()
+	if currency == "" {
+		currency = domain.DefaultCurrency
+	}
+	order := &Order{
 		Base:       NewBase(),
 		CustomerID: customerID,
-		Total:      total,
+		Currency:   currency,
+		Subtotal:   domain.ZeroMoney(currency),
+		Discount:   domain.ZeroMoney(currency),
+		Tax:        domain.ZeroMoney(currency),
+		Shipping:   domain.ZeroMoney(currency),
+		Total:      total.WithCurrency(currency),
 		Status:     status,
+		Tags:       []string{},
 	}
+	order.events.Record(event.OrderCreated{
+		Metadata:   event.NewMetadata(),
+		OrderID:    order.ID,
+		CustomerID: customerID,
+		Currency:   currency,
+		Status:     status,
+	})
+	return order
 }
 
 // Update updates the order fields
-func (e *Order) Update(customerID uuid.UUID, total float64, status string) {
+func (e *Order) Update(customerID uuid.UUID, total domain.Money, status string) {
 	e.CustomerID = customerID
-	e.Total = total
+	e.Total = total.WithCurrency(e.Currency)
 	e.Status = status
 	e.MarkUpdated()
+	e.events.Record(event.OrderUpdated{
+		Metadata:   event.NewMetadata(),
+		OrderID:    e.ID,
+		CustomerID: customerID,
+	})
 }
 
-// Validate validates the entity
-func (e *Order) Validate() error {
-	// Add validation logic here
+// Remove marks the order as deleted
+func (e *Order) Remove() {
+	e.MarkDeleted()
+	e.events.Record(event.OrderDeleted{
+		Metadata: event.NewMetadata(),
+		OrderID:  e.ID,
+		Status:   e.Status,
+	})
+}
+
+// PullEvents returns the events raised by the order and its items
+// since the last call, and clears them
+func (e *Order) PullEvents() []event.Event {
+	events := e.events.PullEvents()
+	for i := range e.Items {
+		events = append(events, e.Items[i].PullEvents()...)
+	}
+	return events
+}
+
+// Annotate replaces the order's tags and notes. Tags are trimmed, and
+// blank and duplicate tags are dropped.
+func (e *Order) Annotate(tags []string, notes string) {
+	e.Tags = make([]string, 0, len(tags))
+	for _, tag := range tags {
+		if tag = strings.TrimSpace(tag); tag != "" && !slices.Contains(e.Tags, tag) {
+			e.Tags = append(e.Tags, tag)
+		}
+	}
+	e.Notes = strings.TrimSpace(notes)
+}
+
+// SetCharges sets the order-level discount, tax and shipping amounts
+// and recalculates the total
+func (e *Order) SetCharges(discount, tax, shipping domain.Money) error {
+	for _, charge := range []domain.Money{discount, tax, shipping} {
+		if charge.IsNegative() {
+			return fmt.Errorf("%w: charges cannot be negative", domain.ErrInvalidAmount)
+		}
+	}
+	e.Discount = discount.WithCurrency(e.Currency)
+	e.Tax = tax.WithCurrency(e.Currency)
+	e.Shipping = shipping.WithCurrency(e.Currency)
+	return e.RecalculateTotal()
+}
+
+// RecalculateTotal derives Subtotal from Items and Total from
+// Subtotal - Discount + Tax + Shipping
+func (e *Order) RecalculateTotal() error {
+	subtotal := domain.ZeroMoney(e.Currency)
+	for i := range e.Items {
+		line, err := e.Items[i].LineTotal()
+		if err != nil {
+			return err
+		}
+		if subtotal, err = subtotal.Add(line); err != nil {
+			return err
+		}
+	}
+
+	total, err := subtotal.Sub(e.Discount)
+	if err != nil {
+		return err
+	}
+	if total, err = total.Add(e.Tax); err != nil {
+		return err
+	}
+	if total, err = total.Add(e.Shipping); err != nil {
+		return err
+	}
+
+	e.Subtotal = subtotal
+	e.Total = total
+	return nil
+}
+
+// VerifyTotal checks a client-supplied total against the computed total.
+// A nil expected total is treated as not supplied.
+func (e *Order) VerifyTotal(expected *domain.Money) error {
+	if expected == nil {
+		return nil
+	}
+	total := *expected
+	if total.Currency() == "" {
+		total = total.WithCurrency(e.Currency)
+	}
+	if total.Equal(e.Total) {
+		return nil
+	}
+	return fmt.Errorf("%w: expected %s %s, computed %s %s",
+		domain.ErrTotalMismatch, total, total.Currency(), e.Total, e.Currency)
+}
+
+// AfterFind is a GORM hook that applies the order currency to all amounts,
+// since decimal columns do not carry a currency
+func (e *Order) AfterFind(_ *gorm.DB) error {
+	e.Subtotal = e.Subtotal.WithCurrency(e.Currency)
+	e.Discount = e.Discount.WithCurrency(e.Currency)
+	e.Tax = e.Tax.WithCurrency(e.Currency)
+	e.Shipping = e.Shipping.WithCurrency(e.Currency)
+	e.Total = e.Total.WithCurrency(e.Currency)
+	for i := range e.Items {
+		e.Items[i].Price = e.Items[i].Price.WithCurrency(e.Currency)
+	}
 	return nil
 }
+
+// Validate checks the order invariants: a customer, a known status, a valid
+// currency, non-negative charges, a discount no larger than the rest of the
+// total and valid items belonging to the order.
+// Violations are reported as a *domain.ValidationError.
+func (e *Order) Validate() error {
+	fields := domain.FieldErrors{}
+	if e.CustomerID == uuid.Nil {
+		fields.Add("customer_id", "This field is required")
+	}
+	if !IsValidOrderStatus(e.Status) {
+		fields.Add("status", "Must be a known order status")
+	}
+	if err := domain.ValidateCurrency(e.Currency); err != nil {
+		fields.Add("currency", "Must be a 3-letter ISO-4217 currency code")
+	}
+	for field, charge := range map[string]domain.Money{"discount": e.Discount, "tax": e.Tax, "shipping": e.Shipping} {
+		if charge.IsNegative() {
+			fields.Add(field, "Must not be negative")
+		}
+	}
+	if e.Total.IsNegative() {
+		fields.Add("discount", "Must not exceed the subtotal plus tax and shipping")
+	}
+	for i := range e.Items {
+		prefix := fmt.Sprintf("items[%d].", i)
+		fields.Merge(prefix, e.Items[i].Validate())
+		if e.Items[i].OrderID != e.ID {
+			fields.Add(prefix+"order_id", "Must reference the order")
+		}
+	}
+	return fields.Err()
+}
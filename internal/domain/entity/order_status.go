@@ -0,0 +1,118 @@
+// Package entity contains domain entities.
+package entity
+
+import (
+	"fmt"
+
+	"github.com/telemetryflow/order-service/internal/domain"
+	"github.com/telemetryflow/order-service/internal/domain/event"
+)
+
+// Order status values
+const (
+	OrderStatusPending   = "pending"
+	OrderStatusConfirmed = "confirmed"
+	OrderStatusPaid      = "paid"
+	OrderStatusShipped   = "shipped"
+	OrderStatusDelivered = "delivered"
+	OrderStatusCancelled = "cancelled"
+	OrderStatusRefunded  = "refunded"
+)
+
+// orderTransitions lists the statuses reachable from each order status
+var orderTransitions = map[string][]string{
+	OrderStatusPending:   {OrderStatusConfirmed, OrderStatusCancelled},
+	OrderStatusConfirmed: {OrderStatusPaid, OrderStatusCancelled},
+	OrderStatusPaid:      {OrderStatusShipped, OrderStatusCancelled, OrderStatusRefunded},
+	OrderStatusShipped:   {OrderStatusDelivered},
+	OrderStatusDelivered: {OrderStatusRefunded},
+	OrderStatusCancelled: {},
+	OrderStatusRefunded:  {},
+}
+
+// IsValidOrderStatus returns true if status is a known order status
+func IsValidOrderStatus(status string) bool {
+	_, ok := orderTransitions[status]
+	return ok
+}
+
+// CanTransitionTo returns true if the order may move to the given status
+func (e *Order) CanTransitionTo(status string) bool {
+	for _, next := range orderTransitions[e.Status] {
+		if next == status {
+			return true
+		}
+	}
+	return false
+}
+
+// TransitionTo moves the order to the given status if the transition is allowed.
+// It records OrderStatusChanged, and OrderCancelled when the order is cancelled.
+func (e *Order) TransitionTo(status string) error {
+	if !e.CanTransitionTo(status) {
+		return fmt.Errorf("%w: %s -> %s", domain.ErrInvalidStateTransition, e.Status, status)
+	}
+	previous := e.Status
+	e.Status = status
+	e.MarkUpdated()
+
+	e.events.Record(event.OrderStatusChanged{
+		Metadata: event.NewMetadata(),
+		OrderID:  e.ID,
+		From:     previous,
+		To:       status,
+	})
+	if status == OrderStatusCancelled {
+		e.events.Record(event.OrderCancelled{
+			Metadata:       event.NewMetadata(),
+			OrderID:        e.ID,
+			PreviousStatus: previous,
+		})
+	}
+	return nil
+}
+
+// IsFinal returns true if the order can no longer change status
+func (e *Order) IsFinal() bool {
+	return len(orderTransitions[e.Status]) == 0
+}
+
+// CheckItemsEditable returns an error wrapping domain.ErrEntityConflict
+// unless the order is still pending, the only status whose items and
+// total may change
+func (e *Order) CheckItemsEditable() error {
+	if e.Status != OrderStatusPending {
+		return fmt.Errorf("%w: items of a %s order cannot be changed", domain.ErrEntityConflict, e.Status)
+	}
+	return nil
+}
+
+// Confirm moves a pending order to confirmed
+func (e *Order) Confirm() error {
+	return e.TransitionTo(OrderStatusConfirmed)
+}
+
+// Pay marks a confirmed order as paid
+func (e *Order) Pay() error {
+	return e.TransitionTo(OrderStatusPaid)
+}
+
+// Ship marks a paid order as shipped
+func (e *Order) Ship() error {
+	return e.TransitionTo(OrderStatusShipped)
+}
+
+// Deliver marks a shipped order as delivered
+func (e *Order) Deliver() error {
+	return e.TransitionTo(OrderStatusDelivered)
+}
+
+// Cancel cancels an order that has not been shipped yet
+func (e *Order) Cancel() error {
+	return e.TransitionTo(OrderStatusCancelled)
+}
+
+// Refund refunds a paid or delivered order
+func (e *Order) Refund() error {
+	return e.TransitionTo(OrderStatusRefunded)
+}
@@ -3,15 +3,19 @@ package entity
 
 import (
 	"github.com/google/uuid"
+	"github.com/telemetryflow/order-service/internal/domain"
+	"github.com/telemetryflow/order-service/internal/domain/event"
 )
 
 // Orderitem represents the orderitem domain entity
 type Orderitem struct {
 	Base
-	OrderID   uuid.UUID `json:"order_id" gorm:"type:uuid;not null;index"`
-	ProductID uuid.UUID `json:"product_id" gorm:"type:uuid;not null;index"`
-	Quantity  int       `json:"quantity" gorm:"not null;default:1"`
-	Price     float64   `json:"price" gorm:"type:decimal(15,2);not null;default:0"`
+	OrderID   uuid.UUID    `json:"order_id" gorm:"type:uuid;not null;index"`
+	ProductID uuid.UUID    `json:"product_id" gorm:"type:uuid;not null;index"`
+	Quantity  int          `json:"quantity" gorm:"type:integer;not null;default:1"`
+	Price     domain.Money `json:"price" gorm:"type:decimal(15,2);not null;default:0"`
+
+	events event.Recorder
 }
 
 // TableName returns the table name for GORM
@@ -20,27 +24,81 @@ func (Orderitem) TableName() string {
 }
 
 // NewOrderitem creates a new Orderitem entity
-func NewOrderitem(orderID uuid.UUID, productID uuid.UUID, quantity int, price float64) *Orderitem {
-	return &Orderitem{
+func NewOrderitem(orderID uuid.UUID, productID uuid.UUID, quantity int, price domain.Money) *Orderitem {
+	item := &Orderitem{
 		Base:      NewBase(),
 		OrderID:   orderID,
 		ProductID: productID,
 		Quantity:  quantity,
 		Price:     price,
 	}
+	item.events.Record(event.OrderItemAdded{
+		Metadata:  event.NewMetadata(),
+		OrderID:   orderID,
+		ItemID:    item.ID,
+		ProductID: productID,
+		Quantity:  quantity,
+		Price:     price,
+	})
+	return item
 }
 
 // Update updates the orderitem fields
-func (e *Orderitem) Update(orderID uuid.UUID, productID uuid.UUID, quantity int, price float64) {
+func (e *Orderitem) Update(orderID uuid.UUID, productID uuid.UUID, quantity int, price domain.Money) {
+	previousOrderID := e.OrderID
 	e.OrderID = orderID
 	e.ProductID = productID
 	e.Quantity = quantity
 	e.Price = price
 	e.MarkUpdated()
+	e.events.Record(event.OrderItemUpdated{
+		Metadata:        event.NewMetadata(),
+		OrderID:         orderID,
+		PreviousOrderID: previousOrderID,
+		ItemID:          e.ID,
+		ProductID:       productID,
+		Quantity:        quantity,
+		Price:           price,
+	})
+}
+
+// Remove marks the item as deleted from its order
+func (e *Orderitem) Remove() {
+	e.MarkDeleted()
+	e.events.Record(event.OrderItemRemoved{
+		Metadata: event.NewMetadata(),
+		OrderID:  e.OrderID,
+		ItemID:   e.ID,
+	})
 }
 
-// Validate validates the entity
+// PullEvents returns the events raised since the last call and clears them
+func (e *Orderitem) PullEvents() []event.Event {
+	return e.events.PullEvents()
+}
+
+// LineTotal returns the item amount (Quantity * Price). It fails with
+// domain.ErrInvalidAmount when the amount is out of range.
+func (e *Orderitem) LineTotal() (domain.Money, error) {
+	return e.Price.Multiply(int64(e.Quantity))
+}
+
+// Validate checks the item invariants: an order and a product, a positive
+// quantity and a non-negative price. Violations are reported as a
+// *domain.ValidationError.
 func (e *Orderitem) Validate() error {
-	// Add validation logic here
-	return nil
+	fields := domain.FieldErrors{}
+	if e.OrderID == uuid.Nil {
+		fields.Add("order_id", "This field is required")
+	}
+	if e.ProductID == uuid.Nil {
+		fields.Add("product_id", "This field is required")
+	}
+	if e.Quantity < 1 {
+		fields.Add("quantity", "Must be greater than or equal to 1")
+	}
+	if e.Price.IsNegative() {
+		fields.Add("price", "Must not be negative")
+	}
+	return fields.Err()
 }
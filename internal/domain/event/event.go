@@ -0,0 +1,56 @@
+// Package event defines the domain events raised by aggregates.
+package event
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Event is something that happened to an aggregate
+type Event interface {
+	// EventName returns the stable name used for routing and serialization
+	EventName() string
+
+	// AggregateID returns the ID of the aggregate root that raised the event
+	AggregateID() uuid.UUID
+
+	// OccurredAt returns when the event was raised
+	OccurredAt() time.Time
+}
+
+// Metadata holds the fields shared by all events
+type Metadata struct {
+	EventID  uuid.UUID `json:"event_id"`
+	Occurred time.Time `json:"occurred_at"`
+}
+
+// NewMetadata creates Metadata with a generated ID and the current time
+func NewMetadata() Metadata {
+	return Metadata{
+		EventID:  uuid.New(),
+		Occurred: time.Now().UTC(),
+	}
+}
+
+// OccurredAt returns when the event was raised
+func (m Metadata) OccurredAt() time.Time {
+	return m.Occurred
+}
+
+// Recorder collects the events raised by an aggregate until they are pulled
+type Recorder struct {
+	events []Event
+}
+
+// Record appends an event
+func (r *Recorder) Record(e Event) {
+	r.events = append(r.events, e)
+}
+
+// PullEvents returns the recorded events and clears the recorder
+func (r *Recorder) PullEvents() []Event {
+	events := r.events
+	r.events = nil
+	return events
+}
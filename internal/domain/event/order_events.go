@@ -0,0 +1,134 @@
+// Package event defines the domain events raised by aggregates.
+package event
+
+import (
+	"github.com/google/uuid"
+	"github.com/telemetryflow/order-service/internal/domain"
+)
+
+// Order event names
+const (
+	OrderCreatedName       = "order.created"
+	OrderUpdatedName       = "order.updated"
+	OrderStatusChangedName = "order.status_changed"
+	OrderCancelledName     = "order.cancelled"
+	OrderDeletedName       = "order.deleted"
+	OrderItemAddedName     = "order.item_added"
+	OrderItemUpdatedName   = "order.item_updated"
+	OrderItemRemovedName   = "order.item_removed"
+)
+
+// OrderCreated is raised when a new order is created
+type OrderCreated struct {
+	Metadata
+	OrderID    uuid.UUID `json:"order_id"`
+	CustomerID uuid.UUID `json:"customer_id"`
+	Currency   string    `json:"currency"`
+	Status     string    `json:"status"`
+}
+
+// EventName returns the event name
+func (OrderCreated) EventName() string { return OrderCreatedName }
+
+// AggregateID returns the order ID
+func (e OrderCreated) AggregateID() uuid.UUID { return e.OrderID }
+
+// OrderUpdated is raised when the order details are changed
+type OrderUpdated struct {
+	Metadata
+	OrderID    uuid.UUID `json:"order_id"`
+	CustomerID uuid.UUID `json:"customer_id"`
+}
+
+// EventName returns the event name
+func (OrderUpdated) EventName() string { return OrderUpdatedName }
+
+// AggregateID returns the order ID
+func (e OrderUpdated) AggregateID() uuid.UUID { return e.OrderID }
+
+// OrderStatusChanged is raised on every order status transition
+type OrderStatusChanged struct {
+	Metadata
+	OrderID uuid.UUID `json:"order_id"`
+	From    string    `json:"from"`
+	To      string    `json:"to"`
+}
+
+// EventName returns the event name
+func (OrderStatusChanged) EventName() string { return OrderStatusChangedName }
+
+// AggregateID returns the order ID
+func (e OrderStatusChanged) AggregateID() uuid.UUID { return e.OrderID }
+
+// OrderCancelled is raised when an order is cancelled
+type OrderCancelled struct {
+	Metadata
+	OrderID        uuid.UUID `json:"order_id"`
+	PreviousStatus string    `json:"previous_status"`
+}
+
+// EventName returns the event name
+func (OrderCancelled) EventName() string { return OrderCancelledName }
+
+// AggregateID returns the order ID
+func (e OrderCancelled) AggregateID() uuid.UUID { return e.OrderID }
+
+// OrderDeleted is raised when an order is deleted
+type OrderDeleted struct {
+	Metadata
+	OrderID uuid.UUID `json:"order_id"`
+	Status  string    `json:"status"`
+}
+
+// EventName returns the event name
+func (OrderDeleted) EventName() string { return OrderDeletedName }
+
+// AggregateID returns the order ID
+func (e OrderDeleted) AggregateID() uuid.UUID { return e.OrderID }
+
+// OrderItemAdded is raised when an item is added to an order
+type OrderItemAdded struct {
+	Metadata
+	OrderID   uuid.UUID    `json:"order_id"`
+	ItemID    uuid.UUID    `json:"item_id"`
+	ProductID uuid.UUID    `json:"product_id"`
+	Quantity  int          `json:"quantity"`
+	Price     domain.Money `json:"price"`
+}
+
+// EventName returns the event name
+func (OrderItemAdded) EventName() string { return OrderItemAddedName }
+
+// AggregateID returns the order ID
+func (e OrderItemAdded) AggregateID() uuid.UUID { return e.OrderID }
+
+// OrderItemUpdated is raised when an order item is changed.
+// PreviousOrderID differs from OrderID when the item moved to another order.
+type OrderItemUpdated struct {
+	Metadata
+	OrderID         uuid.UUID    `json:"order_id"`
+	PreviousOrderID uuid.UUID    `json:"previous_order_id"`
+	ItemID          uuid.UUID    `json:"item_id"`
+	ProductID       uuid.UUID    `json:"product_id"`
+	Quantity        int          `json:"quantity"`
+	Price           domain.Money `json:"price"`
+}
+
+// EventName returns the event name
+func (OrderItemUpdated) EventName() string { return OrderItemUpdatedName }
+
+// AggregateID returns the order ID
+func (e OrderItemUpdated) AggregateID() uuid.UUID { return e.OrderID }
+
+// OrderItemRemoved is raised when an item is removed from an order
+type OrderItemRemoved struct {
+	Metadata
+	OrderID uuid.UUID `json:"order_id"`
+	ItemID  uuid.UUID `json:"item_id"`
+}
+
+// EventName returns the event name
+func (OrderItemRemoved) EventName() string { return OrderItemRemovedName }
+
+// AggregateID returns the order ID
+func (e OrderItemRemoved) AggregateID() uuid.UUID { return e.OrderID }
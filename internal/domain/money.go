@@ -0,0 +1,285 @@
+// Package domain provides domain types and utilities.
+package domain
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// DefaultCurrency is the currency used when none is specified
+const DefaultCurrency = "USD"
+
+// moneyScale is the number of decimal places kept for every amount.
+// It matches the decimal(15,2) columns used for monetary values.
+const moneyScale = 2
+
+// moneyFactor is 10^moneyScale
+const moneyFactor = 100
+
+// Money errors
+var (
+	ErrCurrencyMismatch = errors.New("currency mismatch")
+	ErrInvalidAmount    = errors.New("invalid monetary amount")
+	ErrInvalidCurrency  = errors.New("invalid currency code")
+)
+
+// Money is an exact monetary amount stored in minor units (cents) together
+// with its ISO-4217 currency code.
+//
+// An empty currency means "unspecified": it is what a value scanned from a
+// single decimal column or decoded from JSON carries until the owning
+// aggregate assigns its currency, and it adopts the other operand's currency
+// in arithmetic.
+type Money struct {
+	amount   int64
+	currency string
+}
+
+// NewMoney creates Money from an amount in minor units
+func NewMoney(amount int64, currency string) Money {
+	return Money{amount: amount, currency: currency}
+}
+
+// ZeroMoney returns a zero amount in the given currency
+func ZeroMoney(currency string) Money {
+	return Money{currency: currency}
+}
+
+// ParseMoney parses a decimal string such as "12.50" or "-3" into Money
+func ParseMoney(s, currency string) (Money, error) {
+	amount, err := parseMinorUnits(s)
+	if err != nil {
+		return Money{}, err
+	}
+	return Money{amount: amount, currency: currency}, nil
+}
+
+// MustParseMoney is like ParseMoney but panics on invalid input
+func MustParseMoney(s, currency string) Money {
+	m, err := ParseMoney(s, currency)
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
+
+// ValidateCurrency checks that code looks like an ISO-4217 currency code
+func ValidateCurrency(code string) error {
+	if len(code) != 3 {
+		return fmt.Errorf("%w: %q", ErrInvalidCurrency, code)
+	}
+	for _, r := range code {
+		if r < 'A' || r > 'Z' {
+			return fmt.Errorf("%w: %q", ErrInvalidCurrency, code)
+		}
+	}
+	return nil
+}
+
+// Amount returns the amount in minor units
+func (m Money) Amount() int64 {
+	return m.amount
+}
+
+// Currency returns the ISO-4217 currency code
+func (m Money) Currency() string {
+	return m.currency
+}
+
+// WithCurrency returns a copy of m in the given currency
+func (m Money) WithCurrency(currency string) Money {
+	m.currency = currency
+	return m
+}
+
+// IsZero returns true if the amount is zero
+func (m Money) IsZero() bool {
+	return m.amount == 0
+}
+
+// IsNegative returns true if the amount is below zero
+func (m Money) IsNegative() bool {
+	return m.amount < 0
+}
+
+// Equal returns true if both amount and currency match
+func (m Money) Equal(other Money) bool {
+	return m.amount == other.amount && m.currency == other.currency
+}
+
+// Add returns m + other
+func (m Money) Add(other Money) (Money, error) {
+	currency, err := m.combine(other)
+	if err != nil {
+		return Money{}, err
+	}
+	return Money{amount: m.amount + other.amount, currency: currency}, nil
+}
+
+// Sub returns m - other
+func (m Money) Sub(other Money) (Money, error) {
+	currency, err := m.combine(other)
+	if err != nil {
+		return Money{}, err
+	}
+	return Money{amount: m.amount - other.amount, currency: currency}, nil
+}
+
+// Multiply returns m * n. It fails with ErrInvalidAmount when the product
+// does not fit in the amount.
+func (m Money) Multiply(n int64) (Money, error) {
+	product := m.amount * n
+	if m.amount != 0 && (product/m.amount != n || (m.amount == -1 && n == math.MinInt64)) {
+		return Money{}, fmt.Errorf("%w: %s * %d is out of range", ErrInvalidAmount, m, n)
+	}
+	return Money{amount: product, currency: m.currency}, nil
+}
+
+// combine resolves the currency of a binary operation
+func (m Money) combine(other Money) (string, error) {
+	switch {
+	case m.currency == other.currency, other.currency == "":
+		return m.currency, nil
+	case m.currency == "":
+		return other.currency, nil
+	default:
+		return "", fmt.Errorf("%w: %s and %s", ErrCurrencyMismatch, m.currency, other.currency)
+	}
+}
+
+// String formats the amount as a decimal string, e.g. "12.50"
+func (m Money) String() string {
+	sign := ""
+	amount := m.amount
+	if amount < 0 {
+		sign = "-"
+		amount = -amount
+	}
+	return fmt.Sprintf("%s%d.%0*d", sign, amount/moneyFactor, moneyScale, amount%moneyFactor)
+}
+
+// MarshalJSON encodes the amount as a JSON string, e.g. "12.50"
+func (m Money) MarshalJSON() ([]byte, error) {
+	return json.Marshal(m.String())
+}
+
+// UnmarshalJSON decodes a JSON string ("12.50") or number (12.5) without
+// going through float64
+func (m *Money) UnmarshalJSON(data []byte) error {
+	s := strings.TrimSpace(string(data))
+	if s == "null" {
+		return nil
+	}
+	if strings.HasPrefix(s, `"`) {
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+	}
+	amount, err := parseMinorUnits(s)
+	if err != nil {
+		return err
+	}
+	m.amount = amount
+	return nil
+}
+
+// UnmarshalText decodes a decimal string such as "12.50", e.g. a query parameter
+func (m *Money) UnmarshalText(text []byte) error {
+	amount, err := parseMinorUnits(strings.TrimSpace(string(text)))
+	if err != nil {
+		return err
+	}
+	m.amount = amount
+	return nil
+}
+
+// Value implements driver.Valuer, storing the amount as a decimal string
+func (m Money) Value() (driver.Value, error) {
+	return m.String(), nil
+}
+
+// Scan implements sql.Scanner for decimal columns
+func (m *Money) Scan(src interface{}) error {
+	var amount int64
+	var err error
+
+	switch v := src.(type) {
+	case nil:
+		amount = 0
+	case []byte:
+		amount, err = parseMinorUnits(string(v))
+	case string:
+		amount, err = parseMinorUnits(v)
+	case int64:
+		amount = v * moneyFactor
+	case float64:
+		amount, err = parseMinorUnits(strconv.FormatFloat(v, 'f', -1, 64))
+	default:
+		return fmt.Errorf("%w: cannot scan %T", ErrInvalidAmount, src)
+	}
+	if err != nil {
+		return err
+	}
+
+	m.amount = amount
+	return nil
+}
+
+// parseMinorUnits converts a decimal string into minor units
+func parseMinorUnits(s string) (int64, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, fmt.Errorf("%w: empty", ErrInvalidAmount)
+	}
+
+	negative := false
+	switch s[0] {
+	case '-':
+		negative = true
+		s = s[1:]
+	case '+':
+		s = s[1:]
+	}
+
+	whole, frac, _ := strings.Cut(s, ".")
+	if whole == "" && frac == "" {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidAmount, s)
+	}
+	// Extra trailing zeros (e.g. "1.500" from a numeric(15,3) driver value) are harmless
+	frac = strings.TrimRight(frac, "0")
+	if len(frac) > moneyScale {
+		return 0, fmt.Errorf("%w: more than %d decimal places in %q", ErrInvalidAmount, moneyScale, s)
+	}
+	if !isDigits(whole) || !isDigits(frac) {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidAmount, s)
+	}
+
+	frac += strings.Repeat("0", moneyScale-len(frac))
+	if whole == "" {
+		whole = "0"
+	}
+
+	amount, err := strconv.ParseInt(whole+frac, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidAmount, s)
+	}
+	if negative {
+		amount = -amount
+	}
+	return amount, nil
+}
+
+// isDigits reports whether s only contains ASCII digits
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,36 @@
+// Package repository defines read consistency markers.
+package repository
+
+import "context"
+
+// replicaReadsKey and primaryReadsKey are the context keys of the read
+// consistency markers
+type (
+	replicaReadsKey struct{}
+	primaryReadsKey struct{}
+)
+
+// WithReplicaReads marks ctx as tolerating replication lag: repositories may
+// serve its reads from a read replica. Query handlers mark their contexts;
+// reads made without the marker, and every read inside a unit of work, use
+// the primary database.
+func WithReplicaReads(ctx context.Context) context.Context {
+	return context.WithValue(ctx, replicaReadsKey{}, true)
+}
+
+// WithPrimaryReads requires the reads of ctx to use the primary database,
+// overriding WithReplicaReads. It gives a client that has just changed data
+// read-your-writes consistency.
+func WithPrimaryReads(ctx context.Context) context.Context {
+	return context.WithValue(ctx, primaryReadsKey{}, true)
+}
+
+// ReplicaReadsAllowed reports whether the reads of ctx may be served from a
+// read replica
+func ReplicaReadsAllowed(ctx context.Context) bool {
+	if primary, _ := ctx.Value(primaryReadsKey{}).(bool); primary {
+		return false
+	}
+	replica, _ := ctx.Value(replicaReadsKey{}).(bool)
+	return replica
+}
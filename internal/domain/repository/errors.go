@@ -0,0 +1,36 @@
+// Package repository defines repository interfaces.
+package repository
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/telemetryflow/order-service/internal/domain"
+)
+
+// ErrVersionConflict is matched by ConflictError
+var ErrVersionConflict = errors.New("version conflict")
+
+// ErrUnavailable is returned when the database cannot be reached, e.g.
+// while it fails over or its circuit breaker is open. The operation may
+// succeed when retried later.
+var ErrUnavailable = errors.New("storage unavailable")
+
+// ConflictError is returned by versioned updates when the stored entity
+// no longer has the version the caller loaded, i.e. it was modified
+// concurrently.
+type ConflictError struct {
+	Entity  string
+	ID      uuid.UUID
+	Version int64
+}
+
+func (e *ConflictError) Error() string {
+	return fmt.Sprintf("%s %s was modified concurrently (expected version %d)", e.Entity, e.ID, e.Version)
+}
+
+// Is reports whether target is ErrVersionConflict or domain.ErrEntityConflict
+func (e *ConflictError) Is(target error) bool {
+	return target == ErrVersionConflict || target == domain.ErrEntityConflict
+}
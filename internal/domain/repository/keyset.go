@@ -0,0 +1,24 @@
+// Package repository defines keyset pagination requests.
+package repository
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Keyset is a position in a listing ordered by created_at and id
+type Keyset struct {
+	CreatedAt time.Time
+	ID        uuid.UUID
+}
+
+// KeysetPage requests a page of a listing ordered by created_at and id in
+// Direction. Rows strictly after After, or strictly before Before, are
+// returned; with neither set the page starts at the beginning.
+type KeysetPage struct {
+	After     *Keyset
+	Before    *Keyset
+	Limit     int
+	Direction SortDirection
+}
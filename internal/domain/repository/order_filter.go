@@ -0,0 +1,63 @@
+// Package repository defines the order listing specification.
+package repository
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/telemetryflow/order-service/internal/domain"
+)
+
+// SortDirection is the direction of a sort
+type SortDirection string
+
+// Sort directions
+const (
+	SortAsc  SortDirection = "asc"
+	SortDesc SortDirection = "desc"
+)
+
+// Sort orders a listing by a single field
+type Sort struct {
+	Field     string
+	Direction SortDirection
+}
+
+// Order sort fields
+const (
+	OrderSortCreatedAt = "created_at"
+	OrderSortUpdatedAt = "updated_at"
+	OrderSortTotal     = "total"
+	OrderSortStatus    = "status"
+)
+
+// IsOrderSortField reports whether orders can be sorted by field
+func IsOrderSortField(field string) bool {
+	switch field {
+	case OrderSortCreatedAt, OrderSortUpdatedAt, OrderSortTotal, OrderSortStatus:
+		return true
+	}
+	return false
+}
+
+// OrderFilter specifies which orders a listing returns and in which order.
+// Zero-valued fields do not constrain the result; ranges are inclusive.
+type OrderFilter struct {
+	// Statuses matches orders in any of the given statuses
+	Statuses   []string
+	CustomerID *uuid.UUID
+
+	CreatedFrom *time.Time
+	CreatedTo   *time.Time
+	MinTotal    *domain.Money
+	MaxTotal    *domain.Money
+
+	// Search matches orders whose ID, customer ID or status contains the
+	// text, ignoring case
+	Search string
+
+	// Sort defaults to created_at descending
+	Sort   Sort
+	Offset int
+	Limit  int
+}
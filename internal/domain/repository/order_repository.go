@@ -19,7 +19,22 @@ type OrderRepository interface {
 	// FindAll finds all orders with pagination
 	FindAll(ctx context.Context, offset, limit int) ([]entity.Order, int64, error)
 
-	// Update updates an existing order
+	// List finds the orders matching filter and returns them with the total
+	// number of matches
+	List(ctx context.Context, filter OrderFilter) ([]entity.Order, int64, error)
+
+	// FindPage finds up to page.Limit orders matching filter, ignoring its
+	// sort and offset, without counting them. Orders are returned in page
+	// order; more reports whether further orders exist beyond the page in the
+	// direction of travel.
+	FindPage(ctx context.Context, filter OrderFilter, page KeysetPage) (orders []entity.Order, more bool, err error)
+
+	// Search finds the orders matching the full-text query text, most
+	// relevant first, and returns them with the total number of matches
+	Search(ctx context.Context, text string, offset, limit int) ([]OrderSearchHit, int64, error)
+
+	// Update updates an existing order and increments its version.
+	// It returns a *ConflictError when the stored version differs from e.Version.
 	Update(ctx context.Context, e *entity.Order) error
 
 	// Delete soft-deletes a order by ID
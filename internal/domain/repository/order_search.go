@@ -0,0 +1,25 @@
+// Package repository defines the order full-text search specification.
+package repository
+
+import "github.com/telemetryflow/order-service/internal/domain/entity"
+
+// Order search fields reported in OrderSearchHit.Highlights
+const (
+	OrderSearchFieldID         = "id"
+	OrderSearchFieldCustomerID = "customer_id"
+	OrderSearchFieldStatus     = "status"
+	OrderSearchFieldTags       = "tags"
+	OrderSearchFieldNotes      = "notes"
+)
+
+// OrderSearchHit is an order matching a full-text search
+type OrderSearchHit struct {
+	Order entity.Order
+
+	// Rank orders hits by relevance; higher is better
+	Rank float64
+
+	// Highlights maps each matched field to a fragment of its value with the
+	// matched terms enclosed in <mark> and </mark>
+	Highlights map[string]string
+}
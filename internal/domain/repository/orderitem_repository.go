@@ -19,7 +19,13 @@ type OrderitemRepository interface {
 	// FindAll finds all orderitems with pagination
 	FindAll(ctx context.Context, offset, limit int) ([]entity.Orderitem, int64, error)
 
-	// Update updates an existing orderitem
+	// FindPage finds up to page.Limit orderitems without counting them.
+	// Items are returned in page order; more reports whether further items
+	// exist beyond the page in the direction of travel.
+	FindPage(ctx context.Context, page KeysetPage) (items []entity.Orderitem, more bool, err error)
+
+	// Update updates an existing orderitem and increments its version.
+	// It returns a *ConflictError when the stored version differs from e.Version.
 	Update(ctx context.Context, e *entity.Orderitem) error
 
 	// Delete soft-deletes a orderitem by ID
@@ -0,0 +1,16 @@
+// Package repository defines repository interfaces.
+package repository
+
+import (
+	"context"
+
+	"github.com/telemetryflow/order-service/internal/domain/event"
+)
+
+// OutboxRepository stores domain events for reliable delivery.
+// Events appended inside a UnitOfWork are committed atomically with the
+// aggregate changes that raised them and are later published by the relay.
+type OutboxRepository interface {
+	// Append stores events as pending outbox messages
+	Append(ctx context.Context, events ...event.Event) error
+}
@@ -0,0 +1,48 @@
+// Package repository defines repository interfaces.
+package repository
+
+import "context"
+
+// UnitOfWork groups repository calls into a single atomic operation
+type UnitOfWork interface {
+	// Do runs fn inside a transaction. Repository calls made with the context
+	// passed to fn join that transaction, which is committed when fn returns nil
+	// and rolled back otherwise. Nested calls join the outer transaction.
+	Do(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// afterCommitKey is the context key under which a unit of work collects
+// the callbacks registered with AfterCommit
+type afterCommitKey struct{}
+
+// afterCommitHooks are the callbacks registered in a unit of work
+type afterCommitHooks struct {
+	fns []func(ctx context.Context)
+}
+
+// AfterCommit runs fn once the unit of work carried by ctx has committed,
+// and drops it if the unit of work rolls back. Nested units of work defer
+// fn until the outermost one commits. Without a unit of work, fn runs
+// immediately.
+//
+// fn receives the context the outermost unit of work was started with, so
+// it never runs inside the committed transaction.
+func AfterCommit(ctx context.Context, fn func(ctx context.Context)) {
+	if hooks, ok := ctx.Value(afterCommitKey{}).(*afterCommitHooks); ok {
+		hooks.fns = append(hooks.fns, fn)
+		return
+	}
+	fn(ctx)
+}
+
+// WithAfterCommit returns a copy of ctx collecting AfterCommit callbacks, and
+// a function running them in order. UnitOfWork implementations call it when
+// they begin a transaction, and run the callbacks once it has committed.
+func WithAfterCommit(ctx context.Context) (context.Context, func()) {
+	hooks := &afterCommitHooks{}
+	return context.WithValue(ctx, afterCommitKey{}, hooks), func() {
+		for _, fn := range hooks.fns {
+			fn(ctx)
+		}
+	}
+}
@@ -0,0 +1,75 @@
+// Package domain provides the per-field validation errors of domain entities.
+package domain
+
+import (
+	"sort"
+	"strings"
+)
+
+// FieldErrors collects invariant violations keyed by field name, using the
+// JSON field names of the entity, e.g. "quantity" or "items[0].price"
+type FieldErrors map[string]string
+
+// Add records message for field, keeping the first message of a field
+func (f FieldErrors) Add(field, message string) {
+	if _, ok := f[field]; !ok {
+		f[field] = message
+	}
+}
+
+// Merge adds the field errors of err under prefix, e.g. "items[0].".
+// Errors that are not a *ValidationError are recorded under the bare
+// prefix.
+func (f FieldErrors) Merge(prefix string, err error) {
+	verr, ok := err.(*ValidationError)
+	if !ok {
+		if err != nil {
+			f.Add(strings.TrimSuffix(prefix, "."), err.Error())
+		}
+		return
+	}
+	for field, message := range verr.Fields {
+		f.Add(prefix+field, message)
+	}
+}
+
+// Err returns a *ValidationError holding the collected field errors,
+// or nil if there are none
+func (f FieldErrors) Err() error {
+	if len(f) == 0 {
+		return nil
+	}
+	return &ValidationError{Fields: f}
+}
+
+// ValidationError reports the invariants an entity violates.
+// It matches ErrInvalidEntity with errors.Is.
+type ValidationError struct {
+	Fields map[string]string
+}
+
+// Error lists the field errors sorted by field name
+func (e *ValidationError) Error() string {
+	fields := make([]string, 0, len(e.Fields))
+	for field := range e.Fields {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	var b strings.Builder
+	b.WriteString(ErrInvalidEntity.Error())
+	for i, field := range fields {
+		if i == 0 {
+			b.WriteString(": ")
+		} else {
+			b.WriteString("; ")
+		}
+		b.WriteString(field + ": " + e.Fields[field])
+	}
+	return b.String()
+}
+
+// Unwrap returns ErrInvalidEntity
+func (e *ValidationError) Unwrap() error {
+	return ErrInvalidEntity
+}
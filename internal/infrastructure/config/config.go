@@ -11,12 +11,15 @@ import (
 
 // Config holds all application configuration
 type Config struct {
-	Server    ServerConfig
-	Database  DatabaseConfig
-	JWT       JWTConfig
-	RateLimit RateLimitConfig
-	Telemetry TelemetryConfig
-	Log       LogConfig
+	Server      ServerConfig
+	Database    DatabaseConfig
+	JWT         JWTConfig
+	RateLimit   RateLimitConfig
+	Telemetry   TelemetryConfig
+	Log         LogConfig
+	Outbox      OutboxConfig
+	Idempotency IdempotencyConfig
+	Pagination  PaginationConfig
 }
 
 // ServerConfig holds HTTP server configuration
@@ -28,6 +31,8 @@ type ServerConfig struct {
 
 // DatabaseConfig holds database configuration
 type DatabaseConfig struct {
+	// Driver is "postgres" or "sqlite". For sqlite, Name is the database file
+	// path or ":memory:" and the host and credentials are ignored.
 	Driver          string        `mapstructure:"driver"`
 	Host            string        `mapstructure:"host"`
 	Port            string        `mapstructure:"port"`
@@ -39,6 +44,43 @@ type DatabaseConfig struct {
 	MaxIdleConns    int           `mapstructure:"max_idle_conns"`
 	ConnMaxLifetime time.Duration `mapstructure:"conn_max_lifetime"`
 	Debug           bool          `mapstructure:"debug"`
+	// AutoMigrate applies pending migrations when the server starts
+	AutoMigrate bool `mapstructure:"auto_migrate"`
+	// StrictSchema refuses to start when the schema differs from the entities.
+	// It is ignored for SQLite, whose schema cannot be inspected.
+	StrictSchema bool `mapstructure:"strict_schema"`
+	// Replicas are the "host" or "host:port" addresses of PostgreSQL read
+	// replicas sharing the primary's name and credentials. Query handlers
+	// read from them; writes and transactions use the primary.
+	Replicas []string `mapstructure:"replicas"`
+	// ReplicaCheckInterval is how often replica health is checked
+	ReplicaCheckInterval time.Duration `mapstructure:"replica_check_interval"`
+	// ReadYourWritesWindow is how long a client's reads go to the primary
+	// after it changed data, covering the replication lag
+	ReadYourWritesWindow time.Duration `mapstructure:"read_your_writes_window"`
+	// Resilience configures timeouts, retries and the circuit breaker
+	Resilience ResilienceConfig `mapstructure:"resilience"`
+}
+
+// ResilienceConfig holds database timeout, retry and circuit breaker
+// configuration
+type ResilienceConfig struct {
+	// ReadTimeout and WriteTimeout bound each read and write statement,
+	// retries included; zero disables the timeout
+	ReadTimeout  time.Duration `mapstructure:"read_timeout"`
+	WriteTimeout time.Duration `mapstructure:"write_timeout"`
+	// RetryAttempts is how many times a read outside a transaction is
+	// attempted when it fails with a transient error
+	RetryAttempts int `mapstructure:"retry_attempts"`
+	// RetryBackoff is the delay before the first retry, doubled for each
+	// further retry
+	RetryBackoff time.Duration `mapstructure:"retry_backoff"`
+	// BreakerThreshold is the number of consecutive transient failures
+	// opening the circuit breaker; zero disables the breaker
+	BreakerThreshold int `mapstructure:"breaker_threshold"`
+	// BreakerCooldown is how long the open breaker fails statements fast
+	// before letting one through to probe the database
+	BreakerCooldown time.Duration `mapstructure:"breaker_cooldown"`
 }
 
 // JWTConfig holds JWT authentication configuration
@@ -63,6 +105,30 @@ type TelemetryConfig struct {
 	ServiceVersion string `mapstructure:"service_version"`
 }
 
+// OutboxConfig holds transactional outbox relay configuration
+type OutboxConfig struct {
+	Enabled      bool          `mapstructure:"enabled"`
+	Publisher    string        `mapstructure:"publisher"`
+	FilePath     string        `mapstructure:"file_path"`
+	PollInterval time.Duration `mapstructure:"poll_interval"`
+	BatchSize    int           `mapstructure:"batch_size"`
+	MaxAttempts  int           `mapstructure:"max_attempts"`
+	RetryBackoff time.Duration `mapstructure:"retry_backoff"`
+}
+
+// IdempotencyConfig holds Idempotency-Key middleware configuration
+type IdempotencyConfig struct {
+	TTL             time.Duration `mapstructure:"ttl"`
+	CleanupInterval time.Duration `mapstructure:"cleanup_interval"`
+}
+
+// PaginationConfig holds list pagination configuration
+type PaginationConfig struct {
+	// CursorSecret signs pagination cursors; a random per-process key is used
+	// when empty, so cursors do not survive restarts or work across instances
+	CursorSecret string `mapstructure:"cursor_secret"`
+}
+
 // LogConfig holds logging configuration
 type LogConfig struct {
 	Level  string `mapstructure:"level"`
@@ -96,6 +162,17 @@ func Load() (*Config, error) {
 	viper.SetDefault("database.max_idle_conns", 5)
 	viper.SetDefault("database.conn_max_lifetime", "5m")
 	viper.SetDefault("database.debug", false)
+	viper.SetDefault("database.auto_migrate", false)
+	viper.SetDefault("database.strict_schema", false)
+	viper.SetDefault("database.replicas", []string{})
+	viper.SetDefault("database.replica_check_interval", "10s")
+	viper.SetDefault("database.read_your_writes_window", "5s")
+	viper.SetDefault("database.resilience.read_timeout", "5s")
+	viper.SetDefault("database.resilience.write_timeout", "10s")
+	viper.SetDefault("database.resilience.retry_attempts", 3)
+	viper.SetDefault("database.resilience.retry_backoff", "100ms")
+	viper.SetDefault("database.resilience.breaker_threshold", 5)
+	viper.SetDefault("database.resilience.breaker_cooldown", "30s")
 	viper.SetDefault("jwt.expiration", "24h")
 	viper.SetDefault("jwt.refresh_expiration", "168h")
 	viper.SetDefault("ratelimit.requests", 100)
@@ -107,6 +184,17 @@ func Load() (*Config, error) {
 	viper.SetDefault("log.level", "info")
 	viper.SetDefault("log.format", "json")
 
+	viper.SetDefault("outbox.enabled", true)
+	viper.SetDefault("outbox.publisher", "ndjson")
+	viper.SetDefault("outbox.file_path", "outbox.ndjson")
+	viper.SetDefault("outbox.poll_interval", "1s")
+	viper.SetDefault("outbox.batch_size", 100)
+	viper.SetDefault("outbox.max_attempts", 10)
+	viper.SetDefault("outbox.retry_backoff", "5s")
+
+	viper.SetDefault("idempotency.ttl", "24h")
+	viper.SetDefault("idempotency.cleanup_interval", "1h")
+
 	// Bind environment variables
 	viper.AutomaticEnv()
 	viper.SetEnvPrefix("")
@@ -121,6 +209,17 @@ func Load() (*Config, error) {
 	_ = viper.BindEnv("database.password", "DB_PASSWORD")
 	_ = viper.BindEnv("database.ssl_mode", "DB_SSL_MODE")
 	_ = viper.BindEnv("database.debug", "DB_DEBUG")
+	_ = viper.BindEnv("database.auto_migrate", "DB_AUTO_MIGRATE")
+	_ = viper.BindEnv("database.strict_schema", "DB_STRICT_SCHEMA")
+	_ = viper.BindEnv("database.replicas", "DB_REPLICAS")
+	_ = viper.BindEnv("database.replica_check_interval", "DB_REPLICA_CHECK_INTERVAL")
+	_ = viper.BindEnv("database.read_your_writes_window", "DB_READ_YOUR_WRITES_WINDOW")
+	_ = viper.BindEnv("database.resilience.read_timeout", "DB_READ_TIMEOUT")
+	_ = viper.BindEnv("database.resilience.write_timeout", "DB_WRITE_TIMEOUT")
+	_ = viper.BindEnv("database.resilience.retry_attempts", "DB_RETRY_ATTEMPTS")
+	_ = viper.BindEnv("database.resilience.retry_backoff", "DB_RETRY_BACKOFF")
+	_ = viper.BindEnv("database.resilience.breaker_threshold", "DB_BREAKER_THRESHOLD")
+	_ = viper.BindEnv("database.resilience.breaker_cooldown", "DB_BREAKER_COOLDOWN")
 	_ = viper.BindEnv("jwt.secret", "JWT_SECRET")
 	_ = viper.BindEnv("jwt.expiration", "JWT_EXPIRATION")
 	_ = viper.BindEnv("telemetry.api_key_id", "TELEMETRYFLOW_API_KEY_ID")
@@ -130,6 +229,14 @@ func Load() (*Config, error) {
 
 	_ = viper.BindEnv("log.level", "LOG_LEVEL")
 
+	_ = viper.BindEnv("outbox.enabled", "OUTBOX_ENABLED")
+	_ = viper.BindEnv("outbox.publisher", "OUTBOX_PUBLISHER")
+	_ = viper.BindEnv("outbox.file_path", "OUTBOX_FILE_PATH")
+
+	_ = viper.BindEnv("idempotency.ttl", "IDEMPOTENCY_TTL")
+
+	_ = viper.BindEnv("pagination.cursor_secret", "PAGINATION_CURSOR_SECRET")
+
 	// Read config file (optional)
 	if err := viper.ReadInConfig(); err != nil {
 		if _, ok := err.(viper.ConfigFileNotFoundError); !ok {
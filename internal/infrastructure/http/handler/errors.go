@@ -0,0 +1,133 @@
+// Package handler provides the mapping of application errors to HTTP responses.
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/telemetryflow/order-service/internal/application/bus"
+	"github.com/telemetryflow/order-service/internal/application/command"
+	"github.com/telemetryflow/order-service/internal/application/query"
+	"github.com/telemetryflow/order-service/internal/domain"
+	"github.com/telemetryflow/order-service/internal/domain/repository"
+	"github.com/telemetryflow/order-service/pkg/response"
+	"github.com/telemetryflow/order-service/pkg/validator"
+)
+
+// errorStatus maps command and query error codes to HTTP status codes.
+// Codes not listed here are client errors reported as 400.
+var errorStatus = map[string]int{
+	"INVALID_ID":          http.StatusBadRequest,
+	"INVALID_CURSOR":      http.StatusBadRequest,
+	"VALIDATION_ERROR":    http.StatusBadRequest,
+	"UNAUTHORIZED":        http.StatusUnauthorized,
+	"FORBIDDEN":           http.StatusForbidden,
+	"NOT_FOUND":           http.StatusNotFound,
+	"ALREADY_EXISTS":      http.StatusConflict,
+	"PRECONDITION_FAILED": http.StatusPreconditionFailed,
+}
+
+// retryAfter is the Retry-After delay, in seconds, of 503 responses
+const retryAfter = "5"
+
+// HTTPErrorHandler renders errors returned by handlers and middleware in the
+// standard response envelope. It is installed as echo.Echo.HTTPErrorHandler.
+func HTTPErrorHandler(err error, c echo.Context) {
+	if c.Response().Committed {
+		return
+	}
+	if werr := writeError(c, err); werr != nil {
+		c.Logger().Error(werr)
+	}
+}
+
+// writeError sends the response for err. Validation errors are reported
+// as 400 with per-field details, and errors without a known mapping as 500
+// with a generic message.
+func writeError(c echo.Context, err error) error {
+	if fields, ok := validationFields(err); ok {
+		return response.ValidationError(c, fields)
+	}
+
+	status, code, message := classifyError(err)
+	if status == http.StatusServiceUnavailable {
+		c.Response().Header().Set("Retry-After", retryAfter)
+	} else if status >= http.StatusInternalServerError {
+		return response.ServerError(c, err)
+	}
+	return response.Error(c, status, code, message)
+}
+
+// validationFields returns the per-field details of request, command and
+// entity validation errors
+func validationFields(err error) (map[string]string, bool) {
+	var (
+		reqErr    *validator.ValidationError
+		entityErr *domain.ValidationError
+	)
+
+	switch {
+	case errors.As(err, &reqErr):
+		return reqErr.Errors, true
+	case errors.As(err, &entityErr):
+		return entityErr.Fields, true
+	default:
+		return nil, false
+	}
+}
+
+// classifyError returns the HTTP status, error code and client message for err
+func classifyError(err error) (int, string, string) {
+	var (
+		httpErr *echo.HTTPError
+		cmdErr  *command.CommandError
+		qryErr  *query.QueryError
+	)
+
+	switch {
+	case errors.As(err, &httpErr):
+		message, ok := httpErr.Message.(string)
+		if !ok {
+			message = http.StatusText(httpErr.Code)
+		}
+		return httpErr.Code, statusCode(httpErr.Code), message
+	case errors.As(err, &cmdErr):
+		return codeStatus(cmdErr.Code), cmdErr.Code, cmdErr.Message
+	case errors.As(err, &qryErr):
+		return codeStatus(qryErr.Code), qryErr.Code, qryErr.Message
+	case errors.Is(err, bus.ErrForbidden):
+		return http.StatusForbidden, "FORBIDDEN", "Access forbidden"
+	case errors.Is(err, repository.ErrUnavailable):
+		return http.StatusServiceUnavailable, "SERVICE_UNAVAILABLE", "The service is temporarily unavailable, please retry later"
+	case errors.Is(err, domain.ErrEntityNotFound):
+		return http.StatusNotFound, "NOT_FOUND", err.Error()
+	case errors.Is(err, repository.ErrVersionConflict),
+		errors.Is(err, domain.ErrEntityConflict),
+		errors.Is(err, domain.ErrInvalidStateTransition):
+		return http.StatusConflict, "CONFLICT", err.Error()
+	case errors.Is(err, domain.ErrInvalidEntity),
+		errors.Is(err, domain.ErrTotalMismatch),
+		errors.Is(err, domain.ErrInvalidAmount),
+		errors.Is(err, domain.ErrInvalidCurrency),
+		errors.Is(err, domain.ErrCurrencyMismatch):
+		return http.StatusUnprocessableEntity, "UNPROCESSABLE_ENTITY", err.Error()
+	default:
+		return http.StatusInternalServerError, "INTERNAL_ERROR", ""
+	}
+}
+
+// codeStatus returns the HTTP status for a command or query error code
+func codeStatus(code string) int {
+	if status, ok := errorStatus[code]; ok {
+		return status
+	}
+	return http.StatusBadRequest
+}
+
+// statusCode derives an error code from an HTTP status, e.g. 429 becomes
+// TOO_MANY_REQUESTS
+func statusCode(status int) string {
+	return strings.ToUpper(strings.ReplaceAll(http.StatusText(status), " ", "_"))
+}
@@ -0,0 +1,56 @@
+// Package handler provides entity tag helpers for optimistic concurrency.
+package handler
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/telemetryflow/order-service/pkg/response"
+)
+
+const (
+	headerETag    = "ETag"
+	headerIfMatch = "If-Match"
+)
+
+var (
+	errIfMatchRequired = errors.New("If-Match header is required")
+	errIfMatchInvalid  = errors.New("If-Match header must be \"*\" or an ETag returned by GET")
+)
+
+// setETag sets the ETag header for a resource version
+func setETag(c echo.Context, version int64) {
+	c.Response().Header().Set(headerETag, strconv.Quote(strconv.FormatInt(version, 10)))
+}
+
+// ifMatchVersion returns the version in the If-Match header.
+// "*" matches any version and yields zero.
+func ifMatchVersion(c echo.Context) (int64, error) {
+	value := strings.TrimSpace(c.Request().Header.Get(headerIfMatch))
+	if value == "" {
+		return 0, errIfMatchRequired
+	}
+	if value == "*" {
+		return 0, nil
+	}
+
+	tag, err := strconv.Unquote(value)
+	if err != nil {
+		return 0, errIfMatchInvalid
+	}
+	version, err := strconv.ParseInt(tag, 10, 64)
+	if err != nil || version < 1 {
+		return 0, errIfMatchInvalid
+	}
+	return version, nil
+}
+
+// ifMatchError maps If-Match parsing errors to HTTP responses
+func ifMatchError(c echo.Context, err error) error {
+	if errors.Is(err, errIfMatchRequired) {
+		return response.PreconditionRequired(c, err.Error())
+	}
+	return response.BadRequest(c, err.Error())
+}
@@ -6,6 +6,8 @@ import (
 	"time"
 
 	"github.com/labstack/echo/v4"
+	"github.com/telemetryflow/order-service/internal/infrastructure/persistence"
+	"github.com/telemetryflow/order-service/pkg/circuitbreaker"
 	"gorm.io/gorm"
 )
 
@@ -65,6 +67,31 @@ func (h *HealthHandler) Ready(c echo.Context) error {
 			})
 		}
 		checks["database"] = "healthy"
+
+		// An open circuit breaker fails every statement fast. It lets a
+		// probe through once its cooldown has elapsed (half open).
+		if breaker := persistence.CircuitBreaker(h.db); breaker != nil {
+			state := breaker.State()
+			checks["circuit_breaker"] = state.String()
+			if state == circuitbreaker.Open {
+				return c.JSON(http.StatusServiceUnavailable, HealthResponse{
+					Status:    "unhealthy",
+					Timestamp: time.Now(),
+					Checks:    checks,
+				})
+			}
+		}
+
+		// Unhealthy replicas are reported but do not make the service
+		// unready: their reads fall back to the primary
+		if replicas := persistence.Replicas(h.db); replicas != nil {
+			for _, status := range replicas.Check(c.Request().Context()) {
+				checks["replica "+status.Name] = "healthy"
+				if status.Err != nil {
+					checks["replica "+status.Name] = "unhealthy: " + status.Err.Error()
+				}
+			}
+		}
 	}
 
 	return c.JSON(http.StatusOK, HealthResponse{
@@ -2,29 +2,30 @@
 package handler
 
 import (
+	"net/http"
+
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
+	"github.com/telemetryflow/order-service/internal/application/bus"
 	"github.com/telemetryflow/order-service/internal/application/command"
 	"github.com/telemetryflow/order-service/internal/application/dto"
-	"github.com/telemetryflow/order-service/internal/application/handler"
 	"github.com/telemetryflow/order-service/internal/application/query"
 	"github.com/telemetryflow/order-service/pkg/response"
 )
 
 // OrderHandler handles order HTTP requests
 type OrderHandler struct {
-	commandHandler *handler.OrderCommandHandler
-	queryHandler   *handler.OrderQueryHandler
+	commands *bus.CommandBus
+	queries  *bus.QueryBus
 }
 
-// NewOrderHandler creates a new order handler
-func NewOrderHandler(
-	cmdHandler *handler.OrderCommandHandler,
-	qryHandler *handler.OrderQueryHandler,
-) *OrderHandler {
+// NewOrderHandler creates a new order handler dispatching to the order
+// command and query handlers registered on commands and queries. The buses
+// must validate messages (see bus.Validation).
+func NewOrderHandler(commands *bus.CommandBus, queries *bus.QueryBus) *OrderHandler {
 	return &OrderHandler{
-		commandHandler: cmdHandler,
-		queryHandler:   qryHandler,
+		commands: commands,
+		queries:  queries,
 	}
 }
 
@@ -32,12 +33,21 @@ func NewOrderHandler(
 func (h *OrderHandler) RegisterRoutes(g *echo.Group) {
 	g.POST("/orders", h.Create)
 	g.GET("/orders", h.List)
+	g.GET("/orders/search", h.Search)
 	g.GET("/orders/:id", h.GetByID)
 	g.PUT("/orders/:id", h.Update)
+	g.PATCH("/orders/:id", h.Patch)
 	g.DELETE("/orders/:id", h.Delete)
+	g.POST("/orders/:id/confirm", h.Confirm)
+	g.POST("/orders/:id/pay", h.Pay)
+	g.POST("/orders/:id/ship", h.Ship)
+	g.POST("/orders/:id/deliver", h.Deliver)
+	g.POST("/orders/:id/cancel", h.Cancel)
 }
 
-// Create handles POST /orders
+// Create handles POST /orders.
+// It replies with the created order, including its items, or with no body
+// if the client sends Prefer: return=minimal.
 func (h *OrderHandler) Create(c echo.Context) error {
 	var req dto.CreateOrderRequest
 	if err := c.Bind(&req); err != nil {
@@ -45,33 +55,87 @@ func (h *OrderHandler) Create(c echo.Context) error {
 	}
 
 	if err := c.Validate(&req); err != nil {
-		return response.BadRequest(c, err.Error())
+		return writeError(c, err)
 	}
 
 	cmd := &command.CreateOrderCommand{
 		CustomerID: req.CustomerID,
+		Currency:   req.Currency,
+		Discount:   req.Discount,
+		Tax:        req.Tax,
+		Shipping:   req.Shipping,
 		Total:      req.Total,
 		Status:     req.Status,
+		Tags:       req.Tags,
+		Notes:      req.Notes,
+		Items:      make([]command.CreateOrderItem, len(req.Items)),
+	}
+	for i, item := range req.Items {
+		cmd.Items[i] = command.CreateOrderItem{
+			ProductID: item.ProductID,
+			Quantity:  item.Quantity,
+			Price:     item.Price,
+		}
 	}
 
-	if err := h.commandHandler.HandleOrderCreate(c.Request().Context(), cmd); err != nil {
-		return response.InternalError(c, err.Error())
+	ctx := c.Request().Context()
+	result, err := bus.Send[command.CommandResult](ctx, h.commands, cmd)
+	if err != nil {
+		return writeError(c, err)
 	}
 
-	return response.Created(c, nil, "Order created successfully")
+	if preferMinimal(c) {
+		setLocation(c, result.ID)
+		setETag(c, result.Version)
+		return c.NoContent(http.StatusCreated)
+	}
+
+	order, err := bus.Ask[*dto.OrderResponse](ctx, h.queries, &query.GetOrderByIDQuery{ID: result.ID, WithItems: true})
+	if err != nil {
+		return writeError(c, err)
+	}
+
+	setLocation(c, order.ID)
+	setETag(c, order.Version)
+	return response.Created(c, order, "Order created successfully")
 }
 
-// List handles GET /orders
+// List handles GET /orders with optional filters, sorting and search.
+// Passing a cursor parameter, empty for the first page, switches from
+// offset to keyset pagination.
 func (h *OrderHandler) List(c echo.Context) error {
 	var q query.GetAllOrdersQuery
 	if err := c.Bind(&q); err != nil {
 		return response.BadRequest(c, "Invalid query parameters")
 	}
-	_ = q.Validate()
 
-	result, err := h.queryHandler.HandleOrderGetAll(c.Request().Context(), &q)
+	if q.Keyset() {
+		page, err := bus.Ask[*dto.CursorPage[*dto.OrderResponse]](c.Request().Context(), h.queries, &query.GetOrdersPageQuery{GetAllOrdersQuery: q})
+		if err != nil {
+			return writeError(c, err)
+		}
+		return response.CursorPaginated(c, page.Data, page.Limit, page.NextCursor, page.PrevCursor)
+	}
+
+	result, err := bus.Ask[*dto.OrderListResponse](c.Request().Context(), h.queries, &q)
 	if err != nil {
-		return response.InternalError(c, err.Error())
+		return writeError(c, err)
+	}
+
+	return response.Success(c, result, "")
+}
+
+// Search handles GET /orders/search?q= with full-text search over order
+// IDs, customer IDs, statuses, tags and notes
+func (h *OrderHandler) Search(c echo.Context) error {
+	var q query.SearchOrdersQuery
+	if err := c.Bind(&q); err != nil {
+		return response.BadRequest(c, "Invalid query parameters")
+	}
+
+	result, err := bus.Ask[*dto.OrderSearchResponse](c.Request().Context(), h.queries, &q)
+	if err != nil {
+		return writeError(c, err)
 	}
 
 	return response.Success(c, result, "")
@@ -84,56 +148,206 @@ func (h *OrderHandler) GetByID(c echo.Context) error {
 		return response.BadRequest(c, "Invalid ID format")
 	}
 
-	q := &query.GetOrderByIDQuery{ID: id}
-	result, err := h.queryHandler.HandleOrderGetByID(c.Request().Context(), q)
+	q := &query.GetOrderByIDQuery{ID: id, Expand: c.QueryParam("expand")}
+	result, err := bus.Ask[*dto.OrderResponse](c.Request().Context(), h.queries, q)
 	if err != nil {
-		return response.NotFound(c, "Order not found")
+		return writeError(c, err)
 	}
 
+	setETag(c, result.Version)
 	return response.Success(c, result, "")
 }
 
-// Update handles PUT /orders/:id
+// Update handles PUT /orders/:id.
+// The If-Match header must carry the ETag returned by GET. It replies with
+// the updated order, or with 204 if the client sends Prefer: return=minimal.
 func (h *OrderHandler) Update(c echo.Context) error {
 	id, err := uuid.Parse(c.Param("id"))
 	if err != nil {
 		return response.BadRequest(c, "Invalid ID format")
 	}
 
+	version, err := ifMatchVersion(c)
+	if err != nil {
+		return ifMatchError(c, err)
+	}
+
 	var req dto.UpdateOrderRequest
 	if err := c.Bind(&req); err != nil {
 		return response.BadRequest(c, "Invalid request body")
 	}
 
 	if err := c.Validate(&req); err != nil {
-		return response.BadRequest(c, err.Error())
+		return writeError(c, err)
+	}
+
+	return h.update(c, id, version, &req)
+}
+
+// Patch handles PATCH /orders/:id.
+// The body is a JSON Merge Patch or JSON Patch document, selected by the
+// Content-Type, that is applied to the update request of the current order.
+// The result is validated and saved like a PUT. With If-Match "*" the
+// update still fails if the order changes after it was read.
+func (h *OrderHandler) Patch(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return response.BadRequest(c, "Invalid ID format")
+	}
+
+	version, err := ifMatchVersion(c)
+	if err != nil {
+		return ifMatchError(c, err)
+	}
+
+	current, err := bus.Ask[*dto.OrderResponse](c.Request().Context(), h.queries, &query.GetOrderByIDQuery{ID: id})
+	if err != nil {
+		return writeError(c, err)
+	}
+	if version == 0 {
+		version = current.Version
+	}
+
+	var req dto.UpdateOrderRequest
+	if err := applyPatch(c, dto.UpdateOrderRequestFrom(current), &req); err != nil {
+		return patchError(c, err)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return writeError(c, err)
 	}
 
+	return h.update(c, id, version, &req)
+}
+
+// update dispatches the update of order id at version and replies with the
+// updated order, or with 204 if the client prefers a minimal response
+func (h *OrderHandler) update(c echo.Context, id uuid.UUID, version int64, req *dto.UpdateOrderRequest) error {
 	cmd := &command.UpdateOrderCommand{
 		ID:         id,
+		Version:    version,
 		CustomerID: req.CustomerID,
+		Discount:   req.Discount,
+		Tax:        req.Tax,
+		Shipping:   req.Shipping,
 		Total:      req.Total,
 		Status:     req.Status,
+		Tags:       req.Tags,
+		Notes:      req.Notes,
+	}
+
+	ctx := c.Request().Context()
+	result, err := bus.Send[command.CommandResult](ctx, h.commands, cmd)
+	if err != nil {
+		return writeError(c, err)
 	}
 
-	if err := h.commandHandler.HandleOrderUpdate(c.Request().Context(), cmd); err != nil {
-		return response.InternalError(c, err.Error())
+	if preferMinimal(c) {
+		setETag(c, result.Version)
+		return response.NoContent(c)
 	}
 
-	return response.Success(c, nil, "Order updated successfully")
+	order, err := bus.Ask[*dto.OrderResponse](ctx, h.queries, &query.GetOrderByIDQuery{ID: result.ID})
+	if err != nil {
+		return writeError(c, err)
+	}
+
+	setETag(c, order.Version)
+	return response.Success(c, order, "Order updated successfully")
 }
 
-// Delete handles DELETE /orders/:id
+// Delete handles DELETE /orders/:id.
+// The If-Match header must carry the ETag returned by GET.
 func (h *OrderHandler) Delete(c echo.Context) error {
 	id, err := uuid.Parse(c.Param("id"))
 	if err != nil {
 		return response.BadRequest(c, "Invalid ID format")
 	}
 
-	cmd := &command.DeleteOrderCommand{ID: id}
-	if err := h.commandHandler.HandleOrderDelete(c.Request().Context(), cmd); err != nil {
-		return response.InternalError(c, err.Error())
+	version, err := ifMatchVersion(c)
+	if err != nil {
+		return ifMatchError(c, err)
+	}
+
+	cmd := &command.DeleteOrderCommand{ID: id, Version: version}
+	if err := bus.Exec(c.Request().Context(), h.commands, cmd); err != nil {
+		return writeError(c, err)
 	}
 
 	return response.NoContent(c)
 }
+
+// Confirm handles POST /orders/:id/confirm
+func (h *OrderHandler) Confirm(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return response.BadRequest(c, "Invalid ID format")
+	}
+
+	cmd := &command.ConfirmOrderCommand{ID: id}
+	if err := bus.Exec(c.Request().Context(), h.commands, cmd); err != nil {
+		return writeError(c, err)
+	}
+
+	return response.Success(c, nil, "Order confirmed successfully")
+}
+
+// Pay handles POST /orders/:id/pay
+func (h *OrderHandler) Pay(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return response.BadRequest(c, "Invalid ID format")
+	}
+
+	cmd := &command.PayOrderCommand{ID: id}
+	if err := bus.Exec(c.Request().Context(), h.commands, cmd); err != nil {
+		return writeError(c, err)
+	}
+
+	return response.Success(c, nil, "Order paid successfully")
+}
+
+// Ship handles POST /orders/:id/ship
+func (h *OrderHandler) Ship(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return response.BadRequest(c, "Invalid ID format")
+	}
+
+	cmd := &command.ShipOrderCommand{ID: id}
+	if err := bus.Exec(c.Request().Context(), h.commands, cmd); err != nil {
+		return writeError(c, err)
+	}
+
+	return response.Success(c, nil, "Order shipped successfully")
+}
+
+// Deliver handles POST /orders/:id/deliver
+func (h *OrderHandler) Deliver(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return response.BadRequest(c, "Invalid ID format")
+	}
+
+	cmd := &command.DeliverOrderCommand{ID: id}
+	if err := bus.Exec(c.Request().Context(), h.commands, cmd); err != nil {
+		return writeError(c, err)
+	}
+
+	return response.Success(c, nil, "Order delivered successfully")
+}
+
+// Cancel handles POST /orders/:id/cancel
+func (h *OrderHandler) Cancel(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return response.BadRequest(c, "Invalid ID format")
+	}
+
+	cmd := &command.CancelOrderCommand{ID: id}
+	if err := bus.Exec(c.Request().Context(), h.commands, cmd); err != nil {
+		return writeError(c, err)
+	}
+
+	return response.Success(c, nil, "Order cancelled successfully")
+}
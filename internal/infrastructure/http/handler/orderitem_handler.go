@@ -2,29 +2,30 @@
 package handler
 
 import (
+	"net/http"
+
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
+	"github.com/telemetryflow/order-service/internal/application/bus"
 	"github.com/telemetryflow/order-service/internal/application/command"
 	"github.com/telemetryflow/order-service/internal/application/dto"
-	"github.com/telemetryflow/order-service/internal/application/handler"
 	"github.com/telemetryflow/order-service/internal/application/query"
 	"github.com/telemetryflow/order-service/pkg/response"
 )
 
 // OrderitemHandler handles orderitem HTTP requests
 type OrderitemHandler struct {
-	commandHandler *handler.OrderitemCommandHandler
-	queryHandler   *handler.OrderitemQueryHandler
+	commands *bus.CommandBus
+	queries  *bus.QueryBus
 }
 
-// NewOrderitemHandler creates a new orderitem handler
-func NewOrderitemHandler(
-	cmdHandler *handler.OrderitemCommandHandler,
-	qryHandler *handler.OrderitemQueryHandler,
-) *OrderitemHandler {
+// NewOrderitemHandler creates a new orderitem handler dispatching to the
+// orderitem command and query handlers registered on commands and queries.
+// The buses must validate messages (see bus.Validation).
+func NewOrderitemHandler(commands *bus.CommandBus, queries *bus.QueryBus) *OrderitemHandler {
 	return &OrderitemHandler{
-		commandHandler: cmdHandler,
-		queryHandler:   qryHandler,
+		commands: commands,
+		queries:  queries,
 	}
 }
 
@@ -34,10 +35,19 @@ func (h *OrderitemHandler) RegisterRoutes(g *echo.Group) {
 	g.GET("/order-items", h.List)
 	g.GET("/order-items/:id", h.GetByID)
 	g.PUT("/order-items/:id", h.Update)
+	g.PATCH("/order-items/:id", h.Patch)
 	g.DELETE("/order-items/:id", h.Delete)
+
+	g.GET("/orders/:id/items", h.ListByOrder)
+	g.POST("/orders/:id/items", h.CreateInOrder)
+	g.GET("/orders/:id/items/:itemId", h.GetInOrder)
+	g.PUT("/orders/:id/items/:itemId", h.UpdateInOrder)
+	g.DELETE("/orders/:id/items/:itemId", h.DeleteInOrder)
 }
 
-// Create handles POST /order-items
+// Create handles POST /order-items.
+// It replies with the created item, or with no body if the client sends
+// Prefer: return=minimal.
 func (h *OrderitemHandler) Create(c echo.Context) error {
 	var req dto.CreateOrderitemRequest
 	if err := c.Bind(&req); err != nil {
@@ -45,34 +55,37 @@ func (h *OrderitemHandler) Create(c echo.Context) error {
 	}
 
 	if err := c.Validate(&req); err != nil {
-		return response.BadRequest(c, err.Error())
+		return writeError(c, err)
 	}
 
-	cmd := &command.CreateOrderitemCommand{
+	return h.create(c, &command.CreateOrderitemCommand{
 		OrderID:   req.OrderID,
 		ProductID: req.ProductID,
 		Quantity:  req.Quantity,
 		Price:     req.Price,
-	}
-
-	if err := h.commandHandler.HandleOrderitemCreate(c.Request().Context(), cmd); err != nil {
-		return response.InternalError(c, err.Error())
-	}
-
-	return response.Created(c, nil, "Orderitem created successfully")
+	})
 }
 
-// List handles GET /order-items
+// List handles GET /order-items.
+// Passing a cursor parameter, empty for the first page, switches from
+// offset to keyset pagination.
 func (h *OrderitemHandler) List(c echo.Context) error {
 	var q query.GetAllOrderItemsQuery
 	if err := c.Bind(&q); err != nil {
 		return response.BadRequest(c, "Invalid query parameters")
 	}
-	_ = q.Validate()
 
-	result, err := h.queryHandler.HandleOrderitemGetAll(c.Request().Context(), &q)
+	if q.Keyset() {
+		page, err := bus.Ask[*dto.CursorPage[*dto.OrderitemResponse]](c.Request().Context(), h.queries, &query.GetOrderItemsPageQuery{GetAllOrderItemsQuery: q})
+		if err != nil {
+			return writeError(c, err)
+		}
+		return response.CursorPaginated(c, page.Data, page.Limit, page.NextCursor, page.PrevCursor)
+	}
+
+	result, err := bus.Ask[*dto.OrderitemListResponse](c.Request().Context(), h.queries, &q)
 	if err != nil {
-		return response.InternalError(c, err.Error())
+		return writeError(c, err)
 	}
 
 	return response.Success(c, result, "")
@@ -86,56 +99,280 @@ func (h *OrderitemHandler) GetByID(c echo.Context) error {
 	}
 
 	q := &query.GetOrderitemByIDQuery{ID: id}
-	result, err := h.queryHandler.HandleOrderitemGetByID(c.Request().Context(), q)
+	result, err := bus.Ask[*dto.OrderitemResponse](c.Request().Context(), h.queries, q)
 	if err != nil {
-		return response.NotFound(c, "Orderitem not found")
+		return writeError(c, err)
 	}
 
+	setETag(c, result.Version)
 	return response.Success(c, result, "")
 }
 
-// Update handles PUT /order-items/:id
+// Update handles PUT /order-items/:id.
+// The If-Match header must carry the ETag returned by GET. It replies with
+// the updated item, or with 204 if the client sends Prefer: return=minimal.
 func (h *OrderitemHandler) Update(c echo.Context) error {
 	id, err := uuid.Parse(c.Param("id"))
 	if err != nil {
 		return response.BadRequest(c, "Invalid ID format")
 	}
 
+	version, err := ifMatchVersion(c)
+	if err != nil {
+		return ifMatchError(c, err)
+	}
+
 	var req dto.UpdateOrderitemRequest
 	if err := c.Bind(&req); err != nil {
 		return response.BadRequest(c, "Invalid request body")
 	}
 
 	if err := c.Validate(&req); err != nil {
-		return response.BadRequest(c, err.Error())
+		return writeError(c, err)
 	}
 
-	cmd := &command.UpdateOrderitemCommand{
+	return h.update(c, &command.UpdateOrderitemCommand{
 		ID:        id,
+		Version:   version,
 		OrderID:   req.OrderID,
 		ProductID: req.ProductID,
 		Quantity:  req.Quantity,
 		Price:     req.Price,
+	})
+}
+
+// Delete handles DELETE /order-items/:id.
+// The If-Match header must carry the ETag returned by GET.
+func (h *OrderitemHandler) Delete(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return response.BadRequest(c, "Invalid ID format")
 	}
 
-	if err := h.commandHandler.HandleOrderitemUpdate(c.Request().Context(), cmd); err != nil {
-		return response.InternalError(c, err.Error())
+	version, err := ifMatchVersion(c)
+	if err != nil {
+		return ifMatchError(c, err)
 	}
 
-	return response.Success(c, nil, "Orderitem updated successfully")
+	return h.delete(c, &command.DeleteOrderitemCommand{ID: id, Version: version})
 }
 
-// Delete handles DELETE /order-items/:id
-func (h *OrderitemHandler) Delete(c echo.Context) error {
+// Patch handles PATCH /order-items/:id.
+// The body is a JSON Merge Patch or JSON Patch document, selected by the
+// Content-Type, that is applied to the update request of the current item.
+// The result is validated and saved like a PUT. With If-Match "*" the
+// update still fails if the item changes after it was read.
+func (h *OrderitemHandler) Patch(c echo.Context) error {
 	id, err := uuid.Parse(c.Param("id"))
 	if err != nil {
 		return response.BadRequest(c, "Invalid ID format")
 	}
 
-	cmd := &command.DeleteOrderitemCommand{ID: id}
-	if err := h.commandHandler.HandleOrderitemDelete(c.Request().Context(), cmd); err != nil {
-		return response.InternalError(c, err.Error())
+	version, err := ifMatchVersion(c)
+	if err != nil {
+		return ifMatchError(c, err)
+	}
+
+	current, err := bus.Ask[*dto.OrderitemResponse](c.Request().Context(), h.queries, &query.GetOrderitemByIDQuery{ID: id})
+	if err != nil {
+		return writeError(c, err)
+	}
+	if version == 0 {
+		version = current.Version
+	}
+
+	var req dto.UpdateOrderitemRequest
+	if err := applyPatch(c, dto.UpdateOrderitemRequestFrom(current), &req); err != nil {
+		return patchError(c, err)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return writeError(c, err)
+	}
+
+	return h.update(c, &command.UpdateOrderitemCommand{
+		ID:        id,
+		Version:   version,
+		OrderID:   req.OrderID,
+		ProductID: req.ProductID,
+		Quantity:  req.Quantity,
+		Price:     req.Price,
+	})
+}
+
+// ListByOrder handles GET /orders/:id/items
+func (h *OrderitemHandler) ListByOrder(c echo.Context) error {
+	orderID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return response.BadRequest(c, "Invalid ID format")
+	}
+
+	items, err := bus.Ask[[]*dto.OrderitemResponse](c.Request().Context(), h.queries, &query.GetOrderItemsByOrderQuery{OrderID: orderID})
+	if err != nil {
+		return writeError(c, err)
+	}
+
+	return response.Success(c, items, "")
+}
+
+// CreateInOrder handles POST /orders/:id/items.
+// It replies like Create, with the Location of the nested item.
+func (h *OrderitemHandler) CreateInOrder(c echo.Context) error {
+	orderID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return response.BadRequest(c, "Invalid ID format")
+	}
+
+	var req dto.OrderItemLineRequest
+	if err := c.Bind(&req); err != nil {
+		return response.BadRequest(c, "Invalid request body")
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return writeError(c, err)
+	}
+
+	return h.create(c, &command.CreateOrderitemCommand{
+		OrderID:   orderID,
+		ProductID: req.ProductID,
+		Quantity:  req.Quantity,
+		Price:     req.Price,
+	})
+}
+
+// GetInOrder handles GET /orders/:id/items/:itemId.
+// Items of other orders are not found.
+func (h *OrderitemHandler) GetInOrder(c echo.Context) error {
+	orderID, itemID, err := nestedItemIDs(c)
+	if err != nil {
+		return response.BadRequest(c, "Invalid ID format")
+	}
+
+	q := &query.GetOrderitemByIDQuery{ID: itemID, ParentOrderID: orderID}
+	item, err := bus.Ask[*dto.OrderitemResponse](c.Request().Context(), h.queries, q)
+	if err != nil {
+		return writeError(c, err)
+	}
+
+	setETag(c, item.Version)
+	return response.Success(c, item, "")
+}
+
+// UpdateInOrder handles PUT /orders/:id/items/:itemId.
+// The item stays in the order of the path; items of other orders are not
+// found. The If-Match header must carry the ETag returned by GET.
+func (h *OrderitemHandler) UpdateInOrder(c echo.Context) error {
+	orderID, itemID, err := nestedItemIDs(c)
+	if err != nil {
+		return response.BadRequest(c, "Invalid ID format")
+	}
+
+	version, err := ifMatchVersion(c)
+	if err != nil {
+		return ifMatchError(c, err)
+	}
+
+	var req dto.OrderItemLineRequest
+	if err := c.Bind(&req); err != nil {
+		return response.BadRequest(c, "Invalid request body")
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return writeError(c, err)
+	}
+
+	return h.update(c, &command.UpdateOrderitemCommand{
+		ID:            itemID,
+		Version:       version,
+		ParentOrderID: orderID,
+		OrderID:       orderID,
+		ProductID:     req.ProductID,
+		Quantity:      req.Quantity,
+		Price:         req.Price,
+	})
+}
+
+// DeleteInOrder handles DELETE /orders/:id/items/:itemId.
+// Items of other orders are not found. The If-Match header must carry the
+// ETag returned by GET.
+func (h *OrderitemHandler) DeleteInOrder(c echo.Context) error {
+	orderID, itemID, err := nestedItemIDs(c)
+	if err != nil {
+		return response.BadRequest(c, "Invalid ID format")
+	}
+
+	version, err := ifMatchVersion(c)
+	if err != nil {
+		return ifMatchError(c, err)
+	}
+
+	return h.delete(c, &command.DeleteOrderitemCommand{ID: itemID, Version: version, ParentOrderID: orderID})
+}
+
+// create dispatches cmd and replies with the created item, or with no body
+// if the client prefers a minimal response
+func (h *OrderitemHandler) create(c echo.Context, cmd *command.CreateOrderitemCommand) error {
+	ctx := c.Request().Context()
+	result, err := bus.Send[command.CommandResult](ctx, h.commands, cmd)
+	if err != nil {
+		return writeError(c, err)
+	}
+
+	if preferMinimal(c) {
+		setLocation(c, result.ID)
+		setETag(c, result.Version)
+		return c.NoContent(http.StatusCreated)
+	}
+
+	item, err := bus.Ask[*dto.OrderitemResponse](ctx, h.queries, &query.GetOrderitemByIDQuery{ID: result.ID})
+	if err != nil {
+		return writeError(c, err)
+	}
+
+	setLocation(c, item.ID)
+	setETag(c, item.Version)
+	return response.Created(c, item, "Orderitem created successfully")
+}
+
+// update dispatches cmd and replies with the updated item, or with 204 if
+// the client prefers a minimal response
+func (h *OrderitemHandler) update(c echo.Context, cmd *command.UpdateOrderitemCommand) error {
+	ctx := c.Request().Context()
+	result, err := bus.Send[command.CommandResult](ctx, h.commands, cmd)
+	if err != nil {
+		return writeError(c, err)
+	}
+
+	if preferMinimal(c) {
+		setETag(c, result.Version)
+		return response.NoContent(c)
+	}
+
+	item, err := bus.Ask[*dto.OrderitemResponse](ctx, h.queries, &query.GetOrderitemByIDQuery{ID: result.ID})
+	if err != nil {
+		return writeError(c, err)
+	}
+
+	setETag(c, item.Version)
+	return response.Success(c, item, "Orderitem updated successfully")
+}
+
+// delete dispatches cmd and replies with 204
+func (h *OrderitemHandler) delete(c echo.Context, cmd *command.DeleteOrderitemCommand) error {
+	if err := bus.Exec(c.Request().Context(), h.commands, cmd); err != nil {
+		return writeError(c, err)
 	}
 
 	return response.NoContent(c)
 }
+
+// nestedItemIDs parses the order and item IDs of a nested item path
+func nestedItemIDs(c echo.Context) (orderID, itemID uuid.UUID, err error) {
+	if orderID, err = uuid.Parse(c.Param("id")); err != nil {
+		return uuid.Nil, uuid.Nil, err
+	}
+	if itemID, err = uuid.Parse(c.Param("itemId")); err != nil {
+		return uuid.Nil, uuid.Nil, err
+	}
+	return orderID, itemID, nil
+}
@@ -0,0 +1,81 @@
+// Package handler provides the application of PATCH documents.
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/telemetryflow/order-service/pkg/jsonpatch"
+	"github.com/telemetryflow/order-service/pkg/response"
+)
+
+const headerAcceptPatch = "Accept-Patch"
+
+// acceptPatch lists the supported patch media types
+const acceptPatch = jsonpatch.MergePatchType + ", " + jsonpatch.JSONPatchType
+
+var (
+	errPatchMediaType = errors.New("Content-Type must be " + jsonpatch.MergePatchType + " or " + jsonpatch.JSONPatchType)
+	errPatchResult    = errors.New("patched document is not a valid update")
+)
+
+// applyPatch applies the patch in the request body to the JSON
+// representation of current and decodes the result into target.
+// The Content-Type selects JSON Merge Patch or JSON Patch.
+func applyPatch(c echo.Context, current, target interface{}) error {
+	mediaType, _, _ := mime.ParseMediaType(c.Request().Header.Get(echo.HeaderContentType))
+	if mediaType != jsonpatch.MergePatchType && mediaType != jsonpatch.JSONPatchType {
+		return errPatchMediaType
+	}
+
+	patch, err := io.ReadAll(c.Request().Body)
+	if err != nil {
+		return fmt.Errorf("%w: %v", jsonpatch.ErrInvalidPatch, err)
+	}
+	doc, err := json.Marshal(current)
+	if err != nil {
+		return err
+	}
+
+	var patched []byte
+	if mediaType == jsonpatch.MergePatchType {
+		patched, err = jsonpatch.MergePatch(doc, patch)
+	} else {
+		patched, err = jsonpatch.Apply(doc, patch)
+	}
+	if err != nil {
+		return err
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(patched))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(target); err != nil {
+		return fmt.Errorf("%w: %v", errPatchResult, err)
+	}
+	return nil
+}
+
+// patchError maps applyPatch errors to HTTP responses. A malformed patch is
+// a bad request, a failed test a conflict with the current state, and a
+// patch that cannot be applied or yields an invalid update is unprocessable.
+func patchError(c echo.Context, err error) error {
+	switch {
+	case errors.Is(err, errPatchMediaType):
+		c.Response().Header().Set(headerAcceptPatch, acceptPatch)
+		return response.Error(c, http.StatusUnsupportedMediaType, "UNSUPPORTED_MEDIA_TYPE", err.Error())
+	case errors.Is(err, jsonpatch.ErrInvalidPatch):
+		return response.BadRequest(c, err.Error())
+	case errors.Is(err, jsonpatch.ErrTestFailed):
+		return response.Conflict(c, err.Error())
+	case errors.Is(err, jsonpatch.ErrPathNotFound), errors.Is(err, errPatchResult):
+		return response.UnprocessableEntity(c, err.Error())
+	default:
+		return writeError(c, err)
+	}
+}
@@ -0,0 +1,37 @@
+// Package handler provides helpers for the representation of written resources.
+package handler
+
+import (
+	"path"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	headerPrefer            = "Prefer"
+	headerPreferenceApplied = "Preference-Applied"
+	preferReturnMinimal     = "return=minimal"
+)
+
+// preferMinimal reports whether the client sent Prefer: return=minimal
+// (RFC 7240) and, if so, acknowledges it with Preference-Applied
+func preferMinimal(c echo.Context) bool {
+	for _, header := range c.Request().Header.Values(headerPrefer) {
+		for _, preference := range strings.Split(header, ",") {
+			token, _, _ := strings.Cut(preference, ";")
+			if strings.EqualFold(strings.TrimSpace(token), preferReturnMinimal) {
+				c.Response().Header().Set(headerPreferenceApplied, preferReturnMinimal)
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// setLocation sets the Location header to the URL of the resource with id
+// created by a POST to the collection at the request path
+func setLocation(c echo.Context, id uuid.UUID) {
+	c.Response().Header().Set(echo.HeaderLocation, path.Join(c.Request().URL.Path, id.String()))
+}
@@ -7,9 +7,16 @@ import (
 
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/labstack/echo/v4"
+	"github.com/telemetryflow/order-service/internal/application/bus"
 	"github.com/telemetryflow/order-service/internal/infrastructure/config"
 )
 
+// Roles carried in the JWT role claim
+const (
+	RoleAdmin = "admin"
+	RoleUser  = "user"
+)
+
 // JWTClaims represents JWT token claims
 type JWTClaims struct {
 	UserID string `json:"user_id"`
@@ -59,6 +66,13 @@ func Auth(cfg config.JWTConfig) echo.MiddlewareFunc {
 			c.Set("role", claims.Role)
 			c.Set("claims", claims)
 
+			// Identify the caller to the command and query buses
+			req := c.Request()
+			c.SetRequest(req.WithContext(bus.WithActor(req.Context(), bus.Actor{
+				UserID: claims.UserID,
+				Role:   claims.Role,
+			})))
+
 			return next(c)
 		}
 	}
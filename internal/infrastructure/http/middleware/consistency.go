@@ -0,0 +1,91 @@
+// Package middleware provides HTTP middleware.
+package middleware
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/telemetryflow/order-service/internal/domain/repository"
+)
+
+const (
+	// HeaderConsistency lets a client require reads from the primary
+	// database by sending "strong"
+	HeaderConsistency = "X-Consistency"
+
+	// ConsistencyStrong is the HeaderConsistency value requiring primary reads
+	ConsistencyStrong = "strong"
+
+	// ReadYourWritesCookie holds the Unix time until which a client that
+	// changed data reads from the primary database
+	ReadYourWritesCookie = "read_your_writes"
+)
+
+// ReadYourWrites gives clients read-your-writes consistency when queries
+// are served from read replicas. Mutating requests read from the primary
+// and, when they succeed, set ReadYourWritesCookie so that the client's
+// reads keep using the primary for window, covering the replication lag.
+// Clients without cookies can send HeaderConsistency instead.
+func ReadYourWrites(window time.Duration) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			mutation := isMutation(req.Method)
+
+			if mutation || requiresPrimary(req) {
+				c.SetRequest(req.WithContext(repository.WithPrimaryReads(req.Context())))
+			}
+
+			if mutation && window > 0 {
+				res := c.Response()
+				res.Before(func() {
+					if res.Status < http.StatusBadRequest {
+						c.SetCookie(readYourWritesCookie(window))
+					}
+				})
+			}
+
+			return next(c)
+		}
+	}
+}
+
+// isMutation reports whether method changes data
+func isMutation(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return false
+	}
+	return true
+}
+
+// requiresPrimary reports whether req asks for primary reads, with
+// HeaderConsistency or an unexpired ReadYourWritesCookie
+func requiresPrimary(req *http.Request) bool {
+	if req.Header.Get(HeaderConsistency) == ConsistencyStrong {
+		return true
+	}
+
+	cookie, err := req.Cookie(ReadYourWritesCookie)
+	if err != nil {
+		return false
+	}
+	until, err := strconv.ParseInt(cookie.Value, 10, 64)
+	return err == nil && time.Now().Unix() < until
+}
+
+// readYourWritesCookie builds the cookie routing reads to the primary for window
+func readYourWritesCookie(window time.Duration) *http.Cookie {
+	seconds := int64(math.Ceil(window.Seconds()))
+	return &http.Cookie{
+		Name:     ReadYourWritesCookie,
+		Value:    strconv.FormatInt(time.Now().Unix()+seconds, 10),
+		Path:     "/",
+		MaxAge:   int(seconds),
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	}
+}
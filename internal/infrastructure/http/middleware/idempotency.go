@@ -0,0 +1,213 @@
+// Package middleware provides HTTP middleware.
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/telemetryflow/order-service/internal/infrastructure/config"
+)
+
+const (
+	// HeaderIdempotencyKey is the request header carrying the client-chosen key
+	HeaderIdempotencyKey = "Idempotency-Key"
+
+	// HeaderIdempotentReplayed is set on responses replayed from the store
+	HeaderIdempotentReplayed = "Idempotent-Replayed"
+
+	maxIdempotencyKeyLength = 255
+)
+
+// replayedHeaders are the response headers stored and replayed with the body
+var replayedHeaders = []string{echo.HeaderContentType, echo.HeaderLocation, "ETag", "Preference-Applied"}
+
+// IdempotencyRecord is the stored outcome of a request made with an Idempotency-Key
+type IdempotencyRecord struct {
+	Key         string
+	Fingerprint string
+	StatusCode  int
+	Header      http.Header
+	Body        []byte
+}
+
+// Completed reports whether the response has been stored.
+// A record without a response belongs to a request still in progress.
+func (r *IdempotencyRecord) Completed() bool {
+	return r.StatusCode != 0
+}
+
+// IdempotencyStore persists idempotency records
+type IdempotencyStore interface {
+	// Reserve claims key for a new request expiring after ttl. When an
+	// unexpired record already holds the key, it is returned and nothing is
+	// stored; a nil record means the key was reserved.
+	Reserve(ctx context.Context, key, fingerprint string, ttl time.Duration) (*IdempotencyRecord, error)
+
+	// Complete stores the response for a reserved key
+	Complete(ctx context.Context, key string, status int, header http.Header, body []byte) error
+
+	// Release deletes a reserved key so that the request can be retried
+	Release(ctx context.Context, key string) error
+
+	// DeleteExpired removes expired records and returns how many were deleted
+	DeleteExpired(ctx context.Context) (int64, error)
+}
+
+// Idempotency returns middleware that makes POST requests carrying an
+// Idempotency-Key header safe to retry. The first response for a key is
+// stored and replayed for repeats; reusing a key with a different request
+// is rejected with 422, and a repeat arriving while the first request is
+// still running is rejected with 409. Server errors are not stored, so the
+// client may retry them with the same key.
+//
+// Keys are scoped to the authenticated user, so the middleware should run
+// after Auth. Expired records are deleted by CleanupIdempotencyKeys.
+func Idempotency(store IdempotencyStore, cfg config.IdempotencyConfig) echo.MiddlewareFunc {
+	ttl := cfg.TTL
+	if ttl <= 0 {
+		ttl = 24 * time.Hour
+	}
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			key := strings.TrimSpace(req.Header.Get(HeaderIdempotencyKey))
+			if req.Method != http.MethodPost || key == "" {
+				return next(c)
+			}
+			if len(key) > maxIdempotencyKeyLength {
+				return echo.NewHTTPError(http.StatusBadRequest, "Idempotency-Key must be at most 255 characters")
+			}
+
+			body, err := io.ReadAll(req.Body)
+			if err != nil {
+				return echo.NewHTTPError(http.StatusBadRequest, "failed to read request body")
+			}
+			req.Body = io.NopCloser(bytes.NewReader(body))
+
+			ctx := req.Context()
+			scopedKey := GetUserID(c) + ":" + key
+			fingerprint := requestFingerprint(req, body)
+
+			existing, err := store.Reserve(ctx, scopedKey, fingerprint, ttl)
+			if err != nil {
+				return err
+			}
+			if existing != nil {
+				return replay(c, existing, fingerprint)
+			}
+
+			return capture(c, next, store, scopedKey)
+		}
+	}
+}
+
+// CleanupIdempotencyKeys deletes the expired records of store every
+// interval until ctx is done
+func CleanupIdempotencyKeys(ctx context.Context, store IdempotencyStore, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := store.DeleteExpired(ctx); err != nil && ctx.Err() == nil {
+				log.Printf("Failed to delete expired idempotency keys: %v", err)
+			}
+		}
+	}
+}
+
+// replay answers a repeated request from its stored record
+func replay(c echo.Context, record *IdempotencyRecord, fingerprint string) error {
+	if record.Fingerprint != fingerprint {
+		return echo.NewHTTPError(http.StatusUnprocessableEntity, "Idempotency-Key was already used with a different request")
+	}
+	if !record.Completed() {
+		return echo.NewHTTPError(http.StatusConflict, "a request with this Idempotency-Key is still being processed")
+	}
+
+	res := c.Response()
+	for _, name := range replayedHeaders {
+		if value := record.Header.Get(name); value != "" {
+			res.Header().Set(name, value)
+		}
+	}
+	res.Header().Set(HeaderIdempotentReplayed, "true")
+	res.WriteHeader(record.StatusCode)
+	_, err := res.Write(record.Body)
+	return err
+}
+
+// capture runs the request and stores its response under key
+func capture(c echo.Context, next echo.HandlerFunc, store IdempotencyStore, key string) error {
+	// Store calls must outlive a client that disconnects mid-request
+	ctx := context.WithoutCancel(c.Request().Context())
+
+	done := false
+	defer func() {
+		if !done {
+			if err := store.Release(ctx, key); err != nil {
+				log.Printf("Failed to release idempotency key: %v", err)
+			}
+		}
+	}()
+
+	res := c.Response()
+	writer := &bodyCapture{ResponseWriter: res.Writer}
+	res.Writer = writer
+
+	if err := next(c); err != nil {
+		c.Error(err)
+	}
+
+	if res.Status >= http.StatusInternalServerError {
+		return nil
+	}
+
+	header := http.Header{}
+	for _, name := range replayedHeaders {
+		if value := res.Header().Get(name); value != "" {
+			header.Set(name, value)
+		}
+	}
+	if err := store.Complete(ctx, key, res.Status, header, writer.body.Bytes()); err != nil {
+		log.Printf("Failed to store idempotent response: %v", err)
+		return nil
+	}
+	done = true
+	return nil
+}
+
+// requestFingerprint hashes the parts of a request that must not change
+// between retries
+func requestFingerprint(req *http.Request, body []byte) string {
+	h := sha256.New()
+	h.Write([]byte(req.Method))
+	h.Write([]byte{0})
+	h.Write([]byte(req.URL.RequestURI()))
+	h.Write([]byte{0})
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// bodyCapture copies the response body while it is written
+type bodyCapture struct {
+	http.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *bodyCapture) Write(b []byte) (int, error) {
+	w.body.Write(b)
+	return w.ResponseWriter.Write(b)
+}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/telemetryflow/order-service/internal/infrastructure/http/handler"
 	"github.com/telemetryflow/order-service/internal/infrastructure/http/middleware"
+	"github.com/telemetryflow/order-service/internal/infrastructure/persistence"
 )
 
 // setupRoutes configures all routes
@@ -29,6 +30,9 @@ func (s *Server) setupRoutes() {
 	e.Use(middleware.Logger())
 	e.Use(middleware.CORS())
 	e.Use(middleware.RateLimit(s.config.RateLimit))
+	if persistence.Replicas(s.db) != nil {
+		e.Use(middleware.ReadYourWrites(s.config.Database.ReadYourWritesWindow))
+	}
 
 	// Health check
 	healthHandler := handler.NewHealthHandler(s.db)
@@ -53,8 +57,12 @@ func (s *Server) setupRoutes() {
 		// Protected routes
 		protected := v1.Group("")
 		protected.Use(middleware.Auth(s.config.JWT))
+		protected.Use(middleware.Idempotency(persistence.NewIdempotencyStore(s.db), s.config.Idempotency))
 		{
-			// Add protected routes here
+			api := protected.Group("", middleware.RequireRole(middleware.RoleAdmin, middleware.RoleUser))
+			for _, r := range s.routes {
+				r.RegisterRoutes(api)
+			}
 		}
 	}
 
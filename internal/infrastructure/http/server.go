@@ -7,26 +7,38 @@ import (
 
 	"github.com/labstack/echo/v4"
 	"github.com/telemetryflow/order-service/internal/infrastructure/config"
+	"github.com/telemetryflow/order-service/internal/infrastructure/http/handler"
+	"github.com/telemetryflow/order-service/pkg/validator"
 	"gorm.io/gorm"
 )
 
+// RouteRegistrar registers API routes on a route group
+type RouteRegistrar interface {
+	RegisterRoutes(g *echo.Group)
+}
+
 // Server represents the HTTP server
 type Server struct {
 	echo   *echo.Echo
 	config *config.Config
 	db     *gorm.DB
+	routes []RouteRegistrar
 }
 
-// NewServer creates a new HTTP server
-func NewServer(cfg *config.Config, db *gorm.DB) *Server {
+// NewServer creates a new HTTP server.
+// The routes are mounted under /api/v1 behind authentication.
+func NewServer(cfg *config.Config, db *gorm.DB, routes ...RouteRegistrar) *Server {
 	e := echo.New()
 	e.HideBanner = true
 	e.HidePort = true
+	e.HTTPErrorHandler = handler.HTTPErrorHandler
+	e.Validator = validator.NewEchoValidator()
 
 	server := &Server{
 		echo:   e,
 		config: cfg,
 		db:     db,
+		routes: routes,
 	}
 
 	// Setup routes
@@ -0,0 +1,263 @@
+// Package migration provides the migrator that plans and applies migrations.
+package migration
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"time"
+)
+
+var (
+	// ErrUnknownVersion is returned when a target or applied version has no
+	// migration in this build
+	ErrUnknownVersion = errors.New("unknown migration version")
+	// ErrIrreversible is returned when a migration to revert has no down file
+	ErrIrreversible = errors.New("migration cannot be reverted")
+	// ErrDirty is returned when a previous run left the schema half migrated
+	ErrDirty = errors.New("database schema is dirty")
+)
+
+// Direction tells whether a migration is applied or reverted
+type Direction int
+
+// Migration directions
+const (
+	DirectionUp Direction = iota
+	DirectionDown
+)
+
+// String returns "up" or "down"
+func (d Direction) String() string {
+	if d == DirectionDown {
+		return "down"
+	}
+	return "up"
+}
+
+// Record is a migration applied to the database
+type Record struct {
+	Version   int64
+	Name      string
+	AppliedAt time.Time
+}
+
+// Step is a migration to apply or revert
+type Step struct {
+	Migration
+	Direction Direction
+}
+
+// Status describes a migration and whether it is applied. Migrations applied
+// by a newer build are listed with Missing set.
+type Status struct {
+	Version   int64
+	Name      string
+	Applied   bool
+	AppliedAt time.Time
+	Missing   bool
+}
+
+// Store records applied migrations and executes their SQL.
+// Prepare, Applied and Apply are only called while holding the lock.
+type Store interface {
+	// Lock blocks until the caller holds the migration lock and returns the
+	// function that releases it
+	Lock(ctx context.Context) (func() error, error)
+	// Prepare creates the schema_migrations table if it does not exist
+	Prepare(ctx context.Context, migrations []Migration) error
+	// Applied returns the applied migrations ordered by version
+	Applied(ctx context.Context) ([]Record, error)
+	// Apply runs a migration in the given direction and records the outcome
+	// in the same transaction
+	Apply(ctx context.Context, m Migration, dir Direction) error
+}
+
+// Migrator moves the database schema between migration versions
+type Migrator struct {
+	store      Store
+	migrations []Migration
+}
+
+// NewMigrator creates a migrator for migrations, which must be ordered by
+// version as returned by Load
+func NewMigrator(store Store, migrations []Migration) *Migrator {
+	return &Migrator{store: store, migrations: migrations}
+}
+
+// Up applies all pending migrations and returns the steps taken
+func (m *Migrator) Up(ctx context.Context) ([]Step, error) {
+	return m.run(ctx, func(applied map[int64]Record) ([]Step, error) {
+		return m.pending(applied, m.latest()), nil
+	})
+}
+
+// Down reverts the given number of most recently applied migrations
+func (m *Migrator) Down(ctx context.Context, steps int) ([]Step, error) {
+	if steps < 1 {
+		return nil, fmt.Errorf("steps must be positive, got %d", steps)
+	}
+	return m.run(ctx, func(applied map[int64]Record) ([]Step, error) {
+		versions := appliedVersions(applied)
+		if steps > len(versions) {
+			steps = len(versions)
+		}
+		return m.revert(versions[:steps])
+	})
+}
+
+// Goto migrates up or down to version. Version 0 reverts every migration.
+func (m *Migrator) Goto(ctx context.Context, version int64) ([]Step, error) {
+	if _, ok := m.find(version); !ok && version != 0 {
+		return nil, fmt.Errorf("%w: %d", ErrUnknownVersion, version)
+	}
+	return m.run(ctx, func(applied map[int64]Record) ([]Step, error) {
+		var newer []int64
+		for _, v := range appliedVersions(applied) {
+			if v > version {
+				newer = append(newer, v)
+			}
+		}
+		steps, err := m.revert(newer)
+		if err != nil {
+			return nil, err
+		}
+		return append(steps, m.pending(applied, version)...), nil
+	})
+}
+
+// Status lists every known or applied migration ordered by version
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	var statuses []Status
+	_, err := m.run(ctx, func(applied map[int64]Record) ([]Step, error) {
+		for _, mig := range m.migrations {
+			record, ok := applied[mig.Version]
+			statuses = append(statuses, Status{
+				Version:   mig.Version,
+				Name:      mig.Name,
+				Applied:   ok,
+				AppliedAt: record.AppliedAt,
+			})
+		}
+		for _, record := range applied {
+			if _, ok := m.find(record.Version); !ok {
+				statuses = append(statuses, Status{
+					Version:   record.Version,
+					Name:      record.Name,
+					Applied:   true,
+					AppliedAt: record.AppliedAt,
+					Missing:   true,
+				})
+			}
+		}
+		return nil, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].Version < statuses[j].Version
+	})
+	return statuses, nil
+}
+
+// run plans and executes steps while holding the migration lock. It returns
+// the steps that completed, which on failure are those before the failing one.
+func (m *Migrator) run(ctx context.Context, plan func(applied map[int64]Record) ([]Step, error)) (done []Step, err error) {
+	release, err := m.store.Lock(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire migration lock: %w", err)
+	}
+	defer func() {
+		if releaseErr := release(); releaseErr != nil && err == nil {
+			err = fmt.Errorf("failed to release migration lock: %w", releaseErr)
+		}
+	}()
+
+	if err := m.store.Prepare(ctx, m.migrations); err != nil {
+		return nil, err
+	}
+	records, err := m.store.Applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+	applied := make(map[int64]Record, len(records))
+	for _, record := range records {
+		applied[record.Version] = record
+	}
+
+	steps, err := plan(applied)
+	if err != nil {
+		return nil, err
+	}
+	for _, step := range steps {
+		log.Printf("Migrating %s %06d_%s", step.Direction, step.Version, step.Name)
+		if err := m.store.Apply(ctx, step.Migration, step.Direction); err != nil {
+			return done, fmt.Errorf("migration %06d_%s %s failed: %w", step.Version, step.Name, step.Direction, err)
+		}
+		done = append(done, step)
+	}
+	return done, nil
+}
+
+// pending returns the unapplied migrations up to version, oldest first
+func (m *Migrator) pending(applied map[int64]Record, version int64) []Step {
+	var steps []Step
+	for _, mig := range m.migrations {
+		if mig.Version > version {
+			break
+		}
+		if _, ok := applied[mig.Version]; !ok {
+			steps = append(steps, Step{Migration: mig, Direction: DirectionUp})
+		}
+	}
+	return steps
+}
+
+// revert returns the steps reverting versions in the given order
+func (m *Migrator) revert(versions []int64) ([]Step, error) {
+	steps := make([]Step, 0, len(versions))
+	for _, v := range versions {
+		mig, ok := m.find(v)
+		if !ok {
+			return nil, fmt.Errorf("%w: applied migration %d is not in this build", ErrUnknownVersion, v)
+		}
+		if mig.Down == "" {
+			return nil, fmt.Errorf("%w: %06d_%s has no down file", ErrIrreversible, mig.Version, mig.Name)
+		}
+		steps = append(steps, Step{Migration: mig, Direction: DirectionDown})
+	}
+	return steps, nil
+}
+
+// find returns the migration with the given version
+func (m *Migrator) find(version int64) (Migration, bool) {
+	i := sort.Search(len(m.migrations), func(i int) bool {
+		return m.migrations[i].Version >= version
+	})
+	if i < len(m.migrations) && m.migrations[i].Version == version {
+		return m.migrations[i], true
+	}
+	return Migration{}, false
+}
+
+// latest returns the highest known version
+func (m *Migrator) latest() int64 {
+	if len(m.migrations) == 0 {
+		return 0
+	}
+	return m.migrations[len(m.migrations)-1].Version
+}
+
+// appliedVersions returns the applied versions, newest first
+func appliedVersions(applied map[int64]Record) []int64 {
+	versions := make([]int64, 0, len(applied))
+	for v := range applied {
+		versions = append(versions, v)
+	}
+	sort.Slice(versions, func(i, j int) bool { return versions[i] > versions[j] })
+	return versions
+}
@@ -0,0 +1,190 @@
+// Package migration provides the PostgreSQL migration store.
+package migration
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+// lockKey identifies the migration advisory lock. It only has to differ
+// from other advisory locks taken on the same database.
+const lockKey int64 = 0x6f72646572736d67
+
+// errNotLocked is returned when the store is used without holding the lock
+var errNotLocked = errors.New("migration lock not held")
+
+// PostgresStore keeps applied migrations in schema_migrations and serializes
+// migrators with a session-level advisory lock. The lock and every migration
+// use the same dedicated connection.
+type PostgresStore struct {
+	db   *sql.DB
+	conn *sql.Conn
+}
+
+var _ Store = (*PostgresStore)(nil)
+
+// NewPostgresStore creates a migration store on db
+func NewPostgresStore(db *sql.DB) *PostgresStore {
+	return &PostgresStore{db: db}
+}
+
+// Lock takes the advisory lock on a dedicated connection, waiting for other
+// migrators to finish
+func (s *PostgresStore) Lock(ctx context.Context) (func() error, error) {
+	conn, err := s.db.Conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := conn.ExecContext(ctx, "SELECT pg_advisory_lock($1)", lockKey); err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+	s.conn = conn
+
+	return func() error {
+		s.conn = nil
+		_, err := conn.ExecContext(context.Background(), "SELECT pg_advisory_unlock($1)", lockKey)
+		return errors.Join(err, conn.Close())
+	}, nil
+}
+
+// Prepare creates schema_migrations. A golang-migrate table of the same
+// name is renamed to schema_migrations_legacy and its version carried over,
+// so databases migrated with the external tool are not migrated twice.
+func (s *PostgresStore) Prepare(ctx context.Context, migrations []Migration) error {
+	if s.conn == nil {
+		return errNotLocked
+	}
+
+	var legacy bool
+	err := s.conn.QueryRowContext(ctx, `
+		SELECT EXISTS (
+			SELECT 1 FROM information_schema.columns
+			WHERE table_schema = current_schema()
+			  AND table_name = 'schema_migrations'
+			  AND column_name = 'dirty'
+		)`).Scan(&legacy)
+	if err != nil {
+		return fmt.Errorf("failed to inspect schema_migrations: %w", err)
+	}
+	if !legacy {
+		_, err := s.conn.ExecContext(ctx, createTable)
+		if err != nil {
+			return fmt.Errorf("failed to create schema_migrations: %w", err)
+		}
+		return nil
+	}
+	return s.adoptLegacy(ctx, migrations)
+}
+
+// createTable creates the migration history table
+const createTable = `
+	CREATE TABLE IF NOT EXISTS schema_migrations (
+		version BIGINT PRIMARY KEY,
+		name VARCHAR(255) NOT NULL,
+		applied_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+	)`
+
+// adoptLegacy replaces a golang-migrate version table with schema_migrations,
+// recording every known migration up to its version as applied
+func (s *PostgresStore) adoptLegacy(ctx context.Context, migrations []Migration) error {
+	var (
+		version int64
+		dirty   bool
+	)
+	err := s.conn.QueryRowContext(ctx, "SELECT version, dirty FROM schema_migrations LIMIT 1").Scan(&version, &dirty)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("failed to read legacy schema_migrations: %w", err)
+	}
+	if dirty {
+		return fmt.Errorf("%w: legacy migration %d did not complete; repair the schema and clear the dirty flag", ErrDirty, version)
+	}
+
+	return s.inTx(ctx, func(tx *sql.Tx) error {
+		for _, stmt := range []string{
+			"ALTER TABLE schema_migrations RENAME TO schema_migrations_legacy",
+			"ALTER INDEX IF EXISTS schema_migrations_pkey RENAME TO schema_migrations_legacy_pkey",
+			createTable,
+		} {
+			if _, err := tx.ExecContext(ctx, stmt); err != nil {
+				return fmt.Errorf("failed to adopt legacy schema_migrations: %w", err)
+			}
+		}
+		for _, m := range migrations {
+			if m.Version > version {
+				break
+			}
+			if err := record(ctx, tx, m); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Applied returns the applied migrations ordered by version
+func (s *PostgresStore) Applied(ctx context.Context) ([]Record, error) {
+	if s.conn == nil {
+		return nil, errNotLocked
+	}
+
+	rows, err := s.conn.QueryContext(ctx, "SELECT version, name, applied_at FROM schema_migrations ORDER BY version")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read schema_migrations: %w", err)
+	}
+	defer rows.Close()
+
+	var records []Record
+	for rows.Next() {
+		var r Record
+		if err := rows.Scan(&r.Version, &r.Name, &r.AppliedAt); err != nil {
+			return nil, err
+		}
+		records = append(records, r)
+	}
+	return records, rows.Err()
+}
+
+// Apply runs the migration SQL and updates schema_migrations in one
+// transaction, so a failed migration leaves no trace
+func (s *PostgresStore) Apply(ctx context.Context, m Migration, dir Direction) error {
+	if s.conn == nil {
+		return errNotLocked
+	}
+
+	return s.inTx(ctx, func(tx *sql.Tx) error {
+		if dir == DirectionDown {
+			if _, err := tx.ExecContext(ctx, m.Down); err != nil {
+				return err
+			}
+			_, err := tx.ExecContext(ctx, "DELETE FROM schema_migrations WHERE version = $1", m.Version)
+			return err
+		}
+
+		if _, err := tx.ExecContext(ctx, m.Up); err != nil {
+			return err
+		}
+		return record(ctx, tx, m)
+	})
+}
+
+// inTx runs fn in a transaction on the locked connection
+func (s *PostgresStore) inTx(ctx context.Context, fn func(tx *sql.Tx) error) error {
+	tx, err := s.conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	if err := fn(tx); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+// record marks m as applied
+func record(ctx context.Context, tx *sql.Tx, m Migration) error {
+	_, err := tx.ExecContext(ctx, "INSERT INTO schema_migrations (version, name) VALUES ($1, $2)", m.Version, m.Name)
+	return err
+}
@@ -0,0 +1,46 @@
+// Package outbox provides an in-memory EventPublisher.
+package outbox
+
+import (
+	"context"
+	"sync"
+)
+
+// MemoryPublisher keeps published messages in memory.
+// It is intended for tests and local development.
+type MemoryPublisher struct {
+	mu       sync.Mutex
+	messages []Message
+}
+
+// NewMemoryPublisher creates an empty in-memory publisher
+func NewMemoryPublisher() *MemoryPublisher {
+	return &MemoryPublisher{}
+}
+
+// Publish stores the message
+func (p *MemoryPublisher) Publish(_ context.Context, msg Message) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.messages = append(p.messages, msg)
+	return nil
+}
+
+// Messages returns a copy of the published messages in publish order
+func (p *MemoryPublisher) Messages() []Message {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	messages := make([]Message, len(p.messages))
+	copy(messages, p.messages)
+	return messages
+}
+
+// Reset discards all published messages
+func (p *MemoryPublisher) Reset() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.messages = nil
+}
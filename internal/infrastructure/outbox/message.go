@@ -0,0 +1,61 @@
+// Package outbox provides the transactional outbox relay and its publishers.
+//
+// Command handlers append domain events to the outbox_events table in the
+// same transaction as the aggregate changes. The Relay polls that table,
+// delivers pending messages through an EventPublisher and records the
+// outcome, so events are neither lost on rollback nor published for changes
+// that never committed.
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Status is the delivery state of an outbox message
+type Status string
+
+// Outbox message states
+const (
+	StatusPending   Status = "pending"
+	StatusPublished Status = "published"
+	StatusDead      Status = "dead"
+)
+
+// Message is a stored domain event awaiting publication
+type Message struct {
+	ID          uuid.UUID       `json:"id"`
+	AggregateID uuid.UUID       `json:"aggregate_id"`
+	EventName   string          `json:"event_name"`
+	Payload     json.RawMessage `json:"payload"`
+	OccurredAt  time.Time       `json:"occurred_at"`
+	Attempts    int             `json:"attempts"`
+}
+
+// EventPublisher delivers outbox messages to downstream systems.
+// Delivery is at-least-once: a message may be published again if the relay
+// fails before recording the outcome, so consumers should deduplicate on ID.
+type EventPublisher interface {
+	// Publish delivers a single message
+	Publish(ctx context.Context, msg Message) error
+}
+
+// Store is the persistence used by the Relay. All methods join the unit of
+// work carried by ctx.
+type Store interface {
+	// ClaimPending locks up to limit due pending messages, oldest first.
+	// Rows locked by another relay are skipped.
+	ClaimPending(ctx context.Context, limit int) ([]Message, error)
+
+	// MarkPublished records a successful delivery
+	MarkPublished(ctx context.Context, id uuid.UUID) error
+
+	// Retry records a failed delivery and reschedules the message after delay
+	Retry(ctx context.Context, id uuid.UUID, attempts int, lastErr string, delay time.Duration) error
+
+	// MarkDead records a failed delivery and moves the message to the dead-letter state
+	MarkDead(ctx context.Context, id uuid.UUID, attempts int, lastErr string) error
+}
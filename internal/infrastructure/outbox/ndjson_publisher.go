@@ -0,0 +1,61 @@
+// Package outbox provides an EventPublisher writing newline-delimited JSON.
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+)
+
+// NDJSONPublisher writes each message as one JSON line.
+// It is intended for local development and for piping events into other tools.
+type NDJSONPublisher struct {
+	mu     sync.Mutex
+	w      io.Writer
+	closer io.Closer
+}
+
+// NewNDJSONPublisher creates a publisher writing to w
+func NewNDJSONPublisher(w io.Writer) *NDJSONPublisher {
+	return &NDJSONPublisher{
+		w: w,
+	}
+}
+
+// OpenNDJSONFile creates a publisher appending to the file at path
+func OpenNDJSONFile(path string) (*NDJSONPublisher, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open outbox file: %w", err)
+	}
+	return &NDJSONPublisher{
+		w:      f,
+		closer: f,
+	}, nil
+}
+
+// Publish writes the message followed by a newline
+func (p *NDJSONPublisher) Publish(_ context.Context, msg Message) error {
+	line, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	line = append(line, '\n')
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	_, err = p.w.Write(line)
+	return err
+}
+
+// Close closes the underlying file when the publisher owns it
+func (p *NDJSONPublisher) Close() error {
+	if p.closer == nil {
+		return nil
+	}
+	return p.closer.Close()
+}
@@ -0,0 +1,134 @@
+// Package outbox provides the relay that publishes pending outbox messages.
+package outbox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/telemetryflow/order-service/internal/domain/repository"
+	"github.com/telemetryflow/order-service/internal/infrastructure/config"
+)
+
+// maxRetryBackoff caps the exponential delay between delivery attempts
+const maxRetryBackoff = time.Hour
+
+// Relay polls the outbox and publishes pending messages.
+// Each batch is claimed with FOR UPDATE SKIP LOCKED inside a unit of work,
+// so several relay instances can run concurrently without publishing the
+// same message twice.
+type Relay struct {
+	store     Store
+	uow       repository.UnitOfWork
+	publisher EventPublisher
+	cfg       config.OutboxConfig
+}
+
+// NewRelay creates a new outbox relay
+func NewRelay(store Store, uow repository.UnitOfWork, publisher EventPublisher, cfg config.OutboxConfig) *Relay {
+	if cfg.PollInterval <= 0 {
+		cfg.PollInterval = time.Second
+	}
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = 100
+	}
+	if cfg.MaxAttempts <= 0 {
+		cfg.MaxAttempts = 10
+	}
+	if cfg.RetryBackoff <= 0 {
+		cfg.RetryBackoff = 5 * time.Second
+	}
+	return &Relay{
+		store:     store,
+		uow:       uow,
+		publisher: publisher,
+		cfg:       cfg,
+	}
+}
+
+// Run polls the outbox until ctx is cancelled.
+// A full batch is followed immediately by the next one to drain backlogs.
+func (r *Relay) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.cfg.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		n, err := r.ProcessBatch(ctx)
+		if err != nil && !errors.Is(err, context.Canceled) {
+			log.Printf("Outbox relay error: %v", err)
+		}
+		if err == nil && n == r.cfg.BatchSize {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// ProcessBatch claims and publishes one batch of pending messages and
+// returns how many were claimed. A failed delivery is rescheduled with
+// exponential backoff until MaxAttempts is reached, after which the message
+// is moved to the dead-letter state.
+func (r *Relay) ProcessBatch(ctx context.Context) (int, error) {
+	var claimed int
+	err := r.uow.Do(ctx, func(ctx context.Context) error {
+		messages, err := r.store.ClaimPending(ctx, r.cfg.BatchSize)
+		if err != nil {
+			return fmt.Errorf("failed to claim outbox messages: %w", err)
+		}
+		claimed = len(messages)
+
+		for _, msg := range messages {
+			if err := r.deliver(ctx, msg); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return claimed, err
+}
+
+// deliver publishes a message and records the outcome
+func (r *Relay) deliver(ctx context.Context, msg Message) error {
+	pubErr := r.publisher.Publish(ctx, msg)
+	if pubErr == nil {
+		return r.store.MarkPublished(ctx, msg.ID)
+	}
+
+	attempts := msg.Attempts + 1
+	if attempts >= r.cfg.MaxAttempts {
+		log.Printf("Outbox message %s (%s) moved to dead letter after %d attempts: %v", msg.ID, msg.EventName, attempts, pubErr)
+		return r.store.MarkDead(ctx, msg.ID, attempts, pubErr.Error())
+	}
+	return r.store.Retry(ctx, msg.ID, attempts, pubErr.Error(), r.backoff(attempts))
+}
+
+// backoff returns the delay before the next attempt
+func (r *Relay) backoff(attempts int) time.Duration {
+	delay := r.cfg.RetryBackoff
+	for i := 1; i < attempts; i++ {
+		delay *= 2
+		if delay >= maxRetryBackoff {
+			return maxRetryBackoff
+		}
+	}
+	return delay
+}
+
+// NewPublisher creates the EventPublisher selected by cfg.Publisher
+func NewPublisher(cfg config.OutboxConfig) (EventPublisher, error) {
+	switch cfg.Publisher {
+	case "", "memory":
+		return NewMemoryPublisher(), nil
+	case "ndjson":
+		return OpenNDJSONFile(cfg.FilePath)
+	default:
+		return nil, fmt.Errorf("unsupported outbox publisher: %s", cfg.Publisher)
+	}
+}
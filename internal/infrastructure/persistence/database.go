@@ -2,32 +2,46 @@
 package persistence
 
 import (
+	"context"
+	"database/sql"
 	"fmt"
 	"log"
+	"net"
+	"strings"
+	"time"
 
 	"github.com/uptrace/opentelemetry-go-extra/otelgorm"
 
+	"github.com/glebarez/sqlite"
+	"github.com/telemetryflow/order-service/internal/domain/entity"
 	"github.com/telemetryflow/order-service/internal/infrastructure/config"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
 )
 
+// Supported database drivers
+const (
+	DriverPostgres = "postgres"
+	DriverSQLite   = "sqlite"
+)
+
 // NewDatabase creates a new GORM database connection
 func NewDatabase(cfg config.DatabaseConfig) (*gorm.DB, error) {
-	var dsn string
+	var dialector gorm.Dialector
 
 	switch cfg.Driver {
-	case "postgres":
-		dsn = fmt.Sprintf(
-			"host=%s port=%s user=%s password=%s dbname=%s sslmode=%s",
-			cfg.Host,
-			cfg.Port,
-			cfg.User,
-			cfg.Password,
-			cfg.Name,
-			cfg.SSLMode,
-		)
+	case DriverPostgres:
+		dialector = postgres.Open(postgresDSN(cfg, cfg.Host, cfg.Port))
+	case DriverSQLite:
+		if len(cfg.Replicas) > 0 {
+			return nil, fmt.Errorf("read replicas require the %s driver", DriverPostgres)
+		}
+		dialector = sqlite.Open(sqliteDSN(cfg.Name))
+		// SQLite has a single writer, and every connection to :memory:
+		// would open a separate empty database
+		cfg.MaxOpenConns = 1
+		cfg.MaxIdleConns = 1
 	default:
 		return nil, fmt.Errorf("unsupported database driver: %s", cfg.Driver)
 	}
@@ -38,11 +52,14 @@ func NewDatabase(cfg config.DatabaseConfig) (*gorm.DB, error) {
 		gormLogger = logger.Default.LogMode(logger.Info)
 	}
 
-	// Open GORM connection
-	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{
+	// Open GORM connection. The primary is pinged below; replicas are
+	// health checked, so an unreachable replica does not stop the service.
+	db, err := gorm.Open(dialector, &gorm.Config{
 		Logger:                 gormLogger,
 		SkipDefaultTransaction: true,
 		PrepareStmt:            true,
+		TranslateError:         true,
+		DisableAutomaticPing:   true,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to open database: %w", err)
@@ -64,16 +81,81 @@ func NewDatabase(cfg config.DatabaseConfig) (*gorm.DB, error) {
 	sqlDB.SetMaxIdleConns(cfg.MaxIdleConns)
 	sqlDB.SetConnMaxLifetime(cfg.ConnMaxLifetime)
 
-	// Test connection
-	if err := sqlDB.Ping(); err != nil {
+	// Test connection, retrying while the database starts or fails over
+	if err := connect(sqlDB, cfg.Resilience); err != nil {
 		return nil, fmt.Errorf("failed to ping database: %w", err)
 	}
 
-	log.Printf("Database connected successfully: %s:%s/%s", cfg.Host, cfg.Port, cfg.Name)
+	if cfg.Driver == DriverSQLite {
+		log.Printf("Database connected successfully: sqlite %s", cfg.Name)
+	} else {
+		log.Printf("Database connected successfully: %s:%s/%s", cfg.Host, cfg.Port, cfg.Name)
+	}
+
+	// Route query reads to the read replicas
+	if replicas := postgresReplicas(cfg); len(replicas) > 0 {
+		set := NewReplicaSet(replicas...)
+		if err := db.Use(set); err != nil {
+			return nil, err
+		}
+		set.setPoolLimits(cfg.MaxOpenConns, cfg.MaxIdleConns, cfg.ConnMaxLifetime)
+	}
+
+	// Timeouts, retries and the circuit breaker. Registered last, so that
+	// the replicas opened above do not initialize it again.
+	if err := db.Use(NewResilience(cfg.Resilience)); err != nil {
+		return nil, fmt.Errorf("failed to enable database resilience: %w", err)
+	}
 
 	return db, nil
 }
 
+// postgresDSN builds the PostgreSQL DSN of the server at host and port
+func postgresDSN(cfg config.DatabaseConfig, host, port string) string {
+	return fmt.Sprintf(
+		"host=%s port=%s user=%s password=%s dbname=%s sslmode=%s",
+		host,
+		port,
+		cfg.User,
+		cfg.Password,
+		cfg.Name,
+		cfg.SSLMode,
+	)
+}
+
+// postgresReplicas returns the configured read replicas. An address
+// without a port uses the primary's port.
+func postgresReplicas(cfg config.DatabaseConfig) []Replica {
+	replicas := make([]Replica, 0, len(cfg.Replicas))
+	for _, addr := range cfg.Replicas {
+		addr = strings.TrimSpace(addr)
+		if addr == "" {
+			continue
+		}
+		host, port, err := net.SplitHostPort(addr)
+		if err != nil {
+			host, port = addr, cfg.Port
+		}
+		replicas = append(replicas, Replica{
+			Name:      net.JoinHostPort(host, port),
+			Dialector: postgres.Open(postgresDSN(cfg, host, port)),
+		})
+	}
+	return replicas
+}
+
+// connect pings sqlDB until it answers, at most RetryAttempts times
+func connect(sqlDB *sql.DB, cfg config.ResilienceConfig) error {
+	for attempt := 1; ; attempt++ {
+		err := ping(context.Background(), sqlDB)
+		if err == nil || attempt >= cfg.RetryAttempts {
+			return err
+		}
+		log.Printf("Database is unavailable, retrying: %v", err)
+		time.Sleep(backoff(cfg.RetryBackoff, attempt))
+	}
+}
+
 // Transaction executes a function within a database transaction
 func Transaction(db *gorm.DB, fn func(*gorm.DB) error) error {
 	return db.Transaction(fn)
@@ -83,3 +165,21 @@ func Transaction(db *gorm.DB, fn func(*gorm.DB) error) error {
 func AutoMigrate(db *gorm.DB, models ...interface{}) error {
 	return db.AutoMigrate(models...)
 }
+
+// Models returns every model stored by the service, for AutoMigrate on
+// databases the SQL migrations do not support
+func Models() []interface{} {
+	return []interface{}{&entity.Order{}, &entity.Orderitem{}, &OutboxEvent{}, &IdempotencyKey{}}
+}
+
+// sqliteDSN builds the SQLite DSN for name, a file path or ":memory:".
+// Foreign keys are enforced; a name that already has options is used as is.
+func sqliteDSN(name string) string {
+	if strings.Contains(name, "?") {
+		return name
+	}
+	if name == "" || name == ":memory:" {
+		return "file::memory:?_pragma=foreign_keys(1)"
+	}
+	return "file:" + name + "?_pragma=foreign_keys(1)&_pragma=busy_timeout(5000)&_pragma=journal_mode(WAL)"
+}
@@ -0,0 +1,46 @@
+// Package persistence provides translation of storage errors to domain errors.
+package persistence
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/telemetryflow/order-service/internal/domain"
+	"github.com/telemetryflow/order-service/internal/domain/repository"
+	"github.com/telemetryflow/order-service/pkg/circuitbreaker"
+	"gorm.io/gorm"
+)
+
+// translateError wraps GORM errors in the matching domain error so that
+// callers can test them with errors.Is. Constraint violations are
+// translated by the dialector (gorm.Config.TranslateError), which drops the
+// driver message, so neither SQL nor constraint names leak to callers.
+// Connection failures, timeouts and an open circuit breaker become
+// repository.ErrUnavailable. Errors without a domain meaning are returned
+// unchanged.
+func translateError(err error, name string) error {
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return fmt.Errorf("%w: %s", domain.ErrEntityNotFound, name)
+	case errors.Is(err, gorm.ErrDuplicatedKey):
+		return fmt.Errorf("%w: %s already exists", domain.ErrEntityConflict, name)
+	case errors.Is(err, gorm.ErrForeignKeyViolated):
+		return fmt.Errorf("%w: %s references a missing entity", domain.ErrInvalidEntity, name)
+	case errors.Is(err, gorm.ErrCheckConstraintViolated):
+		return fmt.Errorf("%w: %s violates a constraint", domain.ErrInvalidEntity, name)
+	case errors.Is(err, circuitbreaker.ErrOpen), unavailable(err):
+		return unavailableError(err)
+	default:
+		return err
+	}
+}
+
+// unavailableError wraps err in repository.ErrUnavailable, keeping it for logs
+func unavailableError(err error) error {
+	if errors.Is(err, repository.ErrUnavailable) {
+		return err
+	}
+	return fmt.Errorf("%w: %w", repository.ErrUnavailable, err)
+}
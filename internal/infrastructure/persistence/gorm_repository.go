@@ -0,0 +1,169 @@
+// Package persistence provides a generic GORM repository.
+package persistence
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/telemetryflow/order-service/internal/domain/entity"
+	"github.com/telemetryflow/order-service/internal/domain/repository"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Scope customizes a GORM query
+type Scope = func(*gorm.DB) *gorm.DB
+
+// versioned is implemented by entities embedding entity.Base
+type versioned interface {
+	BaseEntity() *entity.Base
+}
+
+// GormRepository implements repository.Repository[T] with GORM. Concrete
+// repositories embed it and add their own finders on top of conn and
+// translate.
+//
+// T is expected to embed entity.Base: Update then uses optimistic locking.
+// Soft deletion follows GORM: reads skip soft-deleted rows and Delete only
+// sets deleted_at when T has a gorm.DeletedAt field.
+type GormRepository[T any] struct {
+	db     *gorm.DB
+	name   string
+	scopes []Scope
+}
+
+var _ repository.Repository[entity.Order] = (*GormRepository[entity.Order])(nil)
+
+// NewGormRepository creates a repository for T. name identifies the entity
+// in errors; scopes are applied to every read.
+func NewGormRepository[T any](db *gorm.DB, name string, scopes ...Scope) *GormRepository[T] {
+	return &GormRepository[T]{db: db, name: name, scopes: scopes}
+}
+
+// Create inserts e. Associations are not saved.
+func (r *GormRepository[T]) Create(ctx context.Context, e *T) error {
+	return r.translate(r.conn(ctx).Omit(clause.Associations).Create(e).Error)
+}
+
+// CreateBatch inserts all entities in a single statement.
+// Associations are not saved.
+func (r *GormRepository[T]) CreateBatch(ctx context.Context, entities []T) error {
+	if len(entities) == 0 {
+		return nil
+	}
+	return r.translate(r.conn(ctx).Omit(clause.Associations).Create(&entities).Error)
+}
+
+// FindByID retrieves an entity by ID
+func (r *GormRepository[T]) FindByID(ctx context.Context, id uuid.UUID) (*T, error) {
+	return r.First(ctx, byID(id))
+}
+
+// First retrieves the first entity selected by scopes. It returns
+// domain.ErrEntityNotFound when there is none.
+func (r *GormRepository[T]) First(ctx context.Context, scopes ...Scope) (*T, error) {
+	var e T
+	if err := r.read(ctx, scopes).First(&e).Error; err != nil {
+		return nil, r.translate(err)
+	}
+	return &e, nil
+}
+
+// FindAll retrieves entities newest first with pagination and returns
+// them with the total number of entities
+func (r *GormRepository[T]) FindAll(ctx context.Context, offset, limit int) ([]T, int64, error) {
+	total, err := r.Count(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	entities, err := r.Find(ctx, newestFirst, paginateScope(offset, limit))
+	if err != nil {
+		return nil, 0, err
+	}
+	return entities, total, nil
+}
+
+// Find retrieves the entities selected by scopes
+func (r *GormRepository[T]) Find(ctx context.Context, scopes ...Scope) ([]T, error) {
+	var entities []T
+	if err := r.read(ctx, scopes).Find(&entities).Error; err != nil {
+		return nil, r.translate(err)
+	}
+	return entities, nil
+}
+
+// Count counts the entities selected by scopes
+func (r *GormRepository[T]) Count(ctx context.Context, scopes ...Scope) (int64, error) {
+	var total int64
+	if err := r.read(ctx, scopes).Model(new(T)).Count(&total).Error; err != nil {
+		return 0, r.translate(err)
+	}
+	return total, nil
+}
+
+// Update writes e. Entities embedding entity.Base are only written if
+// their version is unchanged since they were loaded; see updateVersioned.
+func (r *GormRepository[T]) Update(ctx context.Context, e *T) error {
+	if v, ok := any(e).(versioned); ok {
+		return updateVersioned(r.conn(ctx), e, v.BaseEntity(), r.name)
+	}
+	return r.translate(r.conn(ctx).Omit(clause.Associations).Save(e).Error)
+}
+
+// Delete deletes an entity by ID, softly if T supports it
+func (r *GormRepository[T]) Delete(ctx context.Context, id uuid.UUID) error {
+	return r.translate(r.conn(ctx).Delete(new(T), "id = ?", id).Error)
+}
+
+// HardDelete permanently deletes an entity by ID
+func (r *GormRepository[T]) HardDelete(ctx context.Context, id uuid.UUID) error {
+	return r.translate(r.conn(ctx).Unscoped().Delete(new(T), "id = ?", id).Error)
+}
+
+// conn returns the connection for ctx, joining its unit of work if any
+func (r *GormRepository[T]) conn(ctx context.Context) *gorm.DB {
+	return conn(ctx, r.db)
+}
+
+// read returns a query with the repository scopes followed by scopes
+func (r *GormRepository[T]) read(ctx context.Context, scopes []Scope) *gorm.DB {
+	return r.conn(ctx).Scopes(r.scopes...).Scopes(scopes...)
+}
+
+// translate maps GORM errors to domain errors for this entity
+func (r *GormRepository[T]) translate(err error) error {
+	return translateError(err, r.name)
+}
+
+// where adds a condition to a query
+func where(query string, args ...interface{}) Scope {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where(query, args...)
+	}
+}
+
+// byID selects the entity with the given ID
+func byID(id uuid.UUID) Scope {
+	return where("id = ?", id)
+}
+
+// newestFirst orders by creation time, newest first
+func newestFirst(db *gorm.DB) *gorm.DB {
+	return db.Order("created_at DESC")
+}
+
+// oldestFirst orders by creation time, oldest first
+func oldestFirst(db *gorm.DB) *gorm.DB {
+	return db.Order("created_at ASC")
+}
+
+// preload loads the given associations
+func preload(associations ...string) Scope {
+	return func(db *gorm.DB) *gorm.DB {
+		for _, association := range associations {
+			db = db.Preload(association)
+		}
+		return db
+	}
+}
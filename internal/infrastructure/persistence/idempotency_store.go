@@ -0,0 +1,136 @@
+// Package persistence provides the GORM implementation of the idempotency key store.
+package persistence
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/telemetryflow/order-service/internal/infrastructure/http/middleware"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// IdempotencyKey is a row of the idempotency_keys table
+type IdempotencyKey struct {
+	Key             string          `gorm:"type:varchar(512);primaryKey"`
+	Fingerprint     string          `gorm:"type:char(64);not null"`
+	StatusCode      int             `gorm:"not null;default:0"`
+	ResponseHeaders json.RawMessage `gorm:"type:jsonb"`
+	ResponseBody    []byte          `gorm:"type:bytea"`
+	CreatedAt       time.Time
+	ExpiresAt       time.Time `gorm:"not null;index"`
+}
+
+// TableName specifies the table name for IdempotencyKey
+func (IdempotencyKey) TableName() string {
+	return "idempotency_keys"
+}
+
+// idempotencyStore implements middleware.IdempotencyStore using GORM
+type idempotencyStore struct {
+	db *gorm.DB
+}
+
+// NewIdempotencyStore creates a new idempotency key store
+func NewIdempotencyStore(db *gorm.DB) middleware.IdempotencyStore {
+	return &idempotencyStore{
+		db: db,
+	}
+}
+
+// Reserve inserts a pending record for key, replacing an expired one.
+// When an unexpired record holds the key, it is returned instead. If that
+// record expires or is released before it can be read, the reservation is
+// retried once; when it is gone again, the key is reported as still in
+// progress.
+func (s *idempotencyStore) Reserve(ctx context.Context, key, fingerprint string, ttl time.Duration) (*middleware.IdempotencyRecord, error) {
+	for attempt := 0; attempt < 2; attempt++ {
+		reserved, err := s.insert(ctx, key, fingerprint, ttl)
+		if err != nil || reserved {
+			return nil, err
+		}
+
+		record, err := s.find(ctx, key)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			continue
+		}
+		return record, err
+	}
+	return &middleware.IdempotencyRecord{Key: key, Fingerprint: fingerprint, Header: http.Header{}}, nil
+}
+
+// insert stores a pending record for key unless an unexpired one holds it,
+// and reports whether it did
+func (s *idempotencyStore) insert(ctx context.Context, key, fingerprint string, ttl time.Duration) (bool, error) {
+	now := time.Now().UTC()
+	result := conn(ctx, s.db).
+		Model(&IdempotencyKey{}).
+		Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "key"}},
+			DoUpdates: clause.AssignmentColumns([]string{
+				"fingerprint", "status_code", "response_headers", "response_body", "created_at", "expires_at",
+			}),
+			Where: clause.Where{Exprs: []clause.Expression{
+				clause.Expr{SQL: "idempotency_keys.expires_at <= ?", Vars: []interface{}{now}},
+			}},
+		}).
+		Create(map[string]interface{}{
+			"key":              key,
+			"fingerprint":      fingerprint,
+			"status_code":      0,
+			"response_headers": nil,
+			"response_body":    nil,
+			"created_at":       now,
+			"expires_at":       now.Add(ttl),
+		})
+	return result.RowsAffected > 0, result.Error
+}
+
+// find returns the record stored for key
+func (s *idempotencyStore) find(ctx context.Context, key string) (*middleware.IdempotencyRecord, error) {
+	var row IdempotencyKey
+	if err := conn(ctx, s.db).First(&row, "key = ?", key).Error; err != nil {
+		return nil, err
+	}
+
+	record := &middleware.IdempotencyRecord{
+		Key:         row.Key,
+		Fingerprint: row.Fingerprint,
+		StatusCode:  row.StatusCode,
+		Header:      http.Header{},
+		Body:        row.ResponseBody,
+	}
+	if len(row.ResponseHeaders) > 0 {
+		if err := json.Unmarshal(row.ResponseHeaders, &record.Header); err != nil {
+			return nil, err
+		}
+	}
+	return record, nil
+}
+
+// Complete stores the response for a reserved key
+func (s *idempotencyStore) Complete(ctx context.Context, key string, status int, header http.Header, body []byte) error {
+	headers, err := json.Marshal(header)
+	if err != nil {
+		return err
+	}
+	return conn(ctx, s.db).Model(&IdempotencyKey{}).Where("key = ?", key).Updates(map[string]interface{}{
+		"status_code":      status,
+		"response_headers": json.RawMessage(headers),
+		"response_body":    body,
+	}).Error
+}
+
+// Release deletes a reserved key that has no stored response
+func (s *idempotencyStore) Release(ctx context.Context, key string) error {
+	return conn(ctx, s.db).Where("key = ? AND status_code = 0", key).Delete(&IdempotencyKey{}).Error
+}
+
+// DeleteExpired removes expired records
+func (s *idempotencyStore) DeleteExpired(ctx context.Context) (int64, error) {
+	result := conn(ctx, s.db).Where("expires_at <= ?", time.Now().UTC()).Delete(&IdempotencyKey{})
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,50 @@
+// Package persistence provides GORM scopes for keyset pagination.
+package persistence
+
+import (
+	"slices"
+
+	"github.com/telemetryflow/order-service/internal/domain/repository"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// keysetScope restricts and orders a query for page and fetches one extra
+// row so callers can tell whether more rows follow. Pages before a position
+// are scanned in reverse and restored by keysetResult.
+func keysetScope(page repository.KeysetPage) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		desc := page.Direction != repository.SortAsc
+		pos := page.After
+		if page.Before != nil {
+			pos = page.Before
+			desc = !desc
+		}
+
+		if pos != nil {
+			op := ">"
+			if desc {
+				op = "<"
+			}
+			db = db.Where("(created_at, id) "+op+" (?, ?)", pos.CreatedAt, pos.ID)
+		}
+
+		return db.Order(clause.OrderBy{Columns: []clause.OrderByColumn{
+			{Column: clause.Column{Name: "created_at"}, Desc: desc},
+			{Column: clause.Column{Name: "id"}, Desc: desc},
+		}}).Limit(page.Limit + 1)
+	}
+}
+
+// keysetResult trims the extra row fetched by keysetScope and restores page
+// order for pages before a position
+func keysetResult[T any](rows []T, page repository.KeysetPage) ([]T, bool) {
+	more := len(rows) > page.Limit
+	if more {
+		rows = rows[:page.Limit]
+	}
+	if page.Before != nil {
+		slices.Reverse(rows)
+	}
+	return rows, more
+}
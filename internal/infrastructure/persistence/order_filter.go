@@ -0,0 +1,85 @@
+// Package persistence provides GORM scopes for the order listing specification.
+package persistence
+
+import (
+	"strings"
+
+	"github.com/telemetryflow/order-service/internal/domain/repository"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// orderSortColumns maps the whitelisted sort fields to columns. Values
+// coming from requests are never used as column names directly.
+var orderSortColumns = map[string]string{
+	repository.OrderSortCreatedAt: "created_at",
+	repository.OrderSortUpdatedAt: "updated_at",
+	repository.OrderSortTotal:     "total",
+	repository.OrderSortStatus:    "status",
+}
+
+// likeEscaper escapes LIKE wildcards so search text matches literally
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// orderFilterScope restricts a query to the orders matching f.
+// All values are passed as bind parameters.
+func orderFilterScope(f repository.OrderFilter) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if len(f.Statuses) > 0 {
+			db = db.Where("status IN ?", f.Statuses)
+		}
+		if f.CustomerID != nil {
+			db = db.Where("customer_id = ?", *f.CustomerID)
+		}
+		if f.CreatedFrom != nil {
+			db = db.Where("created_at >= ?", *f.CreatedFrom)
+		}
+		if f.CreatedTo != nil {
+			db = db.Where("created_at <= ?", *f.CreatedTo)
+		}
+		if f.MinTotal != nil {
+			db = db.Where("total >= ?", *f.MinTotal)
+		}
+		if f.MaxTotal != nil {
+			db = db.Where("total <= ?", *f.MaxTotal)
+		}
+		if search := strings.TrimSpace(f.Search); search != "" {
+			pattern := "%" + likeEscaper.Replace(strings.ToLower(search)) + "%"
+			db = db.Where(
+				`(LOWER(CAST(id AS TEXT)) LIKE ? ESCAPE '\' OR LOWER(CAST(customer_id AS TEXT)) LIKE ? ESCAPE '\' OR LOWER(status) LIKE ? ESCAPE '\')`,
+				pattern, pattern, pattern,
+			)
+		}
+		return db
+	}
+}
+
+// orderSortScope orders the result by f.Sort, falling back to created_at
+// descending, with id as a tie-breaker so pages are stable
+func orderSortScope(f repository.OrderFilter) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		column, ok := orderSortColumns[f.Sort.Field]
+		if !ok {
+			column = orderSortColumns[repository.OrderSortCreatedAt]
+		}
+		desc := f.Sort.Direction != repository.SortAsc
+
+		return db.Order(clause.OrderBy{Columns: []clause.OrderByColumn{
+			{Column: clause.Column{Name: column}, Desc: desc},
+			{Column: clause.Column{Name: "id"}, Desc: desc},
+		}})
+	}
+}
+
+// paginateScope applies offset and limit when set
+func paginateScope(offset, limit int) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if offset > 0 {
+			db = db.Offset(offset)
+		}
+		if limit > 0 {
+			db = db.Limit(limit)
+		}
+		return db
+	}
+}
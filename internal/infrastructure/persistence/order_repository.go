@@ -6,7 +6,6 @@ package persistence
 
 import (
 	"context"
-	"errors"
 
 	"github.com/google/uuid"
 	"github.com/telemetryflow/order-service/internal/domain/entity"
@@ -16,108 +15,109 @@ import (
 
 // orderRepository implements repository.OrderRepository using GORM
 type orderRepository struct {
-	db *gorm.DB
+	*GormRepository[entity.Order]
 }
 
 // NewOrderRepository creates a new Order repository
 func NewOrderRepository(db *gorm.DB) repository.OrderRepository {
 	return &orderRepository{
-		db: db,
+		GormRepository: NewGormRepository[entity.Order](db, "order"),
 	}
 }
 
-// Create creates a new order
-func (r *orderRepository) Create(ctx context.Context, order *entity.Order) error {
-	return r.db.WithContext(ctx).Create(order).Error
-}
+// List retrieves the orders matching filter
+func (r *orderRepository) List(ctx context.Context, filter repository.OrderFilter) ([]entity.Order, int64, error) {
+	total, err := r.Count(ctx, orderFilterScope(filter))
+	if err != nil {
+		return nil, 0, err
+	}
 
-// FindByID retrieves an order by ID
-func (r *orderRepository) FindByID(ctx context.Context, id uuid.UUID) (*entity.Order, error) {
-	var order entity.Order
-	err := r.db.WithContext(ctx).First(&order, "id = ?", id).Error
+	orders, err := r.Find(ctx, orderFilterScope(filter), orderSortScope(filter), paginateScope(filter.Offset, filter.Limit))
 	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("order not found")
-		}
-		return nil, err
+		return nil, 0, err
 	}
-	return &order, nil
+	return orders, total, nil
 }
 
-// FindAll retrieves all orders with pagination
-func (r *orderRepository) FindAll(ctx context.Context, offset, limit int) ([]entity.Order, int64, error) {
-	var orders []entity.Order
-	var total int64
+// FindPage retrieves a keyset page of the orders matching filter
+func (r *orderRepository) FindPage(ctx context.Context, filter repository.OrderFilter, page repository.KeysetPage) ([]entity.Order, bool, error) {
+	orders, err := r.Find(ctx, orderFilterScope(filter), keysetScope(page))
+	if err != nil {
+		return nil, false, err
+	}
 
-	// Count total records
-	if err := r.db.WithContext(ctx).Model(&entity.Order{}).Count(&total).Error; err != nil {
-		return nil, 0, err
+	orders, more := keysetResult(orders, page)
+	return orders, more, nil
+}
+
+// Search runs a full-text search over the orders' search_vector, ranked by
+// relevance with the newest orders first among equal ranks. Databases other
+// than PostgreSQL fall back to substring matching; see searchText.
+func (r *orderRepository) Search(ctx context.Context, text string, offset, limit int) ([]repository.OrderSearchHit, int64, error) {
+	if r.db.Dialector.Name() != DriverPostgres {
+		return r.searchText(ctx, text, offset, limit)
 	}
 
-	// Get paginated records
-	if err := r.db.WithContext(ctx).
-		Order("created_at DESC").
-		Offset(offset).
-		Limit(limit).
-		Find(&orders).Error; err != nil {
+	total, err := r.Count(ctx, where("search_vector @@ "+orderSearchQuery, text))
+	if err != nil {
 		return nil, 0, err
 	}
 
-	return orders, total, nil
-}
-
-// Update updates an order
-func (r *orderRepository) Update(ctx context.Context, order *entity.Order) error {
-	return r.db.WithContext(ctx).Save(order).Error
-}
+	var rows []orderSearchRow
+	if err := r.conn(ctx).
+		Model(&entity.Order{}).
+		Select(orderSearchColumns).
+		Joins("CROSS JOIN "+orderSearchQuery+" AS q(query)", text).
+		Where("orders.search_vector @@ q.query").
+		Order("rank DESC, orders.created_at DESC, orders.id DESC").
+		Scopes(paginateScope(offset, limit)).
+		Scan(&rows).Error; err != nil {
+		return nil, 0, r.translate(err)
+	}
+	if len(rows) == 0 {
+		return []repository.OrderSearchHit{}, total, nil
+	}
 
-// Delete soft-deletes an order by ID
-func (r *orderRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	return r.db.WithContext(ctx).Delete(&entity.Order{}, "id = ?", id).Error
-}
+	ids := make([]uuid.UUID, len(rows))
+	for i := range rows {
+		ids[i] = rows[i].ID
+	}
+	orders, err := r.Find(ctx, where("id IN ?", ids))
+	if err != nil {
+		return nil, 0, err
+	}
+	byID := make(map[uuid.UUID]entity.Order, len(orders))
+	for _, order := range orders {
+		byID[order.ID] = order
+	}
 
-// HardDelete permanently deletes an order by ID
-func (r *orderRepository) HardDelete(ctx context.Context, id uuid.UUID) error {
-	return r.db.WithContext(ctx).Unscoped().Delete(&entity.Order{}, "id = ?", id).Error
+	hits := make([]repository.OrderSearchHit, 0, len(rows))
+	for i := range rows {
+		order, ok := byID[rows[i].ID]
+		if !ok {
+			// Deleted between the two queries
+			continue
+		}
+		hits = append(hits, repository.OrderSearchHit{
+			Order:      order,
+			Rank:       rows[i].Rank,
+			Highlights: rows[i].highlights(),
+		})
+	}
+	return hits, total, nil
 }
 
 // FindByStatus finds orders by status
 func (r *orderRepository) FindByStatus(ctx context.Context, status string) ([]entity.Order, error) {
-	var orders []entity.Order
-	err := r.db.WithContext(ctx).
-		Where("status = ?", status).
-		Order("created_at DESC").
-		Find(&orders).Error
-	if err != nil {
-		return nil, err
-	}
-	return orders, nil
+	return r.Find(ctx, where("status = ?", status), newestFirst)
 }
 
 // FindByCustomerID finds orders by customer ID
 func (r *orderRepository) FindByCustomerID(ctx context.Context, customerID uuid.UUID) ([]entity.Order, error) {
-	var orders []entity.Order
-	err := r.db.WithContext(ctx).
-		Where("customer_id = ?", customerID).
-		Order("created_at DESC").
-		Find(&orders).Error
-	if err != nil {
-		return nil, err
-	}
-	return orders, nil
+	return r.Find(ctx, where("customer_id = ?", customerID), newestFirst)
 }
 
 // FindWithItems retrieves an order with its items
 func (r *orderRepository) FindWithItems(ctx context.Context, id uuid.UUID) (*entity.Order, error) {
-	var order entity.Order
-	err := r.db.WithContext(ctx).
-		Preload("Items").
-		First(&order, "id = ?", id).Error
-	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("order not found")
-		}
-		return nil, err
-	}
-	return &order, nil
+	return r.First(ctx, byID(id), preload("Items"))
 }
@@ -0,0 +1,120 @@
+// Package persistence provides full-text search over orders.
+package persistence
+
+import (
+	"context"
+	"html"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/telemetryflow/order-service/internal/domain/repository"
+	"gorm.io/gorm"
+)
+
+// orderSearchQuery parses search text into a tsquery. websearch_to_tsquery
+// never fails on user input: quotes, "or" and "-" are supported and any
+// other syntax is treated as plain words.
+const orderSearchQuery = "websearch_to_tsquery('simple', ?)"
+
+// Highlight delimiters and ts_headline options. Identifier-like fields are
+// highlighted in full; notes are cut down to the matching fragment.
+const (
+	markStart         = "<mark>"
+	markStop          = "</mark>"
+	headlineShort     = `StartSel="<mark>", StopSel="</mark>", HighlightAll=true`
+	headlineFragments = `StartSel="<mark>", StopSel="</mark>", MaxFragments=2, MaxWords=20, MinWords=5`
+)
+
+// orderSearchTags renders the tags column as text for ts_headline
+const orderSearchTags = `(SELECT COALESCE(string_agg(tag, ', '), '') FROM jsonb_array_elements_text(` +
+	`CASE WHEN jsonb_typeof(orders.tags) = 'array' THEN orders.tags ELSE '[]' END) AS tag)`
+
+// orderSearchColumns selects the rank and per-field highlights of a match
+// against the tsquery joined as q.query
+var orderSearchColumns = strings.Join([]string{
+	"orders.id",
+	"ts_rank_cd(orders.search_vector, q.query) AS rank",
+	"ts_headline('simple', orders.id::text, q.query, '" + headlineShort + "') AS id_highlight",
+	"ts_headline('simple', orders.customer_id::text, q.query, '" + headlineShort + "') AS customer_id_highlight",
+	"ts_headline('simple', orders.status, q.query, '" + headlineShort + "') AS status_highlight",
+	"ts_headline('simple', " + orderSearchTags + ", q.query, '" + headlineShort + "') AS tags_highlight",
+	"ts_headline('simple', orders.notes, q.query, '" + headlineFragments + "') AS notes_highlight",
+}, ", ")
+
+// orderSearchRow is a ranked match read by orderSearchColumns
+type orderSearchRow struct {
+	ID                  uuid.UUID `gorm:"column:id"`
+	Rank                float64   `gorm:"column:rank"`
+	IDHighlight         string    `gorm:"column:id_highlight"`
+	CustomerIDHighlight string    `gorm:"column:customer_id_highlight"`
+	StatusHighlight     string    `gorm:"column:status_highlight"`
+	TagsHighlight       string    `gorm:"column:tags_highlight"`
+	NotesHighlight      string    `gorm:"column:notes_highlight"`
+}
+
+// highlights returns the fields of the row that contain a match
+func (r *orderSearchRow) highlights() map[string]string {
+	fields := map[string]string{
+		repository.OrderSearchFieldID:         r.IDHighlight,
+		repository.OrderSearchFieldCustomerID: r.CustomerIDHighlight,
+		repository.OrderSearchFieldStatus:     r.StatusHighlight,
+		repository.OrderSearchFieldTags:       r.TagsHighlight,
+		repository.OrderSearchFieldNotes:      r.NotesHighlight,
+	}
+	for field, fragment := range fields {
+		if strings.Contains(fragment, markStart) {
+			fields[field] = escapeHighlight(fragment)
+		} else {
+			delete(fields, field)
+		}
+	}
+	return fields
+}
+
+// highlightEscaper restores the highlight delimiters after HTML escaping
+var highlightEscaper = strings.NewReplacer(
+	html.EscapeString(markStart), markStart,
+	html.EscapeString(markStop), markStop,
+)
+
+// escapeHighlight HTML-escapes a fragment, which may contain user text,
+// keeping only the highlight delimiters as markup
+func escapeHighlight(fragment string) string {
+	return highlightEscaper.Replace(html.EscapeString(fragment))
+}
+
+// searchText is the Search fallback for databases without full-text search.
+// Every word of text must occur in one of the searchable fields; hits are
+// ordered newest first and have neither rank nor highlights.
+func (r *orderRepository) searchText(ctx context.Context, text string, offset, limit int) ([]repository.OrderSearchHit, int64, error) {
+	scope := orderTextScope(text)
+	total, err := r.Count(ctx, scope)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	orders, err := r.Find(ctx, scope, newestFirst, paginateScope(offset, limit))
+	if err != nil {
+		return nil, 0, err
+	}
+	hits := make([]repository.OrderSearchHit, len(orders))
+	for i := range orders {
+		hits[i] = repository.OrderSearchHit{Order: orders[i], Highlights: map[string]string{}}
+	}
+	return hits, total, nil
+}
+
+// orderTextScope matches orders containing every word of text
+func orderTextScope(text string) Scope {
+	return func(db *gorm.DB) *gorm.DB {
+		for _, word := range strings.Fields(strings.ToLower(text)) {
+			pattern := "%" + likeEscaper.Replace(word) + "%"
+			db = db.Where(
+				`(LOWER(CAST(id AS TEXT)) LIKE ? ESCAPE '\' OR LOWER(CAST(customer_id AS TEXT)) LIKE ? ESCAPE '\' OR `+
+					`LOWER(status) LIKE ? ESCAPE '\' OR LOWER(CAST(tags AS TEXT)) LIKE ? ESCAPE '\' OR LOWER(notes) LIKE ? ESCAPE '\')`,
+				pattern, pattern, pattern, pattern, pattern,
+			)
+		}
+		return db
+	}
+}
@@ -0,0 +1,135 @@
+// Package persistence provides the GORM implementation of the transactional outbox.
+package persistence
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/telemetryflow/order-service/internal/domain/event"
+	"github.com/telemetryflow/order-service/internal/domain/repository"
+	"github.com/telemetryflow/order-service/internal/infrastructure/outbox"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// OutboxEvent is a row of the outbox_events table
+type OutboxEvent struct {
+	ID          uuid.UUID       `gorm:"type:uuid;primaryKey"`
+	AggregateID uuid.UUID       `gorm:"type:uuid;not null;index"`
+	EventName   string          `gorm:"type:varchar(255);not null"`
+	Payload     json.RawMessage `gorm:"type:jsonb;not null"`
+	Status      outbox.Status   `gorm:"type:varchar(20);not null;default:'pending'"`
+	Attempts    int             `gorm:"not null;default:0"`
+	LastError   *string         `gorm:"type:text"`
+	OccurredAt  time.Time       `gorm:"not null"`
+	AvailableAt time.Time       `gorm:"not null;default:CURRENT_TIMESTAMP"`
+	PublishedAt *time.Time
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// TableName specifies the table name for OutboxEvent
+func (OutboxEvent) TableName() string {
+	return "outbox_events"
+}
+
+// outboxRepository implements repository.OutboxRepository and outbox.Store using GORM
+type outboxRepository struct {
+	db *gorm.DB
+}
+
+// NewOutboxRepository creates a new outbox repository for command handlers
+func NewOutboxRepository(db *gorm.DB) repository.OutboxRepository {
+	return &outboxRepository{
+		db: db,
+	}
+}
+
+// NewOutboxStore creates a new outbox store for the relay
+func NewOutboxStore(db *gorm.DB) outbox.Store {
+	return &outboxRepository{
+		db: db,
+	}
+}
+
+// Append stores events as pending outbox messages
+func (r *outboxRepository) Append(ctx context.Context, events ...event.Event) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	now := time.Now().UTC()
+	rows := make([]OutboxEvent, 0, len(events))
+	for _, e := range events {
+		payload, err := json.Marshal(e)
+		if err != nil {
+			return fmt.Errorf("failed to encode event %s: %w", e.EventName(), err)
+		}
+		rows = append(rows, OutboxEvent{
+			ID:          uuid.New(),
+			AggregateID: e.AggregateID(),
+			EventName:   e.EventName(),
+			Payload:     payload,
+			Status:      outbox.StatusPending,
+			AvailableAt: now,
+			OccurredAt:  e.OccurredAt(),
+		})
+	}
+	return conn(ctx, r.db).Create(&rows).Error
+}
+
+// ClaimPending locks up to limit due pending messages, oldest first
+func (r *outboxRepository) ClaimPending(ctx context.Context, limit int) ([]outbox.Message, error) {
+	var rows []OutboxEvent
+	err := conn(ctx, r.db).
+		Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+		Where("status = ? AND available_at <= ?", outbox.StatusPending, time.Now().UTC()).
+		Order("occurred_at ASC, id ASC").
+		Limit(limit).
+		Find(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	messages := make([]outbox.Message, len(rows))
+	for i, row := range rows {
+		messages[i] = outbox.Message{
+			ID:          row.ID,
+			AggregateID: row.AggregateID,
+			EventName:   row.EventName,
+			Payload:     row.Payload,
+			OccurredAt:  row.OccurredAt,
+			Attempts:    row.Attempts,
+		}
+	}
+	return messages, nil
+}
+
+// MarkPublished records a successful delivery
+func (r *outboxRepository) MarkPublished(ctx context.Context, id uuid.UUID) error {
+	return conn(ctx, r.db).Model(&OutboxEvent{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"status":       outbox.StatusPublished,
+		"published_at": time.Now().UTC(),
+	}).Error
+}
+
+// Retry records a failed delivery and reschedules the message after delay
+func (r *outboxRepository) Retry(ctx context.Context, id uuid.UUID, attempts int, lastErr string, delay time.Duration) error {
+	return conn(ctx, r.db).Model(&OutboxEvent{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"attempts":     attempts,
+		"last_error":   lastErr,
+		"available_at": time.Now().UTC().Add(delay),
+	}).Error
+}
+
+// MarkDead records a failed delivery and moves the message to the dead-letter state
+func (r *outboxRepository) MarkDead(ctx context.Context, id uuid.UUID, attempts int, lastErr string) error {
+	return conn(ctx, r.db).Model(&OutboxEvent{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"status":     outbox.StatusDead,
+		"attempts":   attempts,
+		"last_error": lastErr,
+	}).Error
+}
@@ -0,0 +1,211 @@
+// Package persistence provides read replica routing.
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"math/rand"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/plugin/dbresolver"
+)
+
+const (
+	// replicaSetName is the GORM plugin name of the ReplicaSet
+	replicaSetName = "order-service:replicas"
+
+	// replicaResolver names the dbresolver configuration of the replicas.
+	// It is not the global configuration, so statements only reach a
+	// replica when conn selects it for a context allowing replica reads.
+	replicaResolver = "replicas"
+
+	// pingTimeout bounds each health check
+	pingTimeout = 2 * time.Second
+)
+
+// Replica is a read replica of the primary database
+type Replica struct {
+	// Name identifies the replica in logs and readiness checks
+	Name      string
+	Dialector gorm.Dialector
+}
+
+// ReplicaStatus is the outcome of a replica health check
+type ReplicaStatus struct {
+	Name string
+	// Err is nil when the replica is healthy
+	Err error
+}
+
+// ReplicaSet is a GORM plugin routing the reads of query handlers to read
+// replicas. Reads go to a random healthy replica; when none is healthy they
+// fall back to the primary database. Writes and transactions always use the
+// primary.
+type ReplicaSet struct {
+	replicas []Replica
+	resolver *dbresolver.DBResolver
+
+	mu      sync.RWMutex
+	pools   []gorm.ConnPool
+	healthy map[gorm.ConnPool]bool
+}
+
+// NewReplicaSet creates a replica set. Register it with db.Use.
+func NewReplicaSet(replicas ...Replica) *ReplicaSet {
+	return &ReplicaSet{
+		replicas: replicas,
+		healthy:  make(map[gorm.ConnPool]bool),
+	}
+}
+
+// Name implements gorm.Plugin
+func (s *ReplicaSet) Name() string {
+	return replicaSetName
+}
+
+// Initialize implements gorm.Plugin. It opens the replicas and checks their
+// health once; unreachable replicas do not fail it.
+func (s *ReplicaSet) Initialize(db *gorm.DB) error {
+	if len(s.replicas) == 0 {
+		return fmt.Errorf("replica set has no replicas")
+	}
+
+	dialectors := make([]gorm.Dialector, len(s.replicas))
+	for i, r := range s.replicas {
+		dialectors[i] = r.Dialector
+	}
+	s.resolver = dbresolver.Register(dbresolver.Config{
+		Replicas: dialectors,
+		Policy:   s,
+	}, replicaResolver)
+	if err := db.Use(s.resolver); err != nil {
+		return fmt.Errorf("failed to open read replicas: %w", err)
+	}
+
+	// The resolver visits the primary, then the replicas in order
+	primary := db.Config.ConnPool
+	if prepared, ok := primary.(*gorm.PreparedStmtDB); ok {
+		primary = prepared.ConnPool
+	}
+	_ = s.resolver.Call(func(pool gorm.ConnPool) error {
+		if pool != primary {
+			s.pools = append(s.pools, pool)
+		}
+		return nil
+	})
+	if len(s.pools) != len(s.replicas) {
+		return fmt.Errorf("opened %d of %d read replicas", len(s.pools), len(s.replicas))
+	}
+
+	s.Check(context.Background())
+	return nil
+}
+
+// Resolve implements dbresolver.Policy, picking a random healthy replica
+func (s *ReplicaSet) Resolve(pools []gorm.ConnPool) gorm.ConnPool {
+	s.mu.RLock()
+	healthy := make([]gorm.ConnPool, 0, len(pools))
+	for _, p := range pools {
+		if s.healthy[p] {
+			healthy = append(healthy, p)
+		}
+	}
+	s.mu.RUnlock()
+
+	if len(healthy) == 0 {
+		healthy = pools
+	}
+	return healthy[rand.Intn(len(healthy))]
+}
+
+// Available reports whether at least one replica is healthy
+func (s *ReplicaSet) Available() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, ok := range s.healthy {
+		if ok {
+			return true
+		}
+	}
+	return false
+}
+
+// Check pings every replica, records which are healthy and returns their
+// status in configuration order
+func (s *ReplicaSet) Check(ctx context.Context) []ReplicaStatus {
+	statuses := make([]ReplicaStatus, len(s.pools))
+	for i, pool := range s.pools {
+		statuses[i] = ReplicaStatus{Name: s.replicas[i].Name, Err: ping(ctx, pool)}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i, pool := range s.pools {
+		healthy := statuses[i].Err == nil
+		if was, checked := s.healthy[pool]; !checked || was != healthy {
+			if healthy {
+				log.Printf("Read replica %s is healthy", statuses[i].Name)
+			} else {
+				log.Printf("Read replica %s is unhealthy: %v", statuses[i].Name, statuses[i].Err)
+			}
+		}
+		s.healthy[pool] = healthy
+	}
+	return statuses
+}
+
+// Monitor checks the replicas every interval until ctx is done, so that
+// reads stop going to a replica that fails and resume when it recovers
+func (s *ReplicaSet) Monitor(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.Check(ctx)
+		}
+	}
+}
+
+// setPoolLimits applies the connection pool settings to every replica
+func (s *ReplicaSet) setPoolLimits(maxOpen, maxIdle int, maxLifetime time.Duration) {
+	for _, pool := range s.pools {
+		if sqlDB, ok := pool.(*sql.DB); ok {
+			sqlDB.SetMaxOpenConns(maxOpen)
+			sqlDB.SetMaxIdleConns(maxIdle)
+			sqlDB.SetConnMaxLifetime(maxLifetime)
+		}
+	}
+}
+
+// Replicas returns the replica set registered on db, or nil when db has
+// no read replicas
+func Replicas(db *gorm.DB) *ReplicaSet {
+	if db == nil {
+		return nil
+	}
+	if s, ok := db.Config.Plugins[replicaSetName].(*ReplicaSet); ok {
+		return s
+	}
+	return nil
+}
+
+// ping checks that pool accepts connections
+func ping(ctx context.Context, pool gorm.ConnPool) error {
+	pinger, ok := pool.(interface{ PingContext(context.Context) error })
+	if !ok {
+		return fmt.Errorf("connection pool %T cannot be pinged", pool)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, pingTimeout)
+	defer cancel()
+	return pinger.PingContext(ctx)
+}
@@ -0,0 +1,254 @@
+// Package persistence provides resilient database access.
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"log"
+	"math/rand"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/telemetryflow/order-service/internal/infrastructure/config"
+	"github.com/telemetryflow/order-service/pkg/circuitbreaker"
+	"github.com/telemetryflow/order-service/telemetry/metrics"
+	"gorm.io/gorm"
+)
+
+const (
+	// resilienceName is the GORM plugin name of Resilience
+	resilienceName = "order-service:resilience"
+
+	// breakerName identifies the database circuit breaker in metrics
+	breakerName = "database"
+
+	// Statement settings shared by the before and after callbacks
+	allowedKey = "order-service:resilience:allowed"
+	cancelKey  = "order-service:resilience:cancel"
+	contextKey = "order-service:resilience:context"
+)
+
+// Resilience is a GORM plugin protecting database access. It bounds every
+// statement with a timeout, retries queries outside transactions that fail
+// with a transient error, and opens a circuit breaker after repeated
+// transient failures, so that statements fail fast while the database is
+// down. Repositories report these failures as repository.ErrUnavailable.
+type Resilience struct {
+	cfg     config.ResilienceConfig
+	breaker *circuitbreaker.Breaker
+}
+
+// NewResilience creates the plugin. Register it with db.Use.
+func NewResilience(cfg config.ResilienceConfig) *Resilience {
+	return &Resilience{
+		cfg: cfg,
+		breaker: circuitbreaker.New(circuitbreaker.Config{
+			Threshold: cfg.BreakerThreshold,
+			Cooldown:  cfg.BreakerCooldown,
+			OnStateChange: func(from, to circuitbreaker.State) {
+				log.Printf("Database circuit breaker is %s", to)
+				metrics.RecordCircuitBreakerState(breakerName, from, to)
+			},
+		}),
+	}
+}
+
+// Name implements gorm.Plugin
+func (r *Resilience) Name() string {
+	return resilienceName
+}
+
+// Initialize implements gorm.Plugin
+func (r *Resilience) Initialize(db *gorm.DB) error {
+	const before, after = "order-service:resilience:before", "order-service:resilience:after"
+
+	read := r.before(r.cfg.ReadTimeout)
+	write := r.before(r.cfg.WriteTimeout)
+	cb := db.Callback()
+
+	for _, err := range []error{
+		cb.Create().Before("*").Register(before, write),
+		cb.Create().After("*").Register(after, r.after),
+		cb.Query().Before("*").Register(before, read),
+		cb.Query().Replace("gorm:query", r.retry(cb.Query().Get("gorm:query"))),
+		cb.Query().After("*").Register(after, r.after),
+		cb.Update().Before("*").Register(before, write),
+		cb.Update().After("*").Register(after, r.after),
+		cb.Delete().Before("*").Register(before, write),
+		cb.Delete().After("*").Register(after, r.after),
+		cb.Raw().Before("*").Register(before, write),
+		cb.Raw().After("*").Register(after, r.after),
+		// Rows are read after the callbacks return, so row queries
+		// cannot be bounded by a timeout cancelled in the after callback
+		cb.Row().Before("*").Register(before, r.before(0)),
+		cb.Row().After("*").Register(after, r.after),
+	} {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// before returns the callback admitting a statement through the circuit
+// breaker and bounding it with timeout
+func (r *Resilience) before(timeout time.Duration) func(*gorm.DB) {
+	return func(db *gorm.DB) {
+		if db.Error != nil || db.DryRun {
+			return
+		}
+		if err := r.breaker.Allow(); err != nil {
+			_ = db.AddError(err)
+			return
+		}
+		db.Statement.Settings.Store(allowedKey, true)
+
+		if timeout > 0 {
+			ctx, cancel := context.WithTimeout(db.Statement.Context, timeout)
+			db.Statement.Settings.Store(contextKey, db.Statement.Context)
+			db.Statement.Settings.Store(cancelKey, cancel)
+			db.Statement.Context = ctx
+		}
+	}
+}
+
+// after releases the statement timeout, restoring the caller's context for
+// later statements, and records the outcome with the circuit breaker
+func (r *Resilience) after(db *gorm.DB) {
+	if cancel, ok := db.Statement.Settings.LoadAndDelete(cancelKey); ok {
+		cancel.(context.CancelFunc)()
+	}
+	if ctx, ok := db.Statement.Settings.LoadAndDelete(contextKey); ok {
+		db.Statement.Context = ctx.(context.Context)
+	}
+	if _, ok := db.Statement.Settings.LoadAndDelete(allowedKey); ok {
+		r.record(db.Error)
+	}
+}
+
+// retry wraps the query callback to retry queries failing with a
+// transient error, with exponential backoff within the statement timeout
+func (r *Resilience) retry(query func(*gorm.DB)) func(*gorm.DB) {
+	return func(db *gorm.DB) {
+		query(db)
+
+		for attempt := 1; attempt < r.cfg.RetryAttempts && retryable(db); attempt++ {
+			r.breaker.Failure()
+			if !sleep(db.Statement.Context, backoff(r.cfg.RetryBackoff, attempt)) || r.breaker.Allow() != nil {
+				// The failure is recorded; the after callback has nothing to add
+				db.Statement.Settings.Delete(allowedKey)
+				return
+			}
+
+			metrics.RecordDBRetry("query", db.Statement.Table, attempt)
+			db.Error = nil
+			db.RowsAffected = 0
+			query(db)
+		}
+	}
+}
+
+// backoff returns the delay before retry attempt, doubling from base
+// with jitter so that instances do not retry in lockstep
+func backoff(base time.Duration, attempt int) time.Duration {
+	d := base << (attempt - 1)
+	if d <= 0 {
+		return 0
+	}
+	return d/2 + time.Duration(rand.Int63n(int64(d/2)+1))
+}
+
+// record reports the outcome of an admitted statement to the breaker.
+// Only unavailability counts as a failure: a statement rejected by a
+// constraint still shows that the database is up.
+func (r *Resilience) record(err error) {
+	if unavailable(err) {
+		r.breaker.Failure()
+	} else {
+		r.breaker.Success()
+	}
+}
+
+// CircuitBreaker returns the circuit breaker of db, or nil when db has no
+// Resilience plugin
+func CircuitBreaker(db *gorm.DB) *circuitbreaker.Breaker {
+	if db == nil {
+		return nil
+	}
+	if r, ok := db.Config.Plugins[resilienceName].(*Resilience); ok {
+		return r.breaker
+	}
+	return nil
+}
+
+// retryable reports whether the failed query of db may be retried: it
+// failed with a transient error outside a transaction, whose connection
+// would be broken, and takes no row locks
+func retryable(db *gorm.DB) bool {
+	if !transient(db.Error) || db.Statement.Context.Err() != nil {
+		return false
+	}
+	if _, inTransaction := db.Statement.ConnPool.(gorm.TxCommitter); inTransaction {
+		return false
+	}
+	_, locking := db.Statement.Clauses["FOR"]
+	return !locking
+}
+
+// transient reports whether err is a connection failure that may not
+// recur on another connection, as during a failover or restart
+func transient(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		switch {
+		case strings.HasPrefix(pgErr.Code, "08"): // connection exception
+			return true
+		case pgErr.Code == "57P01", // admin_shutdown
+			pgErr.Code == "57P02", // crash_shutdown
+			pgErr.Code == "57P03", // cannot_connect_now
+			pgErr.Code == "53300": // too_many_connections
+			return true
+		default:
+			return false
+		}
+	}
+
+	var (
+		connectErr *pgconn.ConnectError
+		netErr     net.Error
+	)
+	return pgconn.SafeToRetry(err) ||
+		errors.As(err, &connectErr) ||
+		errors.As(err, &netErr) ||
+		errors.Is(err, driver.ErrBadConn) ||
+		errors.Is(err, sql.ErrConnDone) ||
+		errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+// unavailable reports whether err shows that the database cannot serve
+// requests: a transient failure or a statement timeout
+func unavailable(err error) bool {
+	return transient(err) || errors.Is(err, context.DeadlineExceeded)
+}
+
+// sleep waits for d, returning false if ctx is done first
+func sleep(ctx context.Context, d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
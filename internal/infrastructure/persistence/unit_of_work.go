@@ -0,0 +1,64 @@
+// Package persistence provides the GORM implementation of repository.UnitOfWork.
+package persistence
+
+import (
+	"context"
+
+	"github.com/telemetryflow/order-service/internal/domain/repository"
+	"gorm.io/gorm"
+	"gorm.io/plugin/dbresolver"
+)
+
+// txKey is the context key under which the active transaction is stored
+type txKey struct{}
+
+// unitOfWork implements repository.UnitOfWork using GORM transactions
+type unitOfWork struct {
+	db *gorm.DB
+}
+
+// NewUnitOfWork creates a new GORM unit of work
+func NewUnitOfWork(db *gorm.DB) repository.UnitOfWork {
+	return &unitOfWork{
+		db: db,
+	}
+}
+
+// Do runs fn in a transaction carried by the context passed to it.
+// Callbacks registered with repository.AfterCommit run once it commits.
+func (u *unitOfWork) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(txKey{}).(*gorm.DB); ok {
+		return fn(ctx)
+	}
+
+	hooksCtx, committed := repository.WithAfterCommit(ctx)
+	err := Transaction(u.db.WithContext(ctx), func(tx *gorm.DB) error {
+		return fn(context.WithValue(hooksCtx, txKey{}, tx))
+	})
+	if unavailable(err) {
+		// The transaction could not begin or commit
+		return unavailableError(err)
+	}
+	if err != nil {
+		return err
+	}
+	committed()
+	return nil
+}
+
+// conn returns the transaction stored in ctx, or db when there is none,
+// bound to ctx. Repositories use it for every query so that they join
+// a surrounding unit of work transparently. Outside a transaction, the
+// reads of a context marked with repository.WithReplicaReads go to a
+// healthy read replica when db has any.
+func conn(ctx context.Context, db *gorm.DB) *gorm.DB {
+	if tx, ok := ctx.Value(txKey{}).(*gorm.DB); ok {
+		return tx.WithContext(ctx)
+	}
+	if repository.ReplicaReadsAllowed(ctx) {
+		if replicas := Replicas(db); replicas != nil && replicas.Available() {
+			return db.Clauses(dbresolver.Use(replicaResolver)).Session(&gorm.Session{Context: ctx})
+		}
+	}
+	return db.WithContext(ctx)
+}
@@ -0,0 +1,33 @@
+// Package persistence provides optimistic locking for versioned entities.
+package persistence
+
+import (
+	"github.com/telemetryflow/order-service/internal/domain/entity"
+	"github.com/telemetryflow/order-service/internal/domain/repository"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// updateVersioned writes all columns of model only if the stored row still
+// has base.Version, and increments the version on success. When no row
+// matches, base is left unchanged and a *repository.ConflictError is returned.
+// Associations are never saved.
+func updateVersioned(db *gorm.DB, model interface{}, base *entity.Base, name string) error {
+	expected := base.Version
+	base.Version++
+
+	result := db.Model(model).
+		Select("*").
+		Omit("id", "created_at", clause.Associations).
+		Where("version = ?", expected).
+		Updates(model)
+	if result.Error != nil {
+		base.Version = expected
+		return translateError(result.Error, name)
+	}
+	if result.RowsAffected == 0 {
+		base.Version = expected
+		return &repository.ConflictError{Entity: name, ID: base.ID, Version: expected}
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+// Package schema derives the expected schema from GORM entities.
+package schema
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// Expected returns the tables GORM maps models to, with column types as the
+// dialect of db would create them
+func Expected(db *gorm.DB, models ...interface{}) ([]Table, error) {
+	tables := make([]Table, 0, len(models))
+	for _, model := range models {
+		stmt := &gorm.Statement{DB: db}
+		if err := stmt.Parse(model); err != nil {
+			return nil, fmt.Errorf("failed to parse %T: %w", model, err)
+		}
+		s := stmt.Schema
+
+		table := Table{Name: s.Table}
+		for _, name := range s.DBNames {
+			field := s.FieldsByDBName[name]
+			if field.IgnoreMigration {
+				continue
+			}
+			table.Columns = append(table.Columns, Column{
+				Name:     name,
+				Type:     db.Dialector.DataTypeOf(field),
+				Nullable: !field.NotNull && !field.PrimaryKey,
+			})
+		}
+
+		for _, idx := range s.ParseIndexes() {
+			index := Index{Name: idx.Name, Unique: idx.Class == "UNIQUE"}
+			for _, opt := range idx.Fields {
+				if opt.Expression != "" {
+					index.Columns = append(index.Columns, opt.Expression)
+				} else {
+					index.Columns = append(index.Columns, opt.DBName)
+				}
+			}
+			table.Indexes = append(table.Indexes, index)
+		}
+		tables = append(tables, table)
+	}
+	return tables, nil
+}
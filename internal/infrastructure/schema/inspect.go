@@ -0,0 +1,113 @@
+// Package schema reads the actual schema from PostgreSQL.
+package schema
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// ErrUnsupported is returned when the schema of a database other than
+// PostgreSQL is inspected
+var ErrUnsupported = errors.New("schema inspection is not supported")
+
+// columnRow is a row of information_schema.columns
+type columnRow struct {
+	ColumnName             string
+	DataType               string
+	UdtName                string
+	CharacterMaximumLength *int
+	NumericPrecision       *int
+	NumericScale           *int
+	IsNullable             string
+	ColumnDefault          *string
+}
+
+// indexRow is a secondary index read from pg_index
+type indexRow struct {
+	IndexName string
+	IsUnique  bool
+	Columns   string
+}
+
+// Inspect reads the columns and secondary indexes of tables in the current
+// PostgreSQL schema. Tables that do not exist are left out of the result.
+func Inspect(ctx context.Context, db *gorm.DB, tables ...string) ([]Table, error) {
+	if name := db.Dialector.Name(); name != "postgres" {
+		return nil, fmt.Errorf("%w for %s", ErrUnsupported, name)
+	}
+	db = db.WithContext(ctx)
+
+	result := make([]Table, 0, len(tables))
+	for _, name := range tables {
+		var columns []columnRow
+		err := db.Raw(`
+			SELECT column_name, data_type, udt_name, character_maximum_length,
+			       numeric_precision, numeric_scale, is_nullable, column_default
+			FROM information_schema.columns
+			WHERE table_schema = current_schema() AND table_name = ?
+			ORDER BY ordinal_position`, name).Scan(&columns).Error
+		if err != nil {
+			return nil, fmt.Errorf("failed to read columns of %s: %w", name, err)
+		}
+		if len(columns) == 0 {
+			continue
+		}
+
+		var indexes []indexRow
+		err = db.Raw(`
+			SELECT i.relname AS index_name, ix.indisunique AS is_unique,
+			       string_agg(COALESCE(a.attname, pg_get_indexdef(ix.indexrelid, k.ord::int, true)), ',' ORDER BY k.ord) AS columns
+			FROM pg_index ix
+			JOIN pg_class t ON t.oid = ix.indrelid
+			JOIN pg_class i ON i.oid = ix.indexrelid
+			JOIN pg_namespace n ON n.oid = t.relnamespace
+			CROSS JOIN LATERAL unnest(ix.indkey) WITH ORDINALITY AS k(attnum, ord)
+			LEFT JOIN pg_attribute a ON a.attrelid = t.oid AND a.attnum = k.attnum AND k.attnum > 0
+			WHERE n.nspname = current_schema() AND t.relname = ? AND NOT ix.indisprimary
+			GROUP BY i.relname, ix.indisunique
+			ORDER BY i.relname`, name).Scan(&indexes).Error
+		if err != nil {
+			return nil, fmt.Errorf("failed to read indexes of %s: %w", name, err)
+		}
+
+		table := Table{Name: name}
+		for _, c := range columns {
+			table.Columns = append(table.Columns, Column{
+				Name:       c.ColumnName,
+				Type:       c.columnType(),
+				Nullable:   c.IsNullable == "YES",
+				HasDefault: c.ColumnDefault != nil,
+			})
+		}
+		for _, i := range indexes {
+			table.Indexes = append(table.Indexes, Index{
+				Name:    i.IndexName,
+				Columns: strings.Split(i.Columns, ","),
+				Unique:  i.IsUnique,
+			})
+		}
+		result = append(result, table)
+	}
+	return result, nil
+}
+
+// columnType rebuilds the declared type, e.g. "character varying(50)"
+func (c columnRow) columnType() string {
+	switch c.DataType {
+	case "character varying", "character":
+		if c.CharacterMaximumLength != nil {
+			return fmt.Sprintf("%s(%d)", c.DataType, *c.CharacterMaximumLength)
+		}
+	case "numeric":
+		if c.NumericPrecision != nil && c.NumericScale != nil {
+			return fmt.Sprintf("numeric(%d,%d)", *c.NumericPrecision, *c.NumericScale)
+		}
+	case "USER-DEFINED", "ARRAY":
+		return c.UdtName
+	}
+	return c.DataType
+}
@@ -0,0 +1,199 @@
+// Package schema detects drift between the GORM entities and the database.
+//
+// The expected schema is derived from the entity GORM tags, the actual one
+// is read from information_schema and the PostgreSQL catalog, and Compare
+// reports every table, column, type, nullability and index that disagrees.
+package schema
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// Table describes the columns and indexes of a table
+type Table struct {
+	Name    string
+	Columns []Column
+	Indexes []Index
+}
+
+// Column describes a table column. HasDefault is only reported for
+// database columns.
+type Column struct {
+	Name       string
+	Type       string
+	Nullable   bool
+	HasDefault bool
+}
+
+// Index describes a secondary index
+type Index struct {
+	Name    string
+	Columns []string
+	Unique  bool
+}
+
+// Difference is a single disagreement between an entity and the database
+type Difference struct {
+	Table   string
+	Object  string
+	Message string
+}
+
+// String formats the difference as "table.object: message"
+func (d Difference) String() string {
+	if d.Object == "" {
+		return d.Table + ": " + d.Message
+	}
+	return d.Table + "." + d.Object + ": " + d.Message
+}
+
+// Check compares the tables of models with the database behind db
+func Check(ctx context.Context, db *gorm.DB, models ...interface{}) ([]Difference, error) {
+	expected, err := Expected(db, models...)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, len(expected))
+	for i, t := range expected {
+		names[i] = t.Name
+	}
+	actual, err := Inspect(ctx, db, names...)
+	if err != nil {
+		return nil, err
+	}
+	return Compare(expected, actual), nil
+}
+
+// Compare reports how actual differs from expected. Columns and indexes that
+// exist only in the database are tolerated, except NOT NULL columns without
+// a default, which would make inserts through the entity fail.
+func Compare(expected, actual []Table) []Difference {
+	byName := make(map[string]Table, len(actual))
+	for _, t := range actual {
+		byName[t.Name] = t
+	}
+
+	var diffs []Difference
+	for _, want := range expected {
+		got, ok := byName[want.Name]
+		if !ok {
+			diffs = append(diffs, Difference{Table: want.Name, Message: "table is missing"})
+			continue
+		}
+		diffs = append(diffs, compareColumns(want, got)...)
+		diffs = append(diffs, compareIndexes(want, got)...)
+	}
+	return diffs
+}
+
+// compareColumns reports missing, mistyped and differently nullable columns
+func compareColumns(want, got Table) []Difference {
+	columns := make(map[string]Column, len(got.Columns))
+	for _, c := range got.Columns {
+		columns[c.Name] = c
+	}
+
+	var diffs []Difference
+	known := make(map[string]bool, len(want.Columns))
+	for _, w := range want.Columns {
+		known[w.Name] = true
+		g, ok := columns[w.Name]
+		if !ok {
+			diffs = append(diffs, Difference{Table: want.Name, Object: w.Name, Message: fmt.Sprintf("column is missing, entity expects %s", w.Type)})
+			continue
+		}
+		if wt, gt := NormalizeType(w.Type), NormalizeType(g.Type); wt != gt {
+			diffs = append(diffs, Difference{Table: want.Name, Object: w.Name, Message: fmt.Sprintf("type is %s, entity expects %s", gt, wt)})
+		}
+		if w.Nullable != g.Nullable {
+			diffs = append(diffs, Difference{Table: want.Name, Object: w.Name, Message: fmt.Sprintf("column is %s, entity expects %s", nullability(g.Nullable), nullability(w.Nullable))})
+		}
+	}
+
+	for _, g := range got.Columns {
+		if !known[g.Name] && !g.Nullable && !g.HasDefault {
+			diffs = append(diffs, Difference{Table: want.Name, Object: g.Name, Message: "column is NOT NULL without a default but not mapped by the entity"})
+		}
+	}
+	return diffs
+}
+
+// compareIndexes reports missing indexes and indexes whose definition differs
+func compareIndexes(want, got Table) []Difference {
+	indexes := make(map[string]Index, len(got.Indexes))
+	for _, i := range got.Indexes {
+		indexes[i.Name] = i
+	}
+
+	var diffs []Difference
+	for _, w := range want.Indexes {
+		g, ok := indexes[w.Name]
+		switch {
+		case !ok:
+			diffs = append(diffs, Difference{Table: want.Name, Object: w.Name, Message: fmt.Sprintf("index is missing, entity expects %s", w)})
+		case g.String() != w.String():
+			diffs = append(diffs, Difference{Table: want.Name, Object: w.Name, Message: fmt.Sprintf("index is %s, entity expects %s", g, w)})
+		}
+	}
+	return diffs
+}
+
+// String formats the index definition, e.g. "UNIQUE (order_id, product_id)"
+func (i Index) String() string {
+	def := "(" + strings.Join(i.Columns, ", ") + ")"
+	if i.Unique {
+		return "UNIQUE " + def
+	}
+	return def
+}
+
+// typeAliases maps PostgreSQL type spellings to the names used in reports
+var typeAliases = map[string]string{
+	"character varying":           "varchar",
+	"character":                   "char",
+	"decimal":                     "numeric",
+	"int":                         "integer",
+	"int2":                        "smallint",
+	"int4":                        "integer",
+	"int8":                        "bigint",
+	"bool":                        "boolean",
+	"float4":                      "real",
+	"float8":                      "double precision",
+	"timestamp with time zone":    "timestamptz",
+	"timestamp without time zone": "timestamp",
+	"time with time zone":         "timetz",
+	"time without time zone":      "time",
+}
+
+// typeWithModifier splits "numeric(15, 2)" into "numeric" and "(15,2)"
+var typeWithModifier = regexp.MustCompile(`^([a-z0-9 ]+?)\s*(\([0-9, ]+\))?$`)
+
+// NormalizeType returns the canonical spelling of a PostgreSQL column type,
+// so "DECIMAL(15, 2)" and "numeric(15,2)" compare equal
+func NormalizeType(t string) string {
+	t = strings.ToLower(strings.TrimSpace(t))
+	match := typeWithModifier.FindStringSubmatch(t)
+	if match == nil {
+		return t
+	}
+
+	base, modifier := match[1], strings.ReplaceAll(match[2], " ", "")
+	if alias, ok := typeAliases[base]; ok {
+		base = alias
+	}
+	return base + modifier
+}
+
+// nullability describes a column's nullability in reports
+func nullability(nullable bool) string {
+	if nullable {
+		return "NULL"
+	}
+	return "NOT NULL"
+}
@@ -0,0 +1,9 @@
+-- Migration: Remove order total components
+-- Generated by TelemetryFlow RESTful API Generator
+-- Copyright (c) 2024-2026 DevOpsCorner Indonesia. All rights reserved.
+
+ALTER TABLE orders
+    DROP COLUMN IF EXISTS shipping,
+    DROP COLUMN IF EXISTS tax,
+    DROP COLUMN IF EXISTS discount,
+    DROP COLUMN IF EXISTS subtotal;
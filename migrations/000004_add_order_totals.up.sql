@@ -0,0 +1,20 @@
+-- Migration: Add order total components
+-- Generated by TelemetryFlow RESTful API Generator
+-- Copyright (c) 2024-2026 DevOpsCorner Indonesia. All rights reserved.
+
+ALTER TABLE orders
+    ADD COLUMN IF NOT EXISTS subtotal DECIMAL(15,2) NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS discount DECIMAL(15,2) NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS tax DECIMAL(15,2) NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS shipping DECIMAL(15,2) NOT NULL DEFAULT 0;
+
+-- Backfill subtotal and total from existing order items
+UPDATE orders o
+SET subtotal = COALESCE(i.subtotal, 0),
+    total = COALESCE(i.subtotal, 0)
+FROM (
+    SELECT order_id, SUM(quantity * price) AS subtotal
+    FROM orderitems
+    GROUP BY order_id
+) i
+WHERE i.order_id = o.id;
@@ -0,0 +1,12 @@
+-- Migration: Remove order currency and restore monetary column precision
+-- Generated by TelemetryFlow RESTful API Generator
+-- Copyright (c) 2024-2026 DevOpsCorner Indonesia. All rights reserved.
+
+ALTER TABLE orderitems
+    ALTER COLUMN price DROP DEFAULT,
+    ALTER COLUMN price TYPE DECIMAL(10,2);
+
+ALTER TABLE orders
+    ALTER COLUMN total DROP DEFAULT,
+    ALTER COLUMN total TYPE DECIMAL(10,2),
+    DROP COLUMN IF EXISTS currency;
@@ -0,0 +1,12 @@
+-- Migration: Add order currency and widen monetary columns
+-- Generated by TelemetryFlow RESTful API Generator
+-- Copyright (c) 2024-2026 DevOpsCorner Indonesia. All rights reserved.
+
+ALTER TABLE orders
+    ADD COLUMN IF NOT EXISTS currency CHAR(3) NOT NULL DEFAULT 'USD',
+    ALTER COLUMN total TYPE DECIMAL(15,2),
+    ALTER COLUMN total SET DEFAULT 0;
+
+ALTER TABLE orderitems
+    ALTER COLUMN price TYPE DECIMAL(15,2),
+    ALTER COLUMN price SET DEFAULT 0;
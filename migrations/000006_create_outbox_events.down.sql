@@ -0,0 +1,14 @@
+-- Migration: Drop outbox_events table
+-- Generated by TelemetryFlow RESTful API Generator
+-- Copyright (c) 2024-2026 DevOpsCorner Indonesia. All rights reserved.
+
+-- Drop trigger
+DROP TRIGGER IF EXISTS update_outbox_events_updated_at ON outbox_events;
+
+-- Drop indexes
+DROP INDEX IF EXISTS idx_outbox_events_pending;
+DROP INDEX IF EXISTS idx_outbox_events_aggregate_id;
+DROP INDEX IF EXISTS idx_outbox_events_status;
+
+-- Drop table
+DROP TABLE IF EXISTS outbox_events;
@@ -0,0 +1,31 @@
+-- Migration: Create outbox_events table
+-- Generated by TelemetryFlow RESTful API Generator
+-- Copyright (c) 2024-2026 DevOpsCorner Indonesia. All rights reserved.
+
+CREATE TABLE IF NOT EXISTS outbox_events (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    aggregate_id UUID NOT NULL,
+    event_name VARCHAR(255) NOT NULL,
+    payload JSONB NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT,
+    occurred_at TIMESTAMP NOT NULL,
+    available_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    published_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT chk_outbox_events_status CHECK (status IN ('pending', 'published', 'dead'))
+);
+
+-- Relay polling: pending messages that are due, oldest first
+CREATE INDEX IF NOT EXISTS idx_outbox_events_pending
+    ON outbox_events(available_at, occurred_at)
+    WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS idx_outbox_events_aggregate_id ON outbox_events(aggregate_id);
+CREATE INDEX IF NOT EXISTS idx_outbox_events_status ON outbox_events(status);
+
+CREATE TRIGGER update_outbox_events_updated_at
+    BEFORE UPDATE ON outbox_events
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
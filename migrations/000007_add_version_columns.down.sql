@@ -0,0 +1,9 @@
+-- Migration: Drop optimistic locking version columns
+-- Generated by TelemetryFlow RESTful API Generator
+-- Copyright (c) 2024-2026 DevOpsCorner Indonesia. All rights reserved.
+
+ALTER TABLE orderitems
+    DROP COLUMN IF EXISTS version;
+
+ALTER TABLE orders
+    DROP COLUMN IF EXISTS version;
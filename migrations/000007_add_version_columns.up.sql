@@ -0,0 +1,9 @@
+-- Migration: Add optimistic locking version columns
+-- Generated by TelemetryFlow RESTful API Generator
+-- Copyright (c) 2024-2026 DevOpsCorner Indonesia. All rights reserved.
+
+ALTER TABLE orders
+    ADD COLUMN IF NOT EXISTS version BIGINT NOT NULL DEFAULT 1;
+
+ALTER TABLE orderitems
+    ADD COLUMN IF NOT EXISTS version BIGINT NOT NULL DEFAULT 1;
@@ -0,0 +1,9 @@
+-- Migration: Drop idempotency_keys table
+-- Generated by TelemetryFlow RESTful API Generator
+-- Copyright (c) 2024-2026 DevOpsCorner Indonesia. All rights reserved.
+
+-- Drop indexes
+DROP INDEX IF EXISTS idx_idempotency_keys_expires_at;
+
+-- Drop table
+DROP TABLE IF EXISTS idempotency_keys;
@@ -0,0 +1,6 @@
+-- Migration: Drop keyset pagination indexes
+-- Generated by TelemetryFlow RESTful API Generator
+-- Copyright (c) 2024-2026 DevOpsCorner Indonesia. All rights reserved.
+
+DROP INDEX IF EXISTS idx_orderitems_created_at_id;
+DROP INDEX IF EXISTS idx_orders_created_at_id;
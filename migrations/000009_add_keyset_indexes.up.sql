@@ -0,0 +1,8 @@
+-- Migration: Add keyset pagination indexes
+-- Generated by TelemetryFlow RESTful API Generator
+-- Copyright (c) 2024-2026 DevOpsCorner Indonesia. All rights reserved.
+
+-- Cursor pages seek on (created_at, id); the single-column created_at
+-- indexes cannot serve the id tie-breaker.
+CREATE INDEX IF NOT EXISTS idx_orders_created_at_id ON orders(created_at, id);
+CREATE INDEX IF NOT EXISTS idx_orderitems_created_at_id ON orderitems(created_at, id);
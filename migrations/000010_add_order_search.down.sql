@@ -0,0 +1,12 @@
+-- Migration: Drop order full-text search, tags and notes
+-- Generated by TelemetryFlow RESTful API Generator
+-- Copyright (c) 2024-2026 DevOpsCorner Indonesia. All rights reserved.
+
+DROP INDEX IF EXISTS idx_orders_search_vector;
+DROP TRIGGER IF EXISTS update_orders_search_vector ON orders;
+DROP FUNCTION IF EXISTS orders_search_vector_update();
+
+ALTER TABLE orders
+    DROP COLUMN IF EXISTS search_vector,
+    DROP COLUMN IF EXISTS notes,
+    DROP COLUMN IF EXISTS tags;
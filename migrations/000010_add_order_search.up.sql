@@ -0,0 +1,42 @@
+-- Migration: Add order tags, notes and full-text search
+-- Generated by TelemetryFlow RESTful API Generator
+-- Copyright (c) 2024-2026 DevOpsCorner Indonesia. All rights reserved.
+
+ALTER TABLE orders
+    ADD COLUMN IF NOT EXISTS tags JSONB NOT NULL DEFAULT '[]',
+    ADD COLUMN IF NOT EXISTS notes TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS search_vector TSVECTOR;
+
+-- The 'simple' configuration is used for every field: identifiers, statuses
+-- and tags must match verbatim, and notes are free text in any language.
+-- Weights rank identifier matches above status and tag matches, and those
+-- above matches in notes.
+CREATE OR REPLACE FUNCTION orders_search_vector_update()
+RETURNS TRIGGER AS $$
+BEGIN
+    NEW.search_vector :=
+        setweight(to_tsvector('simple', NEW.id::text), 'A') ||
+        setweight(to_tsvector('simple', NEW.customer_id::text), 'A') ||
+        setweight(to_tsvector('simple', COALESCE(NEW.status, '')), 'B') ||
+        setweight(to_tsvector('simple', CASE
+            WHEN jsonb_typeof(NEW.tags) = 'array'
+                THEN (SELECT COALESCE(string_agg(tag, ' '), '') FROM jsonb_array_elements_text(NEW.tags) AS tag)
+            ELSE ''
+        END), 'B') ||
+        setweight(to_tsvector('simple', COALESCE(NEW.notes, '')), 'C');
+    RETURN NEW;
+END;
+$$ language 'plpgsql';
+
+DROP TRIGGER IF EXISTS update_orders_search_vector ON orders;
+CREATE TRIGGER update_orders_search_vector
+    BEFORE INSERT OR UPDATE ON orders
+    FOR EACH ROW
+    EXECUTE FUNCTION orders_search_vector_update();
+
+-- Backfill existing rows through the trigger without touching updated_at
+ALTER TABLE orders DISABLE TRIGGER update_orders_updated_at;
+UPDATE orders SET notes = notes;
+ALTER TABLE orders ENABLE TRIGGER update_orders_updated_at;
+
+CREATE INDEX IF NOT EXISTS idx_orders_search_vector ON orders USING GIN (search_vector);
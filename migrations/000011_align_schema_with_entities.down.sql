@@ -0,0 +1,40 @@
+-- Migration: Restore the pre-alignment order schema
+-- Generated by TelemetryFlow RESTful API Generator
+-- Copyright (c) 2024-2026 DevOpsCorner Indonesia. All rights reserved.
+
+ALTER TABLE order_items DROP CONSTRAINT IF EXISTS fk_orders_items;
+
+DROP INDEX IF EXISTS idx_order_items_deleted_at;
+DROP INDEX IF EXISTS idx_orders_deleted_at;
+DROP INDEX IF EXISTS idx_orders_status;
+
+ALTER TABLE idempotency_keys
+    ALTER COLUMN expires_at TYPE TIMESTAMP USING expires_at AT TIME ZONE 'UTC',
+    ALTER COLUMN created_at TYPE TIMESTAMP USING created_at AT TIME ZONE 'UTC';
+
+ALTER TABLE outbox_events
+    ALTER COLUMN updated_at TYPE TIMESTAMP USING updated_at AT TIME ZONE 'UTC',
+    ALTER COLUMN created_at TYPE TIMESTAMP USING created_at AT TIME ZONE 'UTC',
+    ALTER COLUMN published_at TYPE TIMESTAMP USING published_at AT TIME ZONE 'UTC',
+    ALTER COLUMN available_at TYPE TIMESTAMP USING available_at AT TIME ZONE 'UTC',
+    ALTER COLUMN occurred_at TYPE TIMESTAMP USING occurred_at AT TIME ZONE 'UTC';
+
+ALTER TABLE order_items
+    DROP COLUMN IF EXISTS deleted_at,
+    ALTER COLUMN quantity DROP DEFAULT,
+    ALTER COLUMN updated_at TYPE TIMESTAMP USING updated_at AT TIME ZONE 'UTC',
+    ALTER COLUMN created_at TYPE TIMESTAMP USING created_at AT TIME ZONE 'UTC';
+
+ALTER TABLE orders
+    DROP COLUMN IF EXISTS deleted_at,
+    ALTER COLUMN status DROP DEFAULT,
+    ALTER COLUMN status TYPE VARCHAR(255),
+    ALTER COLUMN updated_at TYPE TIMESTAMP USING updated_at AT TIME ZONE 'UTC',
+    ALTER COLUMN created_at TYPE TIMESTAMP USING created_at AT TIME ZONE 'UTC';
+
+ALTER TRIGGER update_order_items_updated_at ON order_items RENAME TO update_orderitems_updated_at;
+ALTER INDEX IF EXISTS idx_order_items_created_at_id RENAME TO idx_orderitems_created_at_id;
+ALTER INDEX IF EXISTS idx_order_items_product_id RENAME TO idx_orderitems_product_id;
+ALTER INDEX IF EXISTS idx_order_items_order_id RENAME TO idx_orderitems_order_id;
+ALTER INDEX IF EXISTS idx_order_items_created_at RENAME TO idx_orderitems_created_at;
+ALTER TABLE order_items RENAME TO orderitems;
@@ -0,0 +1,54 @@
+-- Migration: Align the order schema with the GORM entities
+-- Generated by TelemetryFlow RESTful API Generator
+-- Copyright (c) 2024-2026 DevOpsCorner Indonesia. All rights reserved.
+
+-- Order items live in order_items (entity.Orderitem.TableName); index and
+-- trigger names follow the table
+ALTER TABLE orderitems RENAME TO order_items;
+ALTER INDEX IF EXISTS idx_orderitems_created_at RENAME TO idx_order_items_created_at;
+ALTER INDEX IF EXISTS idx_orderitems_order_id RENAME TO idx_order_items_order_id;
+ALTER INDEX IF EXISTS idx_orderitems_product_id RENAME TO idx_order_items_product_id;
+ALTER INDEX IF EXISTS idx_orderitems_created_at_id RENAME TO idx_order_items_created_at_id;
+ALTER TRIGGER update_orderitems_updated_at ON order_items RENAME TO update_order_items_updated_at;
+
+-- GORM maps time.Time to TIMESTAMPTZ. Existing values were written as UTC.
+ALTER TABLE orders
+    ALTER COLUMN created_at TYPE TIMESTAMPTZ USING created_at AT TIME ZONE 'UTC',
+    ALTER COLUMN updated_at TYPE TIMESTAMPTZ USING updated_at AT TIME ZONE 'UTC',
+    ALTER COLUMN status TYPE VARCHAR(50),
+    ALTER COLUMN status SET DEFAULT 'pending',
+    ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMPTZ;
+
+ALTER TABLE order_items
+    ALTER COLUMN created_at TYPE TIMESTAMPTZ USING created_at AT TIME ZONE 'UTC',
+    ALTER COLUMN updated_at TYPE TIMESTAMPTZ USING updated_at AT TIME ZONE 'UTC',
+    ALTER COLUMN quantity SET DEFAULT 1,
+    ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMPTZ;
+
+ALTER TABLE outbox_events
+    ALTER COLUMN occurred_at TYPE TIMESTAMPTZ USING occurred_at AT TIME ZONE 'UTC',
+    ALTER COLUMN available_at TYPE TIMESTAMPTZ USING available_at AT TIME ZONE 'UTC',
+    ALTER COLUMN published_at TYPE TIMESTAMPTZ USING published_at AT TIME ZONE 'UTC',
+    ALTER COLUMN created_at TYPE TIMESTAMPTZ USING created_at AT TIME ZONE 'UTC',
+    ALTER COLUMN updated_at TYPE TIMESTAMPTZ USING updated_at AT TIME ZONE 'UTC';
+
+ALTER TABLE idempotency_keys
+    ALTER COLUMN created_at TYPE TIMESTAMPTZ USING created_at AT TIME ZONE 'UTC',
+    ALTER COLUMN expires_at TYPE TIMESTAMPTZ USING expires_at AT TIME ZONE 'UTC';
+
+CREATE INDEX IF NOT EXISTS idx_orders_status ON orders(status);
+CREATE INDEX IF NOT EXISTS idx_orders_deleted_at ON orders(deleted_at);
+CREATE INDEX IF NOT EXISTS idx_order_items_deleted_at ON order_items(deleted_at);
+
+-- Items are deleted with their order. NOT VALID skips checking rows that
+-- predate the constraint; new and updated rows are checked.
+ALTER TABLE order_items
+    ADD CONSTRAINT fk_orders_items FOREIGN KEY (order_id) REFERENCES orders(id)
+        ON UPDATE CASCADE ON DELETE CASCADE NOT VALID;
+
+-- Recreate the orders updated_at trigger in case it was lost
+DROP TRIGGER IF EXISTS update_orders_updated_at ON orders;
+CREATE TRIGGER update_orders_updated_at
+    BEFORE UPDATE ON orders
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
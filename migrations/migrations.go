@@ -0,0 +1,12 @@
+// Package migrations embeds the SQL schema migrations into the binary.
+//
+// Files are named NNNNNN_description.up.sql and NNNNNN_description.down.sql
+// and are applied by internal/infrastructure/migration.
+package migrations
+
+import "embed"
+
+// FS holds the embedded migration files
+//
+//go:embed *.sql
+var FS embed.FS
@@ -0,0 +1,143 @@
+// Package circuitbreaker provides a circuit breaker that fails calls fast
+// while a dependency is down.
+//
+// The breaker starts closed. After Threshold consecutive failures it opens
+// and rejects every call with ErrOpen. Once Cooldown has elapsed it is half
+// open and lets a single probe call through: a success closes it again, a
+// failure reopens it for another Cooldown.
+package circuitbreaker
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrOpen is returned by Allow while the breaker rejects calls
+var ErrOpen = errors.New("circuit breaker is open")
+
+// State is the state of a breaker
+type State int
+
+// Breaker states
+const (
+	Closed State = iota
+	HalfOpen
+	Open
+)
+
+// String returns the lower-case name of the state
+func (s State) String() string {
+	switch s {
+	case Closed:
+		return "closed"
+	case HalfOpen:
+		return "half-open"
+	case Open:
+		return "open"
+	default:
+		return "unknown"
+	}
+}
+
+// Config configures a Breaker
+type Config struct {
+	// Threshold is the number of consecutive failures opening the breaker.
+	// A breaker with a zero threshold never opens.
+	Threshold int
+	// Cooldown is how long the breaker stays open before a probe call
+	Cooldown time.Duration
+	// OnStateChange, when set, is called after every state change. It runs
+	// with the breaker locked and must not call the breaker.
+	OnStateChange func(from, to State)
+}
+
+// Breaker is a circuit breaker. It is safe for concurrent use.
+type Breaker struct {
+	cfg Config
+
+	mu       sync.Mutex
+	state    State
+	failures int
+	openedAt time.Time
+	probing  bool
+}
+
+// New creates a closed breaker
+func New(cfg Config) *Breaker {
+	return &Breaker{cfg: cfg}
+}
+
+// Allow reports whether a call may proceed, returning ErrOpen when it may
+// not. Every allowed call must be followed by Success or Failure.
+func (b *Breaker) Allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.current() {
+	case Closed:
+		return nil
+	case HalfOpen:
+		if b.probing {
+			return ErrOpen
+		}
+		b.setState(HalfOpen)
+		b.probing = true
+		return nil
+	default:
+		return ErrOpen
+	}
+}
+
+// Success records an allowed call that succeeded, closing the breaker
+func (b *Breaker) Success() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures = 0
+	b.probing = false
+	b.setState(Closed)
+}
+
+// Failure records an allowed call that failed. It opens the breaker after
+// Threshold consecutive failures, or on a failed probe.
+func (b *Breaker) Failure() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures++
+	if b.probing || (b.cfg.Threshold > 0 && b.failures >= b.cfg.Threshold) {
+		b.probing = false
+		b.openedAt = time.Now()
+		b.setState(Open)
+	}
+}
+
+// State returns the state of the breaker. An open breaker whose cooldown
+// has elapsed is reported as half open.
+func (b *Breaker) State() State {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.current()
+}
+
+// current returns the state, accounting for an elapsed cooldown.
+// b.mu must be held.
+func (b *Breaker) current() State {
+	if b.state == Open && time.Since(b.openedAt) >= b.cfg.Cooldown {
+		return HalfOpen
+	}
+	return b.state
+}
+
+// setState changes the state and notifies OnStateChange. b.mu must be held.
+func (b *Breaker) setState(to State) {
+	from := b.state
+	if from == to {
+		return
+	}
+	b.state = to
+	if b.cfg.OnStateChange != nil {
+		b.cfg.OnStateChange(from, to)
+	}
+}
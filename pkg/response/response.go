@@ -26,10 +26,12 @@ type ErrorInfo struct {
 
 // Meta represents response metadata
 type Meta struct {
-	Page       int   `json:"page,omitempty"`
-	PageSize   int   `json:"page_size,omitempty"`
-	TotalCount int64 `json:"total_count,omitempty"`
-	TotalPages int   `json:"total_pages,omitempty"`
+	Page       int    `json:"page,omitempty"`
+	PageSize   int    `json:"page_size,omitempty"`
+	TotalCount int64  `json:"total_count,omitempty"`
+	TotalPages int    `json:"total_pages,omitempty"`
+	NextCursor string `json:"next_cursor,omitempty"`
+	PrevCursor string `json:"prev_cursor,omitempty"`
 }
 
 // Success sends a success response
@@ -74,6 +76,20 @@ func Paginated(c echo.Context, data interface{}, totalCount int64, page, pageSiz
 	})
 }
 
+// CursorPaginated sends a keyset-paginated response. Empty cursors are
+// omitted.
+func CursorPaginated(c echo.Context, data interface{}, pageSize int, nextCursor, prevCursor string) error {
+	return c.JSON(http.StatusOK, Response{
+		Success: true,
+		Data:    data,
+		Meta: &Meta{
+			PageSize:   pageSize,
+			NextCursor: nextCursor,
+			PrevCursor: prevCursor,
+		},
+	})
+}
+
 // Error sends an error response
 func Error(c echo.Context, status int, code, message string) error {
 	logAttrs := map[string]interface{}{
@@ -155,11 +171,48 @@ func Conflict(c echo.Context, message string) error {
 	return Error(c, http.StatusConflict, "CONFLICT", message)
 }
 
+// PreconditionFailed sends a 412 precondition failed response
+func PreconditionFailed(c echo.Context, message string) error {
+	return Error(c, http.StatusPreconditionFailed, "PRECONDITION_FAILED", message)
+}
+
+// PreconditionRequired sends a 428 precondition required response
+func PreconditionRequired(c echo.Context, message string) error {
+	return Error(c, http.StatusPreconditionRequired, "PRECONDITION_REQUIRED", message)
+}
+
+// UnprocessableEntity sends a 422 unprocessable entity response
+func UnprocessableEntity(c echo.Context, message string) error {
+	return Error(c, http.StatusUnprocessableEntity, "UNPROCESSABLE_ENTITY", message)
+}
+
 // InternalError sends a 500 internal server error response
 func InternalError(c echo.Context, message string) error {
 	return Error(c, http.StatusInternalServerError, "INTERNAL_ERROR", message)
 }
 
+// ServerError logs err and sends a 500 internal server error response with
+// a generic message, so driver and SQL details never reach the client
+func ServerError(c echo.Context, err error) error {
+	logs.Error("API error", map[string]interface{}{
+		"code":       "INTERNAL_ERROR",
+		"error":      err.Error(),
+		"status":     http.StatusInternalServerError,
+		"method":     c.Request().Method,
+		"path":       c.Request().URL.Path,
+		"request_id": c.Response().Header().Get(echo.HeaderXRequestID),
+		"remote_ip":  c.RealIP(),
+	})
+
+	return c.JSON(http.StatusInternalServerError, Response{
+		Success: false,
+		Error: &ErrorInfo{
+			Code:    "INTERNAL_ERROR",
+			Message: "An unexpected error occurred",
+		},
+	})
+}
+
 // ValidationError sends a validation error response
 func ValidationError(c echo.Context, details map[string]string) error {
 	return ErrorWithDetails(c, http.StatusBadRequest, "VALIDATION_ERROR", "Validation failed", details)
@@ -4,6 +4,7 @@ package metrics
 import (
 	"context"
 
+	"github.com/telemetryflow/order-service/pkg/circuitbreaker"
 	"github.com/telemetryflow/order-service/telemetry"
 )
 
@@ -66,6 +67,44 @@ func RecordDBQuery(operation, table string, duration float64, success bool) {
 	})
 }
 
+// RecordDBRetry records a retry of a database statement after a transient error
+func RecordDBRetry(operation, table string, attempt int) {
+	IncrementCounter("db.retries.total", 1, map[string]interface{}{
+		"operation": operation,
+		"table":     table,
+		"attempt":   attempt,
+	})
+}
+
+// RecordCircuitBreakerState records a circuit breaker state change. The
+// state gauge is 0 when closed, 1 when half open and 2 when open.
+func RecordCircuitBreakerState(name string, from, to circuitbreaker.State) {
+	RecordGauge("circuit_breaker.state", float64(to), map[string]interface{}{
+		"name": name,
+	})
+	IncrementCounter("circuit_breaker.transitions.total", 1, map[string]interface{}{
+		"name": name,
+		"from": from.String(),
+		"to":   to.String(),
+	})
+}
+
+// Application Metrics
+
+// RecordBusDispatch records the dispatch of a command or query to its handler
+func RecordBusDispatch(kind, message string, duration float64, success bool) {
+	RecordHistogram("bus.dispatch.duration", duration, "s", map[string]interface{}{
+		"kind":    kind,
+		"message": message,
+		"success": success,
+	})
+	IncrementCounter("bus.dispatches.total", 1, map[string]interface{}{
+		"kind":    kind,
+		"message": message,
+		"success": success,
+	})
+}
+
 // Business Metrics
 
 // RecordEntityCreated records an entity creation
@@ -3,10 +3,19 @@ package traces
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/telemetryflow/order-service/telemetry"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 )
 
+// tracerName is the instrumentation scope of spans started with
+// StartInternalSpanContext
+const tracerName = "github.com/telemetryflow/order-service/telemetry/traces"
+
 // StartSpan starts a new trace span with server kind (for HTTP handlers)
 func StartSpan(ctx context.Context, name string, attrs map[string]interface{}) (string, error) {
 	if !telemetry.IsEnabled() {
@@ -23,6 +32,25 @@ func StartInternalSpan(ctx context.Context, name string, attrs map[string]interf
 	return telemetry.Client().StartSpan(ctx, name, "internal", attrs)
 }
 
+// StartInternalSpanContext starts a new internal span as a child of the span
+// in ctx, and returns a context carrying it so that spans started from that
+// context become its children. Call end with the outcome to finish the span.
+// Spans go to the global tracer provider, which the telemetry client installs
+// when it is initialized.
+func StartInternalSpanContext(ctx context.Context, name string, attrs map[string]interface{}) (spanCtx context.Context, end func(err error)) {
+	spanCtx, span := otel.Tracer(tracerName).Start(ctx, name,
+		trace.WithSpanKind(trace.SpanKindInternal),
+		trace.WithAttributes(attributes(attrs)...),
+	)
+	return spanCtx, func(err error) {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}
+}
+
 // StartClientSpan starts a new client span (for outgoing requests)
 func StartClientSpan(ctx context.Context, name string, attrs map[string]interface{}) (string, error) {
 	if !telemetry.IsEnabled() {
@@ -70,3 +98,25 @@ func DBSpan(ctx context.Context, operation, table string) (string, error) {
 		"db.table":     table,
 	})
 }
+
+// attributes converts span attributes to OpenTelemetry key-values
+func attributes(attrs map[string]interface{}) []attribute.KeyValue {
+	kvs := make([]attribute.KeyValue, 0, len(attrs))
+	for k, v := range attrs {
+		switch v := v.(type) {
+		case string:
+			kvs = append(kvs, attribute.String(k, v))
+		case bool:
+			kvs = append(kvs, attribute.Bool(k, v))
+		case int:
+			kvs = append(kvs, attribute.Int(k, v))
+		case int64:
+			kvs = append(kvs, attribute.Int64(k, v))
+		case float64:
+			kvs = append(kvs, attribute.Float64(k, v))
+		default:
+			kvs = append(kvs, attribute.String(k, fmt.Sprint(v)))
+		}
+	}
+	return kvs
+}
@@ -0,0 +1,313 @@
+// Package tests provides integration tests that run the full HTTP API
+// against a real database.
+//
+// The tests use PostgreSQL when ORDER_SERVICE_DB_HOST and
+// ORDER_SERVICE_DB_PORT are set, migrated with the embedded SQL migrations.
+// Otherwise they use an in-memory SQLite database migrated with GORM
+// AutoMigrate, so they run without any external service.
+//
+// Generated by TelemetryFlow RESTful API Generator
+// Copyright (c) 2024-2026 DevOpsCorner Indonesia. All rights reserved.
+package tests
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"github.com/telemetryflow/order-service/internal/app"
+	"github.com/telemetryflow/order-service/internal/infrastructure/config"
+	"github.com/telemetryflow/order-service/internal/infrastructure/http/middleware"
+	"github.com/telemetryflow/order-service/internal/infrastructure/migration"
+	"github.com/telemetryflow/order-service/internal/infrastructure/persistence"
+	"github.com/telemetryflow/order-service/migrations"
+)
+
+const apiTestSecret = "integration-secret"
+
+// =============================================================================
+// Test Helpers
+// =============================================================================
+
+// testDatabaseConfig returns PostgreSQL settings from the integration
+// environment, or an in-memory SQLite database without it
+func testDatabaseConfig() config.DatabaseConfig {
+	if !hasIntegrationEnv() {
+		return config.DatabaseConfig{Driver: persistence.DriverSQLite, Name: ":memory:"}
+	}
+	return config.DatabaseConfig{
+		Driver:          persistence.DriverPostgres,
+		Host:            os.Getenv("ORDER_SERVICE_DB_HOST"),
+		Port:            os.Getenv("ORDER_SERVICE_DB_PORT"),
+		Name:            os.Getenv("ORDER_SERVICE_DB_NAME"),
+		User:            os.Getenv("ORDER_SERVICE_DB_USER"),
+		Password:        os.Getenv("ORDER_SERVICE_DB_PASSWORD"),
+		SSLMode:         "disable",
+		MaxOpenConns:    5,
+		MaxIdleConns:    5,
+		ConnMaxLifetime: time.Minute,
+	}
+}
+
+// newTestDatabase connects to the test database and migrates it
+func newTestDatabase(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	cfg := testDatabaseConfig()
+	db, err := persistence.NewDatabase(cfg)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		if sqlDB, err := db.DB(); err == nil {
+			_ = sqlDB.Close()
+		}
+	})
+
+	if cfg.Driver == persistence.DriverSQLite {
+		require.NoError(t, persistence.AutoMigrate(db, persistence.Models()...))
+		return db
+	}
+
+	all, err := migration.Load(migrations.FS)
+	require.NoError(t, err)
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	_, err = migration.NewMigrator(migration.NewPostgresStore(sqlDB), all).Up(context.Background())
+	require.NoError(t, err)
+	return db
+}
+
+// apiClient sends authenticated requests to the application under test
+type apiClient struct {
+	t       *testing.T
+	handler http.Handler
+	token   string
+}
+
+// newAPIClient builds the application on a migrated test database
+func newAPIClient(t *testing.T) *apiClient {
+	t.Helper()
+
+	cfg := &config.Config{
+		JWT:       config.JWTConfig{Secret: apiTestSecret},
+		RateLimit: config.RateLimitConfig{Requests: 10000, Window: time.Minute},
+		Telemetry: config.TelemetryConfig{ServiceName: "order-service-integration"},
+	}
+	container := app.New(cfg, newTestDatabase(t))
+
+	claims := &middleware.JWTClaims{
+		UserID: "integration-user",
+		Role:   middleware.RoleAdmin,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+		},
+	}
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(apiTestSecret))
+	require.NoError(t, err)
+
+	return &apiClient{t: t, handler: container.Server, token: token}
+}
+
+// do sends a request and decodes the JSON response envelope
+func (c *apiClient) do(method, path string, body interface{}) (int, map[string]interface{}) {
+	c.t.Helper()
+	rec := c.send(method, path, body, nil)
+
+	var envelope map[string]interface{}
+	if rec.Body.Len() > 0 {
+		require.NoError(c.t, json.Unmarshal(rec.Body.Bytes(), &envelope), rec.Body.String())
+	}
+	return rec.Code, envelope
+}
+
+// send sends a request with extra headers and returns the raw response
+func (c *apiClient) send(method, path string, body interface{}, header http.Header) *httptest.ResponseRecorder {
+	c.t.Helper()
+
+	var reader *bytes.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		require.NoError(c.t, err)
+		reader = bytes.NewReader(payload)
+	} else {
+		reader = bytes.NewReader(nil)
+	}
+
+	req := httptest.NewRequest(method, path, reader)
+	for name, values := range header {
+		req.Header[name] = values
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	rec := httptest.NewRecorder()
+	c.handler.ServeHTTP(rec, req)
+	return rec
+}
+
+// data returns the data object of a response envelope
+func data(t *testing.T, envelope map[string]interface{}) map[string]interface{} {
+	t.Helper()
+	d, ok := envelope["data"].(map[string]interface{})
+	require.True(t, ok, "response has no data object: %v", envelope)
+	return d
+}
+
+// =============================================================================
+// Order API Tests
+// =============================================================================
+
+func TestDatabaseAPI_OrderLifecycle(t *testing.T) {
+	skipInShortMode(t)
+	client := newAPIClient(t)
+	customerID := uuid.New()
+
+	// Create an order with an item
+	status, body := client.do(http.MethodPost, "/api/v1/orders", map[string]interface{}{
+		"customer_id": customerID,
+		"status":      "pending",
+		"tags":        []string{"vip", "express"},
+		"notes":       "Leave at the front desk",
+		"items": []map[string]interface{}{
+			{"product_id": uuid.New(), "quantity": 2, "price": "12.50"},
+		},
+	})
+	require.Equal(t, http.StatusCreated, status, "%v", body)
+	order := data(t, body)
+	orderID, _ := order["id"].(string)
+	require.NotEmpty(t, orderID)
+	assert.Equal(t, "25.00", order["total"])
+
+	// Read it back
+	rec := client.send(http.MethodGet, "/api/v1/orders/"+orderID, nil, nil)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	etag := rec.Header().Get("ETag")
+	require.NotEmpty(t, etag)
+	status, body = client.do(http.MethodGet, "/api/v1/orders/"+orderID, nil)
+	require.Equal(t, http.StatusOK, status, "%v", body)
+	order = data(t, body)
+	assert.Equal(t, customerID.String(), order["customer_id"])
+	assert.Equal(t, []interface{}{"vip", "express"}, order["tags"])
+
+	// Update it at the version read
+	rec = client.send(http.MethodPut, "/api/v1/orders/"+orderID, map[string]interface{}{
+		"customer_id": customerID,
+		"status":      "pending",
+		"tags":        []string{"vip"},
+		"notes":       "Ring twice",
+	}, http.Header{"If-Match": {etag}})
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	rec = client.send(http.MethodPut, "/api/v1/orders/"+orderID, map[string]interface{}{
+		"customer_id": customerID,
+		"status":      "pending",
+	}, http.Header{"If-Match": {etag}})
+	assert.Equal(t, http.StatusPreconditionFailed, rec.Code, "stale versions are rejected")
+
+	// Confirm it
+	status, body = client.do(http.MethodPost, "/api/v1/orders/"+orderID+"/confirm", nil)
+	require.Equal(t, http.StatusOK, status, "%v", body)
+
+	// List, filter and search
+	status, body = client.do(http.MethodGet, "/api/v1/orders?status=confirmed", nil)
+	require.Equal(t, http.StatusOK, status, "%v", body)
+	assert.Len(t, data(t, body)["data"], 1)
+
+	status, body = client.do(http.MethodGet, "/api/v1/orders?page_size=1&cursor=", nil)
+	require.Equal(t, http.StatusOK, status, "%v", body)
+	assert.Len(t, body["data"], 1)
+
+	status, body = client.do(http.MethodGet, "/api/v1/orders/search?q=ring", nil)
+	require.Equal(t, http.StatusOK, status, "%v", body)
+	assert.EqualValues(t, 1, data(t, body)["total"])
+
+	// Delete it
+	rec = client.send(http.MethodDelete, "/api/v1/orders/"+orderID, nil, http.Header{"If-Match": {"*"}})
+	require.Less(t, rec.Code, 300, rec.Body.String())
+
+	status, _ = client.do(http.MethodGet, "/api/v1/orders/"+orderID, nil)
+	assert.Equal(t, http.StatusNotFound, status)
+}
+
+func TestDatabaseAPI_OrderItems(t *testing.T) {
+	skipInShortMode(t)
+	client := newAPIClient(t)
+
+	status, body := client.do(http.MethodPost, "/api/v1/orders", map[string]interface{}{
+		"customer_id": uuid.New(),
+		"status":      "pending",
+	})
+	require.Equal(t, http.StatusCreated, status, "%v", body)
+	orderID := data(t, body)["id"]
+
+	status, body = client.do(http.MethodPost, "/api/v1/order-items", map[string]interface{}{
+		"order_id":   orderID,
+		"product_id": uuid.New(),
+		"quantity":   3,
+		"price":      "4.00",
+	})
+	require.Equal(t, http.StatusCreated, status, "%v", body)
+
+	status, body = client.do(http.MethodGet, "/api/v1/order-items", nil)
+	require.Equal(t, http.StatusOK, status, "%v", body)
+	items, _ := data(t, body)["data"].([]interface{})
+	require.Len(t, items, 1)
+	itemID, _ := items[0].(map[string]interface{})["id"].(string)
+	require.NotEmpty(t, itemID)
+
+	status, body = client.do(http.MethodGet, "/api/v1/order-items/"+itemID, nil)
+	require.Equal(t, http.StatusOK, status, "%v", body)
+	assert.EqualValues(t, 3, data(t, body)["quantity"])
+
+	status, body = client.do(http.MethodGet, "/api/v1/orders/"+orderID.(string), nil)
+	require.Equal(t, http.StatusOK, status, "%v", body)
+	assert.Equal(t, "12.00", data(t, body)["total"])
+
+	t.Run("rejects items of unknown orders", func(t *testing.T) {
+		status, _ := client.do(http.MethodPost, "/api/v1/order-items", map[string]interface{}{
+			"order_id":   uuid.New(),
+			"product_id": uuid.New(),
+			"quantity":   1,
+			"price":      "1.00",
+		})
+		assert.GreaterOrEqual(t, status, 400)
+		assert.Less(t, status, 500)
+	})
+}
+
+func TestDatabaseAPI_IdempotencyKey(t *testing.T) {
+	skipInShortMode(t)
+	client := newAPIClient(t)
+	body := map[string]interface{}{"customer_id": uuid.New()}
+	key := http.Header{middleware.HeaderIdempotencyKey: {uuid.NewString()}}
+
+	first := client.send(http.MethodPost, "/api/v1/orders", body, key)
+	require.Equal(t, http.StatusCreated, first.Code, first.Body.String())
+	assert.Empty(t, first.Header().Get(middleware.HeaderIdempotentReplayed))
+
+	t.Run("replays the stored response for a repeat", func(t *testing.T) {
+		rec := client.send(http.MethodPost, "/api/v1/orders", body, key)
+		require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+		assert.Equal(t, "true", rec.Header().Get(middleware.HeaderIdempotentReplayed))
+		assert.Equal(t, first.Header().Get("Location"), rec.Header().Get("Location"))
+		assert.JSONEq(t, first.Body.String(), rec.Body.String())
+
+		status, list := client.do(http.MethodGet, "/api/v1/orders", nil)
+		require.Equal(t, http.StatusOK, status, "%v", list)
+		assert.Len(t, data(t, list)["data"], 1, "the order is created once")
+	})
+
+	t.Run("rejects the key with a different request", func(t *testing.T) {
+		rec := client.send(http.MethodPost, "/api/v1/orders", map[string]interface{}{"customer_id": uuid.New()}, key)
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code, rec.Body.String())
+	})
+}
@@ -0,0 +1,166 @@
+// app_test.go - Composition Root Unit Tests
+//
+// This file contains unit tests for the app package, which wires
+// repositories, application handlers and the HTTP server together.
+//
+// # Test Coverage
+//
+// The tests cover the following behaviour:
+//   - Container: every dependency is constructed
+//   - Routes: order and order item routes are mounted under /api/v1
+//   - Security: API routes require a token with the admin or user role
+//   - Validation: the Echo validator is installed
+//   - Pagination: cursors are never signed with the JWT secret
+//
+// Generated by TelemetryFlow RESTful API Generator
+// Copyright (c) 2024-2026 DevOpsCorner Indonesia. All rights reserved.
+package app_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/telemetryflow/order-service/internal/app"
+	"github.com/telemetryflow/order-service/internal/infrastructure/config"
+	"github.com/telemetryflow/order-service/internal/infrastructure/http/middleware"
+	"github.com/telemetryflow/order-service/pkg/pagination"
+)
+
+const testSecret = "test-secret"
+
+func testConfig() *config.Config {
+	return &config.Config{
+		JWT:       config.JWTConfig{Secret: testSecret},
+		RateLimit: config.RateLimitConfig{Requests: 1000, Window: time.Minute},
+		Telemetry: config.TelemetryConfig{ServiceName: "order-service-test"},
+	}
+}
+
+func createTestToken(role string) string {
+	claims := &middleware.JWTClaims{
+		UserID: "user-123",
+		Role:   role,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+		},
+	}
+	token, _ := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(testSecret))
+	return token
+}
+
+// =============================================================================
+// Container Tests
+// =============================================================================
+
+func TestNew(t *testing.T) {
+	t.Run("builds every dependency", func(t *testing.T) {
+		c := app.New(testConfig(), nil)
+
+		assert.NotNil(t, c.UnitOfWork)
+		assert.NotNil(t, c.OrderRepository)
+		assert.NotNil(t, c.OrderitemRepository)
+		assert.NotNil(t, c.OutboxRepository)
+		assert.NotNil(t, c.Events)
+		assert.NotNil(t, c.CommandBus)
+		assert.NotNil(t, c.QueryBus)
+		assert.NotNil(t, c.OrderCommandHandler)
+		assert.NotNil(t, c.OrderQueryHandler)
+		assert.NotNil(t, c.OrderitemCommandHandler)
+		assert.NotNil(t, c.OrderitemQueryHandler)
+		assert.NotNil(t, c.OrderHandler)
+		assert.NotNil(t, c.OrderitemHandler)
+		require.NotNil(t, c.Server)
+	})
+
+	t.Run("installs the validator", func(t *testing.T) {
+		c := app.New(testConfig(), nil)
+
+		assert.NotNil(t, c.Server.Echo().Validator)
+	})
+}
+
+// =============================================================================
+// Pagination Tests
+// =============================================================================
+
+func TestNew_CursorSecret(t *testing.T) {
+	token := func(secret string) string {
+		return pagination.NewCodec(secret).Encode(pagination.Cursor{CreatedAt: time.Now(), ID: uuid.New()})
+	}
+
+	t.Run("signs cursors with the configured secret", func(t *testing.T) {
+		cfg := testConfig()
+		cfg.Pagination.CursorSecret = "cursor-secret"
+		c := app.New(cfg, nil)
+
+		_, err := c.Cursors.Decode(token("cursor-secret"))
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("uses a random secret instead of the JWT secret", func(t *testing.T) {
+		c := app.New(testConfig(), nil)
+		other := app.New(testConfig(), nil)
+
+		_, err := c.Cursors.Decode(token(testSecret))
+		assert.ErrorIs(t, err, pagination.ErrInvalidCursor)
+
+		_, err = other.Cursors.Decode(c.Cursors.Encode(pagination.Cursor{CreatedAt: time.Now(), ID: uuid.New()}))
+		assert.ErrorIs(t, err, pagination.ErrInvalidCursor, "each process has its own key")
+	})
+}
+
+// =============================================================================
+// Route Tests
+// =============================================================================
+
+func TestRoutes(t *testing.T) {
+	c := app.New(testConfig(), nil)
+
+	t.Run("mounts order and order item routes", func(t *testing.T) {
+		routes := make(map[string]bool)
+		for _, r := range c.Server.Echo().Routes() {
+			routes[r.Method+" "+r.Path] = true
+		}
+
+		for _, want := range []string{
+			"POST /api/v1/orders",
+			"GET /api/v1/orders",
+			"GET /api/v1/orders/:id",
+			"PUT /api/v1/orders/:id",
+			"DELETE /api/v1/orders/:id",
+			"POST /api/v1/orders/:id/confirm",
+			"POST /api/v1/order-items",
+			"GET /api/v1/order-items/:id",
+		} {
+			assert.True(t, routes[want], "missing route %s", want)
+		}
+	})
+
+	t.Run("requires authentication", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/orders", nil)
+		rec := httptest.NewRecorder()
+
+		c.Server.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"code":"UNAUTHORIZED"`)
+	})
+
+	t.Run("rejects roles other than admin and user", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/orders", nil)
+		req.Header.Set("Authorization", "Bearer "+createTestToken("guest"))
+		rec := httptest.NewRecorder()
+
+		c.Server.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"code":"FORBIDDEN"`)
+	})
+}
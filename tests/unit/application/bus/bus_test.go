@@ -0,0 +1,347 @@
+// bus_test.go - Command and Query Bus Unit Tests
+//
+// This file contains unit tests for the generic command and query buses
+// that HTTP handlers dispatch through, and for their standard behaviors.
+//
+// # Test Coverage
+//
+// The tests cover the following behavior:
+//   - Dispatch: messages reach the handler registered for their type
+//   - Dispatch: unknown messages and mismatched result types are errors
+//   - Registration: a message type has a single handler
+//   - Pipeline: behaviors run in order around the handler
+//   - Validation: invalid messages never reach their handler
+//   - Transaction: commands run in a unit of work rolled back on errors
+//   - Authorization: callers without an allowed role are rejected
+//   - Queries: query handlers may read from replicas
+//   - Tracing: handlers run in the context of the dispatch span
+//   - Concurrency: registration and dispatch are goroutine-safe
+//
+// Generated by TelemetryFlow RESTful API Generator
+// Copyright (c) 2024-2026 DevOpsCorner Indonesia. All rights reserved.
+package bus_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/telemetryflow/order-service/internal/application/bus"
+	"github.com/telemetryflow/order-service/internal/domain/repository"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// =============================================================================
+// Test Messages
+// =============================================================================
+
+// greet is a command returning a greeting
+type greet struct {
+	Name string
+}
+
+// Validate rejects empty names
+func (g *greet) Validate() error {
+	if g.Name == "" {
+		return errors.New("name is required")
+	}
+	return nil
+}
+
+// rename is a command without result
+type rename struct{}
+
+// lookup is a query
+type lookup struct{}
+
+// journalUnitOfWork stands in for a database: the entries written in a
+// unit of work are committed to its journal when the work succeeds and
+// discarded when it fails
+type journalUnitOfWork struct {
+	journal []string
+}
+
+// pendingKey is the context key of the entries of the current unit of work
+type pendingKey struct{}
+
+func (u *journalUnitOfWork) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(pendingKey{}).(*[]string); ok {
+		return fn(ctx)
+	}
+
+	var pending []string
+	hooksCtx, committed := repository.WithAfterCommit(ctx)
+	if err := fn(context.WithValue(hooksCtx, pendingKey{}, &pending)); err != nil {
+		return err
+	}
+	u.journal = append(u.journal, pending...)
+	committed()
+	return nil
+}
+
+// write adds entry to the unit of work in ctx, or reports that there is none
+func write(ctx context.Context, entry string) error {
+	pending, ok := ctx.Value(pendingKey{}).(*[]string)
+	if !ok {
+		return errors.New("not in a unit of work")
+	}
+	*pending = append(*pending, entry)
+	return nil
+}
+
+func greeter(_ context.Context, g *greet) (string, error) {
+	return "hello " + g.Name, nil
+}
+
+// =============================================================================
+// Dispatch Tests
+// =============================================================================
+
+func TestCommandBus_Dispatch(t *testing.T) {
+	ctx := context.Background()
+	b := bus.NewCommandBus()
+	bus.RegisterCommand(b, greeter)
+
+	t.Run("returns the handler result", func(t *testing.T) {
+		greeting, err := bus.Send[string](ctx, b, &greet{Name: "ada"})
+
+		require.NoError(t, err)
+		assert.Equal(t, "hello ada", greeting)
+	})
+
+	t.Run("executes void handlers", func(t *testing.T) {
+		var called bool
+		bus.RegisterCommand(b, bus.Void(func(_ context.Context, _ rename) error {
+			called = true
+			return nil
+		}))
+
+		require.NoError(t, bus.Exec(ctx, b, rename{}))
+		assert.True(t, called)
+	})
+
+	t.Run("rejects unregistered messages", func(t *testing.T) {
+		_, err := bus.Send[string](ctx, b, lookup{})
+
+		assert.ErrorIs(t, err, bus.ErrNoHandler)
+	})
+
+	t.Run("rejects mismatched result types", func(t *testing.T) {
+		_, err := bus.Send[int](ctx, b, &greet{Name: "ada"})
+
+		assert.ErrorIs(t, err, bus.ErrResultType)
+	})
+
+	t.Run("panics on duplicate registration", func(t *testing.T) {
+		assert.Panics(t, func() { bus.RegisterCommand(b, greeter) })
+	})
+}
+
+func TestQueryBus_Dispatch(t *testing.T) {
+	b := bus.NewQueryBus()
+	bus.RegisterQuery(b, func(ctx context.Context, _ lookup) (bool, error) {
+		return repository.ReplicaReadsAllowed(ctx), nil
+	})
+
+	replicaReads, err := bus.Ask[bool](context.Background(), b, lookup{})
+
+	require.NoError(t, err)
+	assert.True(t, replicaReads)
+}
+
+func TestBus_Concurrency(t *testing.T) {
+	b := bus.NewCommandBus(bus.Validation())
+	bus.RegisterCommand(b, greeter)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			name := fmt.Sprint(i)
+			greeting, err := bus.Send[string](context.Background(), b, &greet{Name: name})
+			assert.NoError(t, err)
+			assert.Equal(t, "hello "+name, greeting)
+		}(i)
+	}
+	wg.Wait()
+}
+
+// =============================================================================
+// Behavior Tests
+// =============================================================================
+
+func TestBus_Behaviors(t *testing.T) {
+	var calls []string
+	trace := func(name string) bus.Behavior {
+		return func(ctx context.Context, msg bus.Message, next bus.Next) error {
+			calls = append(calls, name+" "+string(msg.Kind)+" "+msg.Name)
+			err := next(ctx)
+			calls = append(calls, name+" done")
+			return err
+		}
+	}
+
+	b := bus.NewCommandBus(trace("outer"), trace("inner"))
+	bus.RegisterCommand(b, func(_ context.Context, g *greet) (string, error) {
+		calls = append(calls, "handler")
+		return g.Name, nil
+	})
+
+	_, err := bus.Send[string](context.Background(), b, &greet{Name: "ada"})
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"outer command greet",
+		"inner command greet",
+		"handler",
+		"inner done",
+		"outer done",
+	}, calls)
+}
+
+func TestValidation(t *testing.T) {
+	var handled bool
+	b := bus.NewCommandBus(bus.Validation())
+	bus.RegisterCommand(b, func(_ context.Context, _ *greet) (string, error) {
+		handled = true
+		return "", nil
+	})
+
+	_, err := bus.Send[string](context.Background(), b, &greet{})
+
+	assert.EqualError(t, err, "name is required")
+	assert.False(t, handled)
+}
+
+func TestTransaction(t *testing.T) {
+	uow := &journalUnitOfWork{}
+	var published []string
+
+	b := bus.NewCommandBus(bus.Transaction(uow))
+	bus.RegisterCommand(b, func(ctx context.Context, g *greet) (string, error) {
+		// Units of work started by the handler join the bus transaction
+		err := uow.Do(ctx, func(ctx context.Context) error {
+			if err := write(ctx, g.Name); err != nil {
+				return err
+			}
+			repository.AfterCommit(ctx, func(context.Context) {
+				published = append(published, g.Name)
+			})
+			return nil
+		})
+		if err != nil {
+			return "", err
+		}
+		if g.Name == "eve" {
+			return "", errors.New("handler failed")
+		}
+		return "hello " + g.Name, nil
+	})
+	ctx := context.Background()
+
+	t.Run("commits what a successful command wrote", func(t *testing.T) {
+		_, err := bus.Send[string](ctx, b, &greet{Name: "ada"})
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"ada"}, uow.journal)
+		assert.Equal(t, []string{"ada"}, published, "events are published after commit")
+	})
+
+	t.Run("rolls back when the handler fails", func(t *testing.T) {
+		_, err := bus.Send[string](ctx, b, &greet{Name: "eve"})
+
+		assert.EqualError(t, err, "handler failed")
+		assert.Equal(t, []string{"ada"}, uow.journal, "the write of the failed command is discarded")
+		assert.Equal(t, []string{"ada"}, published, "events of the failed command are not published")
+	})
+
+	t.Run("passes queries through", func(t *testing.T) {
+		q := bus.NewQueryBus(bus.Transaction(uow))
+		bus.RegisterQuery(q, func(ctx context.Context, _ lookup) (string, error) {
+			return "", write(ctx, "lookup")
+		})
+
+		_, err := bus.Ask[string](ctx, q, lookup{})
+
+		assert.EqualError(t, err, "not in a unit of work")
+	})
+}
+
+func TestAuthorization(t *testing.T) {
+	b := bus.NewCommandBus(bus.Authorization(bus.RequireRole("admin", "user")))
+	bus.RegisterCommand(b, greeter)
+	cmd := &greet{Name: "ada"}
+
+	t.Run("allows listed roles", func(t *testing.T) {
+		ctx := bus.WithActor(context.Background(), bus.Actor{UserID: "u-1", Role: "user"})
+
+		_, err := bus.Send[string](ctx, b, cmd)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("rejects other roles", func(t *testing.T) {
+		ctx := bus.WithActor(context.Background(), bus.Actor{UserID: "u-1", Role: "guest"})
+
+		_, err := bus.Send[string](ctx, b, cmd)
+
+		assert.ErrorIs(t, err, bus.ErrForbidden)
+	})
+
+	t.Run("rejects anonymous callers", func(t *testing.T) {
+		_, err := bus.Send[string](context.Background(), b, cmd)
+
+		assert.ErrorIs(t, err, bus.ErrForbidden)
+	})
+}
+
+func TestObservabilityBehaviors(t *testing.T) {
+	// Telemetry is disabled in tests; the behaviors must pass results and
+	// errors through unchanged
+	b := bus.NewCommandBus(bus.Tracing(), bus.Metrics(), bus.Logging())
+	bus.RegisterCommand(b, func(_ context.Context, g *greet) (string, error) {
+		if g.Name == "" {
+			return "", errors.New("no name")
+		}
+		return strings.ToUpper(g.Name), nil
+	})
+
+	greeting, err := bus.Send[string](context.Background(), b, &greet{Name: "ada"})
+	require.NoError(t, err)
+	assert.Equal(t, "ADA", greeting)
+
+	_, err = bus.Send[string](context.Background(), b, &greet{})
+	assert.EqualError(t, err, "no name")
+}
+
+func TestTracing(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+
+	b := bus.NewCommandBus(bus.Tracing())
+	var handled trace.SpanContext
+	bus.RegisterCommand(b, func(ctx context.Context, g *greet) (string, error) {
+		handled = trace.SpanContextFromContext(ctx)
+		return "", errors.New("no greeting")
+	})
+
+	_, err := bus.Send[string](context.Background(), b, &greet{Name: "ada"})
+	assert.EqualError(t, err, "no greeting")
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	assert.Equal(t, "command.greet", spans[0].Name())
+	assert.Equal(t, spans[0].SpanContext(), handled, "the handler runs in the span's context")
+	assert.Equal(t, "no greeting", spans[0].Status().Description)
+}
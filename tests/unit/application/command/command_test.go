@@ -7,9 +7,11 @@
 // # Test Coverage
 //
 // The tests cover the following commands:
-//   - CreateOrderCommand: Order creation with validation and entity conversion
-//   - UpdateOrderCommand: Order modification with ID validation
+//   - CreateOrderCommand: Order creation with per-field validation and entity conversion
+//   - UpdateOrderCommand: Order modification with ID and per-field validation
 //   - DeleteOrderCommand: Order deletion with ID validation
+//   - Confirm/Pay/Ship/Deliver/CancelOrderCommand: Status transition ID validation
+//   - Create/UpdateOrderitemCommand: Per-field item validation
 //
 // # Test Patterns
 //
@@ -21,14 +23,28 @@
 package command_test
 
 import (
+	"sort"
 	"testing"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/telemetryflow/order-service/internal/application/command"
+	"github.com/telemetryflow/order-service/internal/domain"
+	"github.com/telemetryflow/order-service/pkg/validator"
 )
 
+// usd builds a US dollar amount from its decimal representation.
+func usd(amount string) domain.Money {
+	return domain.MustParseMoney(amount, domain.DefaultCurrency)
+}
+
+// usdPtr builds an optional US dollar amount, e.g. a client-supplied total.
+func usdPtr(amount string) *domain.Money {
+	m := usd(amount)
+	return &m
+}
+
 // =============================================================================
 // CreateOrderCommand Tests
 //
@@ -41,13 +57,65 @@ func TestCreateOrderCommand_Validate(t *testing.T) {
 	t.Run("valid command returns nil", func(t *testing.T) {
 		cmd := &command.CreateOrderCommand{
 			CustomerID: uuid.New(),
-			Total:      100.0,
+			Total:      usdPtr("100.00"),
 			Status:     "pending",
 		}
 
 		err := cmd.Validate()
 		assert.NoError(t, err)
 	})
+
+	t.Run("accepts free items", func(t *testing.T) {
+		cmd := &command.CreateOrderCommand{
+			CustomerID: uuid.New(),
+			Status:     "pending",
+			Items:      []command.CreateOrderItem{{ProductID: uuid.New(), Quantity: 1, Price: usd("0.00")}},
+		}
+
+		assert.NoError(t, cmd.Validate())
+	})
+
+	t.Run("reports every invalid field", func(t *testing.T) {
+		cmd := &command.CreateOrderCommand{
+			Currency: "eur",
+			Status:   "archived",
+			Discount: usd("-1.00"),
+			Items: []command.CreateOrderItem{
+				{ProductID: uuid.New(), Quantity: 1, Price: usd("5.00")},
+				{Quantity: -2, Price: usd("-5.00")},
+			},
+		}
+
+		err := cmd.Validate()
+
+		var verr *validator.ValidationError
+		require.ErrorAs(t, err, &verr)
+		assert.Equal(t, "Validation failed", verr.Message)
+		assert.Equal(t, []string{
+			"currency", "customer_id", "discount", "items[1].price",
+			"items[1].product_id", "items[1].quantity", "status",
+		}, sortedKeys(verr.Errors))
+	})
+
+	t.Run("rejects statuses other than pending", func(t *testing.T) {
+		for _, status := range []string{"confirmed", "paid", "shipped", "delivered"} {
+			cmd := &command.CreateOrderCommand{CustomerID: uuid.New(), Status: status}
+
+			var verr *validator.ValidationError
+			require.ErrorAs(t, cmd.Validate(), &verr, status)
+			assert.Contains(t, verr.Errors, "status", status)
+		}
+	})
+}
+
+// sortedKeys returns the field names of a validation error in order.
+func sortedKeys(fields map[string]string) []string {
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
 }
 
 func TestCreateOrderCommand_ToEntity(t *testing.T) {
@@ -55,8 +123,8 @@ func TestCreateOrderCommand_ToEntity(t *testing.T) {
 		customerID := uuid.New()
 		cmd := &command.CreateOrderCommand{
 			CustomerID: customerID,
-			Total:      150.50,
-			Status:     "confirmed",
+			Total:      usdPtr("150.50"),
+			Status:     "pending",
 		}
 
 		entity := cmd.ToEntity()
@@ -64,8 +132,8 @@ func TestCreateOrderCommand_ToEntity(t *testing.T) {
 		require.NotNil(t, entity)
 		assert.NotEqual(t, uuid.Nil, entity.ID)
 		assert.Equal(t, customerID, entity.CustomerID)
-		assert.Equal(t, 150.50, entity.Total)
-		assert.Equal(t, "confirmed", entity.Status)
+		assert.Equal(t, usd("150.50"), entity.Total)
+		assert.Equal(t, "pending", entity.Status)
 		assert.False(t, entity.CreatedAt.IsZero())
 		assert.False(t, entity.UpdatedAt.IsZero())
 	})
@@ -73,7 +141,7 @@ func TestCreateOrderCommand_ToEntity(t *testing.T) {
 	t.Run("creates unique entities", func(t *testing.T) {
 		cmd := &command.CreateOrderCommand{
 			CustomerID: uuid.New(),
-			Total:      100.0,
+			Total:      usdPtr("100.00"),
 			Status:     "pending",
 		}
 
@@ -103,7 +171,7 @@ func TestUpdateOrderCommand_Validate(t *testing.T) {
 			cmd: &command.UpdateOrderCommand{
 				ID:         uuid.New(),
 				CustomerID: uuid.New(),
-				Total:      100.0,
+				Total:      usdPtr("100.00"),
 				Status:     "pending",
 			},
 			expectError: false,
@@ -113,7 +181,7 @@ func TestUpdateOrderCommand_Validate(t *testing.T) {
 			cmd: &command.UpdateOrderCommand{
 				ID:         uuid.Nil,
 				CustomerID: uuid.New(),
-				Total:      100.0,
+				Total:      usdPtr("100.00"),
 				Status:     "pending",
 			},
 			expectError: true,
@@ -131,6 +199,19 @@ func TestUpdateOrderCommand_Validate(t *testing.T) {
 			}
 		})
 	}
+
+	t.Run("unknown status and negative charges return field errors", func(t *testing.T) {
+		cmd := &command.UpdateOrderCommand{
+			ID:         uuid.New(),
+			CustomerID: uuid.New(),
+			Status:     "lost",
+			Shipping:   usd("-3.00"),
+		}
+
+		var verr *validator.ValidationError
+		require.ErrorAs(t, cmd.Validate(), &verr)
+		assert.Equal(t, []string{"shipping", "status"}, sortedKeys(verr.Errors))
+	})
 }
 
 func TestUpdateOrderCommand_ToEntity(t *testing.T) {
@@ -140,7 +221,7 @@ func TestUpdateOrderCommand_ToEntity(t *testing.T) {
 		cmd := &command.UpdateOrderCommand{
 			ID:         id,
 			CustomerID: customerID,
-			Total:      200.0,
+			Total:      usdPtr("200.00"),
 			Status:     "shipped",
 		}
 
@@ -149,7 +230,7 @@ func TestUpdateOrderCommand_ToEntity(t *testing.T) {
 		require.NotNil(t, entity)
 		assert.Equal(t, id, entity.ID)
 		assert.Equal(t, customerID, entity.CustomerID)
-		assert.Equal(t, 200.0, entity.Total)
+		assert.Equal(t, usd("200.00"), entity.Total)
 		assert.Equal(t, "shipped", entity.Status)
 	})
 }
@@ -197,6 +278,73 @@ func TestDeleteOrderCommand_Validate(t *testing.T) {
 	}
 }
 
+// =============================================================================
+// Order Status Transition Command Tests
+//
+// Tests for the commands that drive the order status state machine.
+// Each command only carries the ID of the order to transition.
+// =============================================================================
+
+// TestOrderTransitionCommands_Validate verifies ID validation for transition commands.
+func TestOrderTransitionCommands_Validate(t *testing.T) {
+	tests := []struct {
+		name  string
+		build func(id uuid.UUID) command.Command
+	}{
+		{"confirm", func(id uuid.UUID) command.Command { return &command.ConfirmOrderCommand{ID: id} }},
+		{"pay", func(id uuid.UUID) command.Command { return &command.PayOrderCommand{ID: id} }},
+		{"ship", func(id uuid.UUID) command.Command { return &command.ShipOrderCommand{ID: id} }},
+		{"deliver", func(id uuid.UUID) command.Command { return &command.DeliverOrderCommand{ID: id} }},
+		{"cancel", func(id uuid.UUID) command.Command { return &command.CancelOrderCommand{ID: id} }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name+" with valid ID returns nil", func(t *testing.T) {
+			assert.NoError(t, tt.build(uuid.New()).Validate())
+		})
+		t.Run(tt.name+" with nil ID returns error", func(t *testing.T) {
+			assert.Equal(t, command.ErrInvalidID, tt.build(uuid.Nil).Validate())
+		})
+	}
+}
+
+// =============================================================================
+// Orderitem Command Tests
+//
+// Tests for the Orderitem create and update commands, which require an order
+// and a product, a positive quantity and a non-negative price.
+// =============================================================================
+
+// TestOrderitemCommands_Validate verifies validation rules for order items.
+func TestOrderitemCommands_Validate(t *testing.T) {
+	t.Run("valid create command returns nil", func(t *testing.T) {
+		cmd := &command.CreateOrderitemCommand{OrderID: uuid.New(), ProductID: uuid.New(), Quantity: 1, Price: usd("0.00")}
+		assert.NoError(t, cmd.Validate())
+	})
+
+	t.Run("create command reports invalid fields", func(t *testing.T) {
+		cmd := &command.CreateOrderitemCommand{Quantity: 0, Price: usd("-0.01")}
+
+		var verr *validator.ValidationError
+		require.ErrorAs(t, cmd.Validate(), &verr)
+		assert.Equal(t, []string{"order_id", "price", "product_id", "quantity"}, sortedKeys(verr.Errors))
+		assert.Equal(t, "Must be greater than or equal to 1", verr.Errors["quantity"])
+	})
+
+	t.Run("update command requires an ID", func(t *testing.T) {
+		cmd := &command.UpdateOrderitemCommand{OrderID: uuid.New(), ProductID: uuid.New(), Quantity: 1}
+		assert.Equal(t, command.ErrInvalidID, cmd.Validate())
+	})
+
+	t.Run("update command reports a negative quantity", func(t *testing.T) {
+		cmd := &command.UpdateOrderitemCommand{ID: uuid.New(), OrderID: uuid.New(), ProductID: uuid.New(), Quantity: -1}
+
+		var verr *validator.ValidationError
+		require.ErrorAs(t, cmd.Validate(), &verr)
+		assert.Equal(t, []string{"quantity"}, sortedKeys(verr.Errors))
+	})
+}
+
 // =============================================================================
 // Edge Cases
 //
@@ -208,34 +356,35 @@ func TestCommand_EdgeCases(t *testing.T) {
 	t.Run("create command with zero total", func(t *testing.T) {
 		cmd := &command.CreateOrderCommand{
 			CustomerID: uuid.New(),
-			Total:      0,
+			Total:      usdPtr("0.00"),
 			Status:     "pending",
 		}
 
 		entity := cmd.ToEntity()
-		assert.Equal(t, float64(0), entity.Total)
+		assert.Equal(t, usd("0.00"), entity.Total)
 	})
 
 	t.Run("create command with negative total", func(t *testing.T) {
 		cmd := &command.CreateOrderCommand{
 			CustomerID: uuid.New(),
-			Total:      -50.0,
+			Total:      usdPtr("-50.00"),
 			Status:     "refunded",
 		}
 
 		entity := cmd.ToEntity()
-		assert.Equal(t, -50.0, entity.Total)
+		assert.Equal(t, usd("-50.00"), entity.Total)
 	})
 
-	t.Run("create command with empty status", func(t *testing.T) {
+	t.Run("create command with empty status starts pending", func(t *testing.T) {
 		cmd := &command.CreateOrderCommand{
 			CustomerID: uuid.New(),
-			Total:      100.0,
+			Total:      usdPtr("100.00"),
 			Status:     "",
 		}
 
+		require.NoError(t, cmd.Validate())
 		entity := cmd.ToEntity()
-		assert.Equal(t, "", entity.Status)
+		assert.Equal(t, "pending", entity.Status)
 	})
 
 	t.Run("update command preserves provided ID", func(t *testing.T) {
@@ -243,7 +392,7 @@ func TestCommand_EdgeCases(t *testing.T) {
 		cmd := &command.UpdateOrderCommand{
 			ID:         specificID,
 			CustomerID: uuid.New(),
-			Total:      100.0,
+			Total:      usdPtr("100.00"),
 			Status:     "pending",
 		}
 
@@ -263,7 +412,7 @@ func TestCommand_EdgeCases(t *testing.T) {
 func BenchmarkCreateOrderCommand_ToEntity(b *testing.B) {
 	cmd := &command.CreateOrderCommand{
 		CustomerID: uuid.New(),
-		Total:      100.0,
+		Total:      usdPtr("100.00"),
 		Status:     "pending",
 	}
 
@@ -277,7 +426,7 @@ func BenchmarkUpdateOrderCommand_Validate(b *testing.B) {
 	cmd := &command.UpdateOrderCommand{
 		ID:         uuid.New(),
 		CustomerID: uuid.New(),
-		Total:      100.0,
+		Total:      usdPtr("100.00"),
 		Status:     "pending",
 	}
 
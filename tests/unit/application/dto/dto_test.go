@@ -29,9 +29,21 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/telemetryflow/order-service/internal/application/dto"
+	"github.com/telemetryflow/order-service/internal/domain"
 	"github.com/telemetryflow/order-service/internal/domain/entity"
 )
 
+// usd builds a US dollar amount from its decimal representation.
+func usd(amount string) domain.Money {
+	return domain.MustParseMoney(amount, domain.DefaultCurrency)
+}
+
+// usdPtr builds an optional US dollar amount, e.g. a client-supplied total.
+func usdPtr(amount string) *domain.Money {
+	m := usd(amount)
+	return &m
+}
+
 // =============================================================================
 // OrderResponse Tests
 //
@@ -42,13 +54,13 @@ import (
 func TestFromOrder(t *testing.T) {
 	t.Run("converts entity to response correctly", func(t *testing.T) {
 		customerID := uuid.New()
-		order := entity.NewOrder(customerID, 150.50, "confirmed")
+		order := entity.NewOrder(customerID, usd("150.50"), "confirmed")
 
 		response := dto.FromOrder(order)
 
 		assert.Equal(t, order.ID, response.ID)
 		assert.Equal(t, customerID, response.CustomerID)
-		assert.Equal(t, 150.50, response.Total)
+		assert.Equal(t, usd("150.50"), response.Total)
 		assert.Equal(t, "confirmed", response.Status)
 		assert.Equal(t, order.CreatedAt, response.CreatedAt)
 		assert.Equal(t, order.UpdatedAt, response.UpdatedAt)
@@ -61,7 +73,7 @@ func TestFromOrder(t *testing.T) {
 
 		assert.Equal(t, uuid.Nil, response.ID)
 		assert.Equal(t, uuid.Nil, response.CustomerID)
-		assert.Equal(t, float64(0), response.Total)
+		assert.True(t, response.Total.IsZero())
 		assert.Equal(t, "", response.Status)
 	})
 }
@@ -69,9 +81,9 @@ func TestFromOrder(t *testing.T) {
 func TestFromOrders(t *testing.T) {
 	t.Run("converts multiple entities to responses", func(t *testing.T) {
 		entities := []entity.Order{
-			*entity.NewOrder(uuid.New(), 100.0, "pending"),
-			*entity.NewOrder(uuid.New(), 200.0, "confirmed"),
-			*entity.NewOrder(uuid.New(), 300.0, "shipped"),
+			*entity.NewOrder(uuid.New(), usd("100.00"), "pending"),
+			*entity.NewOrder(uuid.New(), usd("200.00"), "confirmed"),
+			*entity.NewOrder(uuid.New(), usd("300.00"), "shipped"),
 		}
 
 		responses := dto.FromOrders(entities)
@@ -94,7 +106,7 @@ func TestFromOrders(t *testing.T) {
 	t.Run("handles nil entities in slice", func(t *testing.T) {
 		// This tests the actual behavior - it creates a response for each entity
 		entities := []entity.Order{
-			*entity.NewOrder(uuid.New(), 100.0, "pending"),
+			*entity.NewOrder(uuid.New(), usd("100.00"), "pending"),
 		}
 
 		responses := dto.FromOrders(entities)
@@ -105,7 +117,7 @@ func TestFromOrders(t *testing.T) {
 
 func TestOrderToResponse(t *testing.T) {
 	t.Run("converts entity pointer to response pointer", func(t *testing.T) {
-		order := entity.NewOrder(uuid.New(), 100.0, "pending")
+		order := entity.NewOrder(uuid.New(), usd("100.00"), "pending")
 
 		response := dto.OrderToResponse(order)
 
@@ -126,8 +138,8 @@ func TestOrderToResponse(t *testing.T) {
 
 func TestOrderListResponse(t *testing.T) {
 	t.Run("contains correct pagination info", func(t *testing.T) {
-		order1 := entity.NewOrder(uuid.New(), 100.0, "pending")
-		order2 := entity.NewOrder(uuid.New(), 200.0, "confirmed")
+		order1 := entity.NewOrder(uuid.New(), usd("100.00"), "pending")
+		order2 := entity.NewOrder(uuid.New(), usd("200.00"), "confirmed")
 
 		resp1 := dto.OrderToResponse(order1)
 		resp2 := dto.OrderToResponse(order2)
@@ -166,12 +178,12 @@ func TestCreateOrderRequest(t *testing.T) {
 	t.Run("struct has correct field tags", func(t *testing.T) {
 		req := dto.CreateOrderRequest{
 			CustomerID: uuid.New(),
-			Total:      99.99,
+			Total:      usdPtr("99.99"),
 			Status:     "pending",
 		}
 
 		assert.NotEqual(t, uuid.Nil, req.CustomerID)
-		assert.Equal(t, 99.99, req.Total)
+		assert.Equal(t, usdPtr("99.99"), req.Total)
 		assert.Equal(t, "pending", req.Status)
 	})
 }
@@ -184,12 +196,12 @@ func TestUpdateOrderRequest(t *testing.T) {
 	t.Run("struct has correct field tags", func(t *testing.T) {
 		req := dto.UpdateOrderRequest{
 			CustomerID: uuid.New(),
-			Total:      199.99,
+			Total:      usdPtr("199.99"),
 			Status:     "confirmed",
 		}
 
 		assert.NotEqual(t, uuid.Nil, req.CustomerID)
-		assert.Equal(t, 199.99, req.Total)
+		assert.Equal(t, usdPtr("199.99"), req.Total)
 		assert.Equal(t, "confirmed", req.Status)
 	})
 }
@@ -208,7 +220,7 @@ func TestDTO_EdgeCases(t *testing.T) {
 				UpdatedAt: now,
 			},
 			CustomerID: uuid.New(),
-			Total:      100.0,
+			Total:      usd("100.00"),
 			Status:     "pending",
 		}
 
@@ -219,7 +231,7 @@ func TestDTO_EdgeCases(t *testing.T) {
 	})
 
 	t.Run("handles very large totals", func(t *testing.T) {
-		largeTotal := 9999999999.99
+		largeTotal := usd("9999999999.99")
 		order := entity.NewOrder(uuid.New(), largeTotal, "pending")
 
 		response := dto.FromOrder(order)
@@ -228,7 +240,7 @@ func TestDTO_EdgeCases(t *testing.T) {
 	})
 
 	t.Run("handles negative totals", func(t *testing.T) {
-		negativeTotal := -50.0
+		negativeTotal := usd("-50.00")
 		order := entity.NewOrder(uuid.New(), negativeTotal, "refunded")
 
 		response := dto.FromOrder(order)
@@ -238,7 +250,7 @@ func TestDTO_EdgeCases(t *testing.T) {
 
 	t.Run("handles special characters in status", func(t *testing.T) {
 		specialStatus := "pending-review_123"
-		order := entity.NewOrder(uuid.New(), 100.0, specialStatus)
+		order := entity.NewOrder(uuid.New(), usd("100.00"), specialStatus)
 
 		response := dto.FromOrder(order)
 
@@ -251,7 +263,7 @@ func TestDTO_EdgeCases(t *testing.T) {
 // =============================================================================
 
 func BenchmarkFromOrder(b *testing.B) {
-	order := entity.NewOrder(uuid.New(), 100.0, "pending")
+	order := entity.NewOrder(uuid.New(), usd("100.00"), "pending")
 
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
@@ -262,7 +274,7 @@ func BenchmarkFromOrder(b *testing.B) {
 func BenchmarkFromOrders(b *testing.B) {
 	entities := make([]entity.Order, 100)
 	for i := range entities {
-		entities[i] = *entity.NewOrder(uuid.New(), float64(i*10), "pending")
+		entities[i] = *entity.NewOrder(uuid.New(), domain.NewMoney(int64(i*1000), domain.DefaultCurrency), "pending")
 	}
 
 	b.ResetTimer()
@@ -272,7 +284,7 @@ func BenchmarkFromOrders(b *testing.B) {
 }
 
 func BenchmarkOrderToResponse(b *testing.B) {
-	order := entity.NewOrder(uuid.New(), 100.0, "pending")
+	order := entity.NewOrder(uuid.New(), usd("100.00"), "pending")
 
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
@@ -0,0 +1,110 @@
+// eventbus_test.go - Domain Event Dispatcher Unit Tests
+//
+// This file contains unit tests for the in-process event dispatcher that
+// command handlers use to publish domain events after a successful commit.
+//
+// # Test Coverage
+//
+// The tests cover the following behavior:
+//   - Typed subscriptions only receive events of their type
+//   - SubscribeAll receives every event after the typed subscribers
+//   - A failing subscriber is reported and does not stop the others
+//
+// Generated by TelemetryFlow RESTful API Generator
+// Copyright (c) 2024-2026 DevOpsCorner Indonesia. All rights reserved.
+package eventbus_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/telemetryflow/order-service/internal/application/eventbus"
+	"github.com/telemetryflow/order-service/internal/domain/event"
+)
+
+// =============================================================================
+// Dispatcher Tests
+// =============================================================================
+
+// TestDispatcher_Subscribe verifies typed subscriber registration.
+func TestDispatcher_Subscribe(t *testing.T) {
+	t.Run("typed subscriber receives only its event type", func(t *testing.T) {
+		d := eventbus.NewDispatcher()
+		orderID := uuid.New()
+
+		var cancelled []event.OrderCancelled
+		eventbus.Subscribe(d, func(_ context.Context, e event.OrderCancelled) error {
+			cancelled = append(cancelled, e)
+			return nil
+		})
+
+		d.Publish(context.Background(),
+			event.OrderCreated{Metadata: event.NewMetadata(), OrderID: orderID},
+			event.OrderCancelled{Metadata: event.NewMetadata(), OrderID: orderID, PreviousStatus: "pending"},
+		)
+
+		require.Len(t, cancelled, 1)
+		assert.Equal(t, orderID, cancelled[0].OrderID)
+		assert.Equal(t, "pending", cancelled[0].PreviousStatus)
+	})
+
+	t.Run("subscribers run in registration order", func(t *testing.T) {
+		d := eventbus.NewDispatcher()
+
+		var calls []string
+		eventbus.Subscribe(d, func(_ context.Context, _ event.OrderCreated) error {
+			calls = append(calls, "first")
+			return nil
+		})
+		eventbus.Subscribe(d, func(_ context.Context, _ event.OrderCreated) error {
+			calls = append(calls, "second")
+			return nil
+		})
+		d.SubscribeAll(func(_ context.Context, e event.Event) error {
+			calls = append(calls, "all:"+e.EventName())
+			return nil
+		})
+
+		d.Publish(context.Background(), event.OrderCreated{Metadata: event.NewMetadata()})
+
+		assert.Equal(t, []string{"first", "second", "all:" + event.OrderCreatedName}, calls)
+	})
+
+	t.Run("publishing without subscribers is a no-op", func(t *testing.T) {
+		d := eventbus.NewDispatcher()
+
+		assert.NotPanics(t, func() {
+			d.Publish(context.Background(), event.OrderItemRemoved{Metadata: event.NewMetadata()})
+		})
+	})
+}
+
+// TestDispatcher_Errors verifies that subscriber errors are isolated.
+func TestDispatcher_Errors(t *testing.T) {
+	d := eventbus.NewDispatcher()
+	failure := errors.New("subscriber failed")
+
+	var reported []error
+	d.OnError(func(_ context.Context, e event.Event, err error) {
+		assert.Equal(t, event.OrderUpdatedName, e.EventName())
+		reported = append(reported, err)
+	})
+
+	delivered := false
+	eventbus.Subscribe(d, func(_ context.Context, _ event.OrderUpdated) error {
+		return failure
+	})
+	eventbus.Subscribe(d, func(_ context.Context, _ event.OrderUpdated) error {
+		delivered = true
+		return nil
+	})
+
+	d.Publish(context.Background(), event.OrderUpdated{Metadata: event.NewMetadata()})
+
+	assert.True(t, delivered)
+	assert.Equal(t, []error{failure}, reported)
+}
@@ -8,7 +8,11 @@
 //
 // The tests cover the following handlers:
 //   - OrderCommandHandler: Create, Update, Delete operations
-//   - OrderQueryHandler: GetByID, GetAll queries
+//   - OrderQueryHandler: GetByID, GetAll queries, cursor pages and search
+//   - OrderitemCommandHandler: order total recalculation on item changes
+//   - OrderitemCommandHandler: items of orders past pending cannot change
+//   - Orderitem handlers: items addressed through their parent order
+//   - Domain events: publication after commit and outbox writes in the unit of work
 //   - Full CRUD workflow integration tests
 //
 // # Mocking Strategy
@@ -23,18 +27,40 @@ package handler_test
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"github.com/telemetryflow/order-service/internal/application/command"
+	"github.com/telemetryflow/order-service/internal/application/eventbus"
 	"github.com/telemetryflow/order-service/internal/application/handler"
 	"github.com/telemetryflow/order-service/internal/application/query"
+	"github.com/telemetryflow/order-service/internal/domain"
 	"github.com/telemetryflow/order-service/internal/domain/entity"
+	"github.com/telemetryflow/order-service/internal/domain/event"
+	"github.com/telemetryflow/order-service/internal/domain/repository"
+	"github.com/telemetryflow/order-service/pkg/pagination"
+	"github.com/telemetryflow/order-service/pkg/validator"
 )
 
+// usd builds a US dollar amount from its decimal representation.
+func usd(amount string) domain.Money {
+	return domain.MustParseMoney(amount, domain.DefaultCurrency)
+}
+
+// usdPtr builds an optional US dollar amount, e.g. a client-supplied total.
+func usdPtr(amount string) *domain.Money {
+	m := usd(amount)
+	return &m
+}
+
+// testCursors signs pagination cursors in tests.
+var testCursors = pagination.NewCodec("test-secret")
+
 // =============================================================================
 // Mock Order Repository
 //
@@ -68,6 +94,30 @@ func (m *MockOrderRepository) FindAll(ctx context.Context, offset, limit int) ([
 	return args.Get(0).([]entity.Order), args.Get(1).(int64), args.Error(2)
 }
 
+func (m *MockOrderRepository) List(ctx context.Context, filter repository.OrderFilter) ([]entity.Order, int64, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, 0, args.Error(2)
+	}
+	return args.Get(0).([]entity.Order), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockOrderRepository) FindPage(ctx context.Context, filter repository.OrderFilter, page repository.KeysetPage) ([]entity.Order, bool, error) {
+	args := m.Called(ctx, filter, page)
+	if args.Get(0) == nil {
+		return nil, false, args.Error(2)
+	}
+	return args.Get(0).([]entity.Order), args.Bool(1), args.Error(2)
+}
+
+func (m *MockOrderRepository) Search(ctx context.Context, text string, offset, limit int) ([]repository.OrderSearchHit, int64, error) {
+	args := m.Called(ctx, text, offset, limit)
+	if args.Get(0) == nil {
+		return nil, 0, args.Error(2)
+	}
+	return args.Get(0).([]repository.OrderSearchHit), args.Get(1).(int64), args.Error(2)
+}
+
 func (m *MockOrderRepository) Update(ctx context.Context, e *entity.Order) error {
 	args := m.Called(ctx, e)
 	return args.Error(0)
@@ -107,6 +157,53 @@ func (m *MockOrderRepository) FindWithItems(ctx context.Context, id uuid.UUID) (
 	return args.Get(0).(*entity.Order), args.Error(1)
 }
 
+// inlineUnitOfWork runs the work directly, standing in for
+// persistence.NewUnitOfWork, and counts how often it was used.
+type inlineUnitOfWork struct {
+	calls int
+}
+
+// unitOfWorkKey marks contexts handed out by inlineUnitOfWork
+type unitOfWorkKey struct{}
+
+func (u *inlineUnitOfWork) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	u.calls++
+	return fn(context.WithValue(ctx, unitOfWorkKey{}, u))
+}
+
+// inUnitOfWork matches contexts passed through inlineUnitOfWork
+func inUnitOfWork(ctx context.Context) bool {
+	return ctx.Value(unitOfWorkKey{}) != nil
+}
+
+// memoryOutbox implements repository.OutboxRepository for testing.
+// It records appended events and whether they were appended inside a unit of work.
+type memoryOutbox struct {
+	events    []event.Event
+	outsideTx int
+	err       error
+}
+
+func (o *memoryOutbox) Append(ctx context.Context, events ...event.Event) error {
+	if o.err != nil {
+		return o.err
+	}
+	if !inUnitOfWork(ctx) {
+		o.outsideTx++
+	}
+	o.events = append(o.events, events...)
+	return nil
+}
+
+// names returns the names of the appended events.
+func (o *memoryOutbox) names() []string {
+	var names []string
+	for _, e := range o.events {
+		names = append(names, e.EventName())
+	}
+	return names
+}
+
 // =============================================================================
 // Order Command Handler Tests
 //
@@ -118,7 +215,7 @@ func (m *MockOrderRepository) FindWithItems(ctx context.Context, id uuid.UUID) (
 func TestNewOrderCommandHandler(t *testing.T) {
 	t.Run("creates handler with repository", func(t *testing.T) {
 		repo := new(MockOrderRepository)
-		h := handler.NewOrderCommandHandler(repo)
+		h := handler.NewOrderCommandHandler(repo, new(MockOrderitemRepository), new(inlineUnitOfWork), new(memoryOutbox), eventbus.NewDispatcher())
 
 		require.NotNil(t, h)
 	})
@@ -127,17 +224,18 @@ func TestNewOrderCommandHandler(t *testing.T) {
 func TestOrderCommandHandler_HandleOrderCreate(t *testing.T) {
 	t.Run("successfully creates order", func(t *testing.T) {
 		repo := new(MockOrderRepository)
-		h := handler.NewOrderCommandHandler(repo)
+		h := handler.NewOrderCommandHandler(repo, new(MockOrderitemRepository), new(inlineUnitOfWork), new(memoryOutbox), eventbus.NewDispatcher())
 
 		cmd := &command.CreateOrderCommand{
 			CustomerID: uuid.New(),
-			Total:      100.0,
+			Shipping:   usd("10.00"),
+			Total:      usdPtr("10.00"),
 			Status:     "pending",
 		}
 
 		repo.On("Create", mock.Anything, mock.AnythingOfType("*entity.Order")).Return(nil)
 
-		err := h.HandleOrderCreate(context.Background(), cmd)
+		_, err := h.HandleOrderCreate(context.Background(), cmd)
 
 		assert.NoError(t, err)
 		repo.AssertExpectations(t)
@@ -145,75 +243,344 @@ func TestOrderCommandHandler_HandleOrderCreate(t *testing.T) {
 
 	t.Run("returns error on repository failure", func(t *testing.T) {
 		repo := new(MockOrderRepository)
-		h := handler.NewOrderCommandHandler(repo)
+		h := handler.NewOrderCommandHandler(repo, new(MockOrderitemRepository), new(inlineUnitOfWork), new(memoryOutbox), eventbus.NewDispatcher())
 
 		cmd := &command.CreateOrderCommand{
 			CustomerID: uuid.New(),
-			Total:      100.0,
+			Shipping:   usd("10.00"),
+			Total:      usdPtr("10.00"),
 			Status:     "pending",
 		}
 
 		expectedErr := errors.New("database error")
 		repo.On("Create", mock.Anything, mock.AnythingOfType("*entity.Order")).Return(expectedErr)
 
-		err := h.HandleOrderCreate(context.Background(), cmd)
+		_, err := h.HandleOrderCreate(context.Background(), cmd)
 
 		assert.Error(t, err)
 		assert.Equal(t, expectedErr, err)
 		repo.AssertExpectations(t)
 	})
+
+	t.Run("rejects total that disagrees with computed total", func(t *testing.T) {
+		repo := new(MockOrderRepository)
+		h := handler.NewOrderCommandHandler(repo, new(MockOrderitemRepository), new(inlineUnitOfWork), new(memoryOutbox), eventbus.NewDispatcher())
+
+		cmd := &command.CreateOrderCommand{
+			CustomerID: uuid.New(),
+			Shipping:   usd("10.00"),
+			Total:      usdPtr("100.00"),
+			Status:     "pending",
+		}
+
+		_, err := h.HandleOrderCreate(context.Background(), cmd)
+
+		assert.ErrorIs(t, err, domain.ErrTotalMismatch)
+		repo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("rejects an explicit zero total for a non-zero order", func(t *testing.T) {
+		repo := new(MockOrderRepository)
+		h := handler.NewOrderCommandHandler(repo, new(MockOrderitemRepository), new(inlineUnitOfWork), new(memoryOutbox), eventbus.NewDispatcher())
+
+		cmd := &command.CreateOrderCommand{
+			CustomerID: uuid.New(),
+			Shipping:   usd("10.00"),
+			Total:      usdPtr("0.00"),
+		}
+
+		_, err := h.HandleOrderCreate(context.Background(), cmd)
+
+		assert.ErrorIs(t, err, domain.ErrTotalMismatch)
+		repo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("rejects a discount larger than the order", func(t *testing.T) {
+		repo := new(MockOrderRepository)
+		h := handler.NewOrderCommandHandler(repo, new(MockOrderitemRepository), new(inlineUnitOfWork), new(memoryOutbox), eventbus.NewDispatcher())
+
+		cmd := &command.CreateOrderCommand{
+			CustomerID: uuid.New(),
+			Discount:   usd("25.00"),
+			Items:      []command.CreateOrderItem{{ProductID: uuid.New(), Quantity: 1, Price: usd("20.00")}},
+		}
+
+		_, err := h.HandleOrderCreate(context.Background(), cmd)
+
+		var verr *domain.ValidationError
+		require.ErrorAs(t, err, &verr)
+		assert.Contains(t, verr.Fields, "discount")
+		repo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("creates order and items in one transaction", func(t *testing.T) {
+		repo := new(MockOrderRepository)
+		itemRepo := new(MockOrderitemRepository)
+		uow := new(inlineUnitOfWork)
+		h := handler.NewOrderCommandHandler(repo, itemRepo, uow, new(memoryOutbox), eventbus.NewDispatcher())
+
+		cmd := &command.CreateOrderCommand{
+			CustomerID: uuid.New(),
+			Status:     "pending",
+			Total:      usdPtr("35.00"),
+			Items: []command.CreateOrderItem{
+				{ProductID: uuid.New(), Quantity: 2, Price: usd("10.00")},
+				{ProductID: uuid.New(), Quantity: 1, Price: usd("15.00")},
+			},
+		}
+
+		var order *entity.Order
+		repo.On("Create", mock.Anything, mock.AnythingOfType("*entity.Order")).
+			Run(func(args mock.Arguments) { order = args.Get(1).(*entity.Order) }).
+			Return(nil)
+		itemRepo.On("CreateBatch", mock.Anything, mock.MatchedBy(func(items []entity.Orderitem) bool {
+			return len(items) == 2
+		})).Return(nil)
+
+		result, err := h.HandleOrderCreate(context.Background(), cmd)
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, uow.calls)
+		assert.True(t, result.Success)
+		assert.Equal(t, order.ID, result.ID)
+		assert.Equal(t, int64(1), result.Version)
+		assert.Equal(t, order.CreatedAt, result.CreatedAt)
+		assert.Equal(t, order.UpdatedAt, result.UpdatedAt)
+		require.Len(t, order.Items, 2)
+		for _, item := range order.Items {
+			assert.Equal(t, order.ID, item.OrderID)
+		}
+		assert.Equal(t, usd("35.00"), order.Subtotal)
+		assert.Equal(t, usd("35.00"), order.Total)
+		repo.AssertExpectations(t)
+		itemRepo.AssertExpectations(t)
+	})
+
+	t.Run("returns item error so the transaction rolls back", func(t *testing.T) {
+		repo := new(MockOrderRepository)
+		itemRepo := new(MockOrderitemRepository)
+		h := handler.NewOrderCommandHandler(repo, itemRepo, new(inlineUnitOfWork), new(memoryOutbox), eventbus.NewDispatcher())
+
+		cmd := &command.CreateOrderCommand{
+			CustomerID: uuid.New(),
+			Status:     "pending",
+			Items:      []command.CreateOrderItem{{ProductID: uuid.New(), Quantity: 1, Price: usd("5.00")}},
+		}
+
+		expectedErr := errors.New("insert failed")
+		repo.On("Create", mock.Anything, mock.AnythingOfType("*entity.Order")).Return(nil)
+		itemRepo.On("CreateBatch", mock.Anything, mock.Anything).Return(expectedErr)
+
+		result, err := h.HandleOrderCreate(context.Background(), cmd)
+
+		assert.Equal(t, command.CommandResult{}, result)
+		assert.Equal(t, expectedErr, err)
+	})
+
+	t.Run("rejects invalid items before persisting", func(t *testing.T) {
+		repo := new(MockOrderRepository)
+		h := handler.NewOrderCommandHandler(repo, new(MockOrderitemRepository), new(inlineUnitOfWork), new(memoryOutbox), eventbus.NewDispatcher())
+
+		cmd := &command.CreateOrderCommand{
+			CustomerID: uuid.New(),
+			Status:     "pending",
+			Items:      []command.CreateOrderItem{{ProductID: uuid.New(), Quantity: 0, Price: usd("5.00")}},
+		}
+
+		_, err := h.HandleOrderCreate(context.Background(), cmd)
+
+		var verr *validator.ValidationError
+		require.ErrorAs(t, err, &verr)
+		assert.Contains(t, verr.Errors, "items[0].quantity")
+		repo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
 }
 
 func TestOrderCommandHandler_HandleOrderUpdate(t *testing.T) {
 	t.Run("successfully updates order", func(t *testing.T) {
 		repo := new(MockOrderRepository)
-		h := handler.NewOrderCommandHandler(repo)
+		h := handler.NewOrderCommandHandler(repo, new(MockOrderitemRepository), new(inlineUnitOfWork), new(memoryOutbox), eventbus.NewDispatcher())
 
+		existing := entity.NewOrder(uuid.New(), usd("100.00"), "pending")
+		existing.Items = []entity.Orderitem{*entity.NewOrderitem(existing.ID, uuid.New(), 2, usd("100.00"))}
 		cmd := &command.UpdateOrderCommand{
-			ID:         uuid.New(),
+			ID:         existing.ID,
 			CustomerID: uuid.New(),
-			Total:      200.0,
+			Total:      usdPtr("200.00"),
 			Status:     "confirmed",
 		}
 
+		repo.On("FindWithItems", mock.Anything, existing.ID).Return(existing, nil)
 		repo.On("Update", mock.Anything, mock.AnythingOfType("*entity.Order")).Return(nil)
 
-		err := h.HandleOrderUpdate(context.Background(), cmd)
+		result, err := h.HandleOrderUpdate(context.Background(), cmd)
 
 		assert.NoError(t, err)
+		assert.Equal(t, existing.ID, result.ID)
+		assert.Equal(t, existing.Version, result.Version)
+		assert.Equal(t, existing.UpdatedAt, result.UpdatedAt)
+		assert.Equal(t, "confirmed", existing.Status)
+		assert.Equal(t, usd("200.00"), existing.Subtotal)
+		assert.Equal(t, usd("200.00"), existing.Total)
 		repo.AssertExpectations(t)
 	})
 
 	t.Run("returns error on repository failure", func(t *testing.T) {
 		repo := new(MockOrderRepository)
-		h := handler.NewOrderCommandHandler(repo)
+		h := handler.NewOrderCommandHandler(repo, new(MockOrderitemRepository), new(inlineUnitOfWork), new(memoryOutbox), eventbus.NewDispatcher())
 
+		existing := entity.NewOrder(uuid.New(), usd("100.00"), "pending")
+		existing.Items = []entity.Orderitem{*entity.NewOrderitem(existing.ID, uuid.New(), 2, usd("100.00"))}
 		cmd := &command.UpdateOrderCommand{
-			ID:         uuid.New(),
+			ID:         existing.ID,
 			CustomerID: uuid.New(),
-			Total:      200.0,
+			Total:      usdPtr("200.00"),
 			Status:     "confirmed",
 		}
 
 		expectedErr := errors.New("update failed")
+		repo.On("FindWithItems", mock.Anything, existing.ID).Return(existing, nil)
 		repo.On("Update", mock.Anything, mock.AnythingOfType("*entity.Order")).Return(expectedErr)
 
-		err := h.HandleOrderUpdate(context.Background(), cmd)
+		_, err := h.HandleOrderUpdate(context.Background(), cmd)
 
 		assert.Error(t, err)
 		assert.Equal(t, expectedErr, err)
 		repo.AssertExpectations(t)
 	})
+
+	t.Run("rejects illegal status transition", func(t *testing.T) {
+		repo := new(MockOrderRepository)
+		h := handler.NewOrderCommandHandler(repo, new(MockOrderitemRepository), new(inlineUnitOfWork), new(memoryOutbox), eventbus.NewDispatcher())
+
+		existing := entity.NewOrder(uuid.New(), usd("100.00"), "pending")
+		cmd := &command.UpdateOrderCommand{
+			ID:         existing.ID,
+			CustomerID: existing.CustomerID,
+			Total:      usdPtr("100.00"),
+			Status:     "delivered",
+		}
+
+		repo.On("FindWithItems", mock.Anything, existing.ID).Return(existing, nil)
+
+		_, err := h.HandleOrderUpdate(context.Background(), cmd)
+
+		assert.ErrorIs(t, err, domain.ErrInvalidStateTransition)
+		assert.Equal(t, "pending", existing.Status)
+		repo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+
+	t.Run("returns not found when order is missing", func(t *testing.T) {
+		repo := new(MockOrderRepository)
+		h := handler.NewOrderCommandHandler(repo, new(MockOrderitemRepository), new(inlineUnitOfWork), new(memoryOutbox), eventbus.NewDispatcher())
+
+		cmd := &command.UpdateOrderCommand{
+			ID:         uuid.New(),
+			CustomerID: uuid.New(),
+			Total:      usdPtr("100.00"),
+			Status:     "pending",
+		}
+
+		repo.On("FindWithItems", mock.Anything, cmd.ID).Return(nil, domain.ErrEntityNotFound)
+
+		_, err := h.HandleOrderUpdate(context.Background(), cmd)
+
+		assert.Equal(t, command.ErrNotFound, err)
+	})
+}
+
+// TestOrderCommandHandler_StatusTransitions verifies the explicit transition commands.
+func TestOrderCommandHandler_StatusTransitions(t *testing.T) {
+	tests := []struct {
+		name     string
+		from     string
+		handle   func(h *handler.OrderCommandHandler, id uuid.UUID) error
+		expected string
+	}{
+		{
+			name: "confirm pending order",
+			from: "pending",
+			handle: func(h *handler.OrderCommandHandler, id uuid.UUID) error {
+				return h.HandleOrderConfirm(context.Background(), &command.ConfirmOrderCommand{ID: id})
+			},
+			expected: "confirmed",
+		},
+		{
+			name: "pay confirmed order",
+			from: "confirmed",
+			handle: func(h *handler.OrderCommandHandler, id uuid.UUID) error {
+				return h.HandleOrderPay(context.Background(), &command.PayOrderCommand{ID: id})
+			},
+			expected: "paid",
+		},
+		{
+			name: "ship paid order",
+			from: "paid",
+			handle: func(h *handler.OrderCommandHandler, id uuid.UUID) error {
+				return h.HandleOrderShip(context.Background(), &command.ShipOrderCommand{ID: id})
+			},
+			expected: "shipped",
+		},
+		{
+			name: "deliver shipped order",
+			from: "shipped",
+			handle: func(h *handler.OrderCommandHandler, id uuid.UUID) error {
+				return h.HandleOrderDeliver(context.Background(), &command.DeliverOrderCommand{ID: id})
+			},
+			expected: "delivered",
+		},
+		{
+			name: "cancel pending order",
+			from: "pending",
+			handle: func(h *handler.OrderCommandHandler, id uuid.UUID) error {
+				return h.HandleOrderCancel(context.Background(), &command.CancelOrderCommand{ID: id})
+			},
+			expected: "cancelled",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(MockOrderRepository)
+			h := handler.NewOrderCommandHandler(repo, new(MockOrderitemRepository), new(inlineUnitOfWork), new(memoryOutbox), eventbus.NewDispatcher())
+
+			order := entity.NewOrder(uuid.New(), usd("100.00"), tt.from)
+			repo.On("FindByID", mock.Anything, order.ID).Return(order, nil)
+			repo.On("Update", mock.Anything, order).Return(nil)
+
+			err := tt.handle(h, order.ID)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, order.Status)
+			repo.AssertExpectations(t)
+		})
+	}
+
+	t.Run("cancel shipped order is rejected", func(t *testing.T) {
+		repo := new(MockOrderRepository)
+		h := handler.NewOrderCommandHandler(repo, new(MockOrderitemRepository), new(inlineUnitOfWork), new(memoryOutbox), eventbus.NewDispatcher())
+
+		order := entity.NewOrder(uuid.New(), usd("100.00"), "shipped")
+		repo.On("FindByID", mock.Anything, order.ID).Return(order, nil)
+
+		err := h.HandleOrderCancel(context.Background(), &command.CancelOrderCommand{ID: order.ID})
+
+		assert.ErrorIs(t, err, domain.ErrInvalidStateTransition)
+		assert.Equal(t, "shipped", order.Status)
+		repo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
 }
 
 func TestOrderCommandHandler_HandleOrderDelete(t *testing.T) {
 	t.Run("successfully deletes order", func(t *testing.T) {
 		repo := new(MockOrderRepository)
-		h := handler.NewOrderCommandHandler(repo)
+		h := handler.NewOrderCommandHandler(repo, new(MockOrderitemRepository), new(inlineUnitOfWork), new(memoryOutbox), eventbus.NewDispatcher())
 
-		orderID := uuid.New()
+		order := entity.NewOrder(uuid.New(), usd("100.00"), "pending")
+		orderID := order.ID
 		cmd := &command.DeleteOrderCommand{ID: orderID}
 
+		repo.On("FindByID", mock.Anything, orderID).Return(order, nil)
 		repo.On("Delete", mock.Anything, orderID).Return(nil)
 
 		err := h.HandleOrderDelete(context.Background(), cmd)
@@ -224,12 +591,14 @@ func TestOrderCommandHandler_HandleOrderDelete(t *testing.T) {
 
 	t.Run("returns error on repository failure", func(t *testing.T) {
 		repo := new(MockOrderRepository)
-		h := handler.NewOrderCommandHandler(repo)
+		h := handler.NewOrderCommandHandler(repo, new(MockOrderitemRepository), new(inlineUnitOfWork), new(memoryOutbox), eventbus.NewDispatcher())
 
-		orderID := uuid.New()
+		order := entity.NewOrder(uuid.New(), usd("100.00"), "pending")
+		orderID := order.ID
 		cmd := &command.DeleteOrderCommand{ID: orderID}
 
 		expectedErr := errors.New("delete failed")
+		repo.On("FindByID", mock.Anything, orderID).Return(order, nil)
 		repo.On("Delete", mock.Anything, orderID).Return(expectedErr)
 
 		err := h.HandleOrderDelete(context.Background(), cmd)
@@ -238,6 +607,719 @@ func TestOrderCommandHandler_HandleOrderDelete(t *testing.T) {
 		assert.Equal(t, expectedErr, err)
 		repo.AssertExpectations(t)
 	})
+
+	t.Run("returns not found without deleting", func(t *testing.T) {
+		repo := new(MockOrderRepository)
+		h := handler.NewOrderCommandHandler(repo, new(MockOrderitemRepository), new(inlineUnitOfWork), new(memoryOutbox), eventbus.NewDispatcher())
+
+		orderID := uuid.New()
+		repo.On("FindByID", mock.Anything, orderID).Return(nil, domain.ErrEntityNotFound)
+
+		err := h.HandleOrderDelete(context.Background(), &command.DeleteOrderCommand{ID: orderID})
+
+		assert.ErrorIs(t, err, command.ErrNotFound)
+		repo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+	})
+}
+
+// =============================================================================
+// Orderitem Command Handler Tests
+//
+// Tests for OrderitemCommandHandler, which keeps the parent order total in
+// sync with its items after every item change.
+// =============================================================================
+
+// MockOrderitemRepository implements repository.OrderitemRepository for testing.
+type MockOrderitemRepository struct {
+	mock.Mock
+}
+
+func (m *MockOrderitemRepository) Create(ctx context.Context, e *entity.Orderitem) error {
+	args := m.Called(ctx, e)
+	return args.Error(0)
+}
+
+func (m *MockOrderitemRepository) FindByID(ctx context.Context, id uuid.UUID) (*entity.Orderitem, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Orderitem), args.Error(1)
+}
+
+func (m *MockOrderitemRepository) FindAll(ctx context.Context, offset, limit int) ([]entity.Orderitem, int64, error) {
+	args := m.Called(ctx, offset, limit)
+	if args.Get(0) == nil {
+		return nil, 0, args.Error(2)
+	}
+	return args.Get(0).([]entity.Orderitem), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockOrderitemRepository) FindPage(ctx context.Context, page repository.KeysetPage) ([]entity.Orderitem, bool, error) {
+	args := m.Called(ctx, page)
+	if args.Get(0) == nil {
+		return nil, false, args.Error(2)
+	}
+	return args.Get(0).([]entity.Orderitem), args.Bool(1), args.Error(2)
+}
+
+func (m *MockOrderitemRepository) Update(ctx context.Context, e *entity.Orderitem) error {
+	args := m.Called(ctx, e)
+	return args.Error(0)
+}
+
+func (m *MockOrderitemRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockOrderitemRepository) HardDelete(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockOrderitemRepository) FindByOrderID(ctx context.Context, orderID uuid.UUID) ([]entity.Orderitem, error) {
+	args := m.Called(ctx, orderID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entity.Orderitem), args.Error(1)
+}
+
+func (m *MockOrderitemRepository) FindByProductID(ctx context.Context, productID uuid.UUID) ([]entity.Orderitem, error) {
+	args := m.Called(ctx, productID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entity.Orderitem), args.Error(1)
+}
+
+func (m *MockOrderitemRepository) CreateBatch(ctx context.Context, items []entity.Orderitem) error {
+	args := m.Called(ctx, items)
+	return args.Error(0)
+}
+
+func (m *MockOrderitemRepository) DeleteByOrderID(ctx context.Context, orderID uuid.UUID) error {
+	args := m.Called(ctx, orderID)
+	return args.Error(0)
+}
+
+func TestOrderitemCommandHandler_RecalculatesOrderTotal(t *testing.T) {
+	t.Run("create adds item amount to order total", func(t *testing.T) {
+		itemRepo := new(MockOrderitemRepository)
+		orderRepo := new(MockOrderRepository)
+		h := handler.NewOrderitemCommandHandler(itemRepo, orderRepo, new(inlineUnitOfWork), new(memoryOutbox), eventbus.NewDispatcher())
+
+		order := entity.NewOrder(uuid.New(), usd("0.00"), "pending")
+		cmd := &command.CreateOrderitemCommand{OrderID: order.ID, ProductID: uuid.New(), Quantity: 2, Price: usd("12.50")}
+
+		orderRepo.On("FindByID", mock.Anything, order.ID).Return(order, nil)
+		itemRepo.On("Create", mock.Anything, mock.AnythingOfType("*entity.Orderitem")).
+			Run(func(args mock.Arguments) {
+				order.Items = append(order.Items, *args.Get(1).(*entity.Orderitem))
+			}).Return(nil)
+		orderRepo.On("FindWithItems", mock.Anything, order.ID).Return(order, nil)
+		orderRepo.On("Update", mock.Anything, order).Return(nil)
+
+		result, err := h.HandleOrderitemCreate(context.Background(), cmd)
+
+		assert.NoError(t, err)
+		require.Len(t, order.Items, 1)
+		assert.Equal(t, order.Items[0].ID, result.ID)
+		assert.Equal(t, usd("25.00"), order.Total)
+		itemRepo.AssertExpectations(t)
+		orderRepo.AssertExpectations(t)
+	})
+
+	t.Run("create returns not found for unknown order", func(t *testing.T) {
+		itemRepo := new(MockOrderitemRepository)
+		orderRepo := new(MockOrderRepository)
+		h := handler.NewOrderitemCommandHandler(itemRepo, orderRepo, new(inlineUnitOfWork), new(memoryOutbox), eventbus.NewDispatcher())
+
+		cmd := &command.CreateOrderitemCommand{OrderID: uuid.New(), ProductID: uuid.New(), Quantity: 1, Price: usd("1.00")}
+		orderRepo.On("FindByID", mock.Anything, cmd.OrderID).Return(nil, domain.ErrEntityNotFound)
+
+		_, err := h.HandleOrderitemCreate(context.Background(), cmd)
+
+		assert.Equal(t, command.ErrNotFound, err)
+		itemRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("update rejects an item violating its invariants", func(t *testing.T) {
+		itemRepo := new(MockOrderitemRepository)
+		orderRepo := new(MockOrderRepository)
+		h := handler.NewOrderitemCommandHandler(itemRepo, orderRepo, new(inlineUnitOfWork), new(memoryOutbox), eventbus.NewDispatcher())
+
+		order := entity.NewOrder(uuid.New(), usd("10.00"), "pending")
+		item := entity.NewOrderitem(order.ID, uuid.New(), 1, usd("10.00"))
+		cmd := &command.UpdateOrderitemCommand{ID: item.ID, OrderID: item.OrderID, ProductID: item.ProductID, Quantity: 1, Price: usd("-10.00")}
+		itemRepo.On("FindByID", mock.Anything, item.ID).Return(item, nil)
+		orderRepo.On("FindByID", mock.Anything, order.ID).Return(order, nil)
+
+		_, err := h.HandleOrderitemUpdate(context.Background(), cmd)
+
+		var verr *domain.ValidationError
+		require.ErrorAs(t, err, &verr)
+		assert.Contains(t, verr.Fields, "price")
+		itemRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+
+	t.Run("update moving item recalculates both orders", func(t *testing.T) {
+		itemRepo := new(MockOrderitemRepository)
+		orderRepo := new(MockOrderRepository)
+		h := handler.NewOrderitemCommandHandler(itemRepo, orderRepo, new(inlineUnitOfWork), new(memoryOutbox), eventbus.NewDispatcher())
+
+		from := entity.NewOrder(uuid.New(), usd("10.00"), "pending")
+		to := entity.NewOrder(uuid.New(), usd("0.00"), "pending")
+		item := entity.NewOrderitem(from.ID, uuid.New(), 1, usd("10.00"))
+		cmd := &command.UpdateOrderitemCommand{ID: item.ID, OrderID: to.ID, ProductID: item.ProductID, Quantity: 3, Price: usd("10.00")}
+
+		itemRepo.On("FindByID", mock.Anything, item.ID).Return(item, nil)
+		orderRepo.On("FindByID", mock.Anything, from.ID).Return(from, nil)
+		orderRepo.On("FindByID", mock.Anything, to.ID).Return(to, nil)
+		itemRepo.On("Update", mock.Anything, item).Run(func(mock.Arguments) {
+			to.Items = []entity.Orderitem{*item}
+		}).Return(nil)
+		orderRepo.On("FindWithItems", mock.Anything, to.ID).Return(to, nil)
+		orderRepo.On("FindWithItems", mock.Anything, from.ID).Return(from, nil)
+		orderRepo.On("Update", mock.Anything, to).Return(nil)
+		orderRepo.On("Update", mock.Anything, from).Return(nil)
+
+		_, err := h.HandleOrderitemUpdate(context.Background(), cmd)
+
+		assert.NoError(t, err)
+		assert.Equal(t, usd("30.00"), to.Total)
+		assert.Equal(t, usd("0.00"), from.Total)
+		orderRepo.AssertExpectations(t)
+	})
+
+	t.Run("create retries the total after a concurrent item change", func(t *testing.T) {
+		itemRepo := new(MockOrderitemRepository)
+		orderRepo := new(MockOrderRepository)
+		h := handler.NewOrderitemCommandHandler(itemRepo, orderRepo, new(inlineUnitOfWork), new(memoryOutbox), eventbus.NewDispatcher())
+
+		order := entity.NewOrder(uuid.New(), usd("0.00"), "pending")
+		cmd := &command.CreateOrderitemCommand{OrderID: order.ID, ProductID: uuid.New(), Quantity: 1, Price: usd("5.00")}
+
+		orderRepo.On("FindByID", mock.Anything, order.ID).Return(order, nil)
+		itemRepo.On("Create", mock.Anything, mock.AnythingOfType("*entity.Orderitem")).Return(nil)
+		orderRepo.On("FindWithItems", mock.Anything, order.ID).Return(order, nil).Twice()
+		orderRepo.On("Update", mock.Anything, order).
+			Return(&repository.ConflictError{Entity: "order", ID: order.ID, Version: order.Version}).Once()
+		orderRepo.On("Update", mock.Anything, order).Return(nil).Once()
+
+		_, err := h.HandleOrderitemCreate(context.Background(), cmd)
+
+		assert.NoError(t, err)
+		orderRepo.AssertExpectations(t)
+	})
+
+	t.Run("create fails when the total conflicts twice", func(t *testing.T) {
+		itemRepo := new(MockOrderitemRepository)
+		orderRepo := new(MockOrderRepository)
+		h := handler.NewOrderitemCommandHandler(itemRepo, orderRepo, new(inlineUnitOfWork), new(memoryOutbox), eventbus.NewDispatcher())
+
+		order := entity.NewOrder(uuid.New(), usd("0.00"), "pending")
+		cmd := &command.CreateOrderitemCommand{OrderID: order.ID, ProductID: uuid.New(), Quantity: 1, Price: usd("5.00")}
+
+		orderRepo.On("FindByID", mock.Anything, order.ID).Return(order, nil)
+		itemRepo.On("Create", mock.Anything, mock.AnythingOfType("*entity.Orderitem")).Return(nil)
+		orderRepo.On("FindWithItems", mock.Anything, order.ID).Return(order, nil)
+		orderRepo.On("Update", mock.Anything, order).
+			Return(&repository.ConflictError{Entity: "order", ID: order.ID, Version: order.Version})
+
+		_, err := h.HandleOrderitemCreate(context.Background(), cmd)
+
+		assert.ErrorIs(t, err, repository.ErrVersionConflict)
+		orderRepo.AssertNumberOfCalls(t, "Update", 2)
+	})
+
+	t.Run("delete removes item amount from order total", func(t *testing.T) {
+		itemRepo := new(MockOrderitemRepository)
+		orderRepo := new(MockOrderRepository)
+		h := handler.NewOrderitemCommandHandler(itemRepo, orderRepo, new(inlineUnitOfWork), new(memoryOutbox), eventbus.NewDispatcher())
+
+		order := entity.NewOrder(uuid.New(), usd("10.00"), "pending")
+		item := entity.NewOrderitem(order.ID, uuid.New(), 1, usd("10.00"))
+
+		itemRepo.On("FindByID", mock.Anything, item.ID).Return(item, nil)
+		itemRepo.On("Delete", mock.Anything, item.ID).Return(nil)
+		orderRepo.On("FindByID", mock.Anything, order.ID).Return(order, nil)
+		orderRepo.On("FindWithItems", mock.Anything, order.ID).Return(order, nil)
+		orderRepo.On("Update", mock.Anything, order).Return(nil)
+
+		err := h.HandleOrderitemDelete(context.Background(), &command.DeleteOrderitemCommand{ID: item.ID})
+
+		assert.NoError(t, err)
+		assert.Equal(t, usd("0.00"), order.Total)
+		orderRepo.AssertExpectations(t)
+	})
+
+	t.Run("delete rejects a total turned negative by the order discount", func(t *testing.T) {
+		itemRepo := new(MockOrderitemRepository)
+		orderRepo := new(MockOrderRepository)
+		h := handler.NewOrderitemCommandHandler(itemRepo, orderRepo, new(inlineUnitOfWork), new(memoryOutbox), eventbus.NewDispatcher())
+
+		order := entity.NewOrder(uuid.New(), usd("0.00"), "pending")
+		order.Discount = usd("5.00")
+		item := entity.NewOrderitem(order.ID, uuid.New(), 1, usd("10.00"))
+
+		itemRepo.On("FindByID", mock.Anything, item.ID).Return(item, nil)
+		itemRepo.On("Delete", mock.Anything, item.ID).Return(nil)
+		orderRepo.On("FindByID", mock.Anything, order.ID).Return(order, nil)
+		orderRepo.On("FindWithItems", mock.Anything, order.ID).Return(order, nil)
+
+		err := h.HandleOrderitemDelete(context.Background(), &command.DeleteOrderitemCommand{ID: item.ID})
+
+		var verr *domain.ValidationError
+		require.ErrorAs(t, err, &verr)
+		assert.Contains(t, verr.Fields, "discount")
+		orderRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+
+	t.Run("create runs every repository call in one unit of work", func(t *testing.T) {
+		itemRepo := new(MockOrderitemRepository)
+		orderRepo := new(MockOrderRepository)
+		uow := new(inlineUnitOfWork)
+		h := handler.NewOrderitemCommandHandler(itemRepo, orderRepo, uow, new(memoryOutbox), eventbus.NewDispatcher())
+
+		order := entity.NewOrder(uuid.New(), usd("0.00"), "pending")
+		cmd := &command.CreateOrderitemCommand{OrderID: order.ID, ProductID: uuid.New(), Quantity: 1, Price: usd("4.00")}
+		tx := mock.MatchedBy(inUnitOfWork)
+
+		orderRepo.On("FindByID", tx, order.ID).Return(order, nil)
+		itemRepo.On("Create", tx, mock.AnythingOfType("*entity.Orderitem")).Return(nil)
+		orderRepo.On("FindWithItems", tx, order.ID).Return(order, nil)
+		orderRepo.On("Update", tx, order).Return(errors.New("write failed"))
+
+		_, err := h.HandleOrderitemCreate(context.Background(), cmd)
+
+		assert.EqualError(t, err, "write failed")
+		assert.Equal(t, 1, uow.calls)
+		itemRepo.AssertExpectations(t)
+		orderRepo.AssertExpectations(t)
+	})
+}
+
+func TestOrderitemHandlers_ParentOrder(t *testing.T) {
+	t.Run("update rejects an item of another order", func(t *testing.T) {
+		itemRepo := new(MockOrderitemRepository)
+		orderRepo := new(MockOrderRepository)
+		h := handler.NewOrderitemCommandHandler(itemRepo, orderRepo, new(inlineUnitOfWork), new(memoryOutbox), eventbus.NewDispatcher())
+
+		item := entity.NewOrderitem(uuid.New(), uuid.New(), 1, usd("10.00"))
+		parentID := uuid.New()
+		cmd := &command.UpdateOrderitemCommand{ID: item.ID, ParentOrderID: parentID, OrderID: parentID, ProductID: item.ProductID, Quantity: 2, Price: usd("10.00")}
+		itemRepo.On("FindByID", mock.Anything, item.ID).Return(item, nil)
+
+		_, err := h.HandleOrderitemUpdate(context.Background(), cmd)
+
+		assert.Equal(t, command.ErrNotFound, err)
+		itemRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+		orderRepo.AssertNotCalled(t, "FindByID", mock.Anything, mock.Anything)
+	})
+
+	t.Run("delete rejects an item of another order", func(t *testing.T) {
+		itemRepo := new(MockOrderitemRepository)
+		orderRepo := new(MockOrderRepository)
+		h := handler.NewOrderitemCommandHandler(itemRepo, orderRepo, new(inlineUnitOfWork), new(memoryOutbox), eventbus.NewDispatcher())
+
+		item := entity.NewOrderitem(uuid.New(), uuid.New(), 1, usd("10.00"))
+		itemRepo.On("FindByID", mock.Anything, item.ID).Return(item, nil)
+
+		err := h.HandleOrderitemDelete(context.Background(), &command.DeleteOrderitemCommand{ID: item.ID, ParentOrderID: uuid.New()})
+
+		assert.Equal(t, command.ErrNotFound, err)
+		itemRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+	})
+
+	t.Run("get by ID returns not found for an item of another order", func(t *testing.T) {
+		itemRepo := new(MockOrderitemRepository)
+		h := handler.NewOrderitemQueryHandler(itemRepo, new(MockOrderRepository), testCursors)
+
+		item := entity.NewOrderitem(uuid.New(), uuid.New(), 1, usd("10.00"))
+		itemRepo.On("FindByID", mock.Anything, item.ID).Return(item, nil)
+
+		_, err := h.HandleOrderitemGetByID(context.Background(), &query.GetOrderitemByIDQuery{ID: item.ID, ParentOrderID: uuid.New()})
+		assert.Equal(t, query.ErrNotFound, err)
+
+		result, err := h.HandleOrderitemGetByID(context.Background(), &query.GetOrderitemByIDQuery{ID: item.ID, ParentOrderID: item.OrderID})
+		require.NoError(t, err)
+		assert.Equal(t, item.ID, result.ID)
+	})
+
+	t.Run("get by order returns not found for an unknown order", func(t *testing.T) {
+		itemRepo := new(MockOrderitemRepository)
+		orderRepo := new(MockOrderRepository)
+		h := handler.NewOrderitemQueryHandler(itemRepo, orderRepo, testCursors)
+
+		orderID := uuid.New()
+		orderRepo.On("FindByID", mock.Anything, orderID).Return(nil, domain.ErrEntityNotFound)
+
+		_, err := h.HandleOrderitemGetByOrder(context.Background(), &query.GetOrderItemsByOrderQuery{OrderID: orderID})
+
+		assert.Equal(t, query.ErrNotFound, err)
+		itemRepo.AssertNotCalled(t, "FindByOrderID", mock.Anything, mock.Anything)
+	})
+}
+
+func TestOrderitemCommandHandler_OrderNotEditable(t *testing.T) {
+	statuses := []string{
+		entity.OrderStatusConfirmed, entity.OrderStatusPaid, entity.OrderStatusShipped,
+		entity.OrderStatusDelivered, entity.OrderStatusCancelled, entity.OrderStatusRefunded,
+	}
+
+	for _, status := range statuses {
+		t.Run(status, func(t *testing.T) {
+			setup := func() (*handler.OrderitemCommandHandler, *MockOrderitemRepository, *MockOrderRepository, *entity.Order, *entity.Orderitem) {
+				itemRepo := new(MockOrderitemRepository)
+				orderRepo := new(MockOrderRepository)
+				h := handler.NewOrderitemCommandHandler(itemRepo, orderRepo, new(inlineUnitOfWork), new(memoryOutbox), eventbus.NewDispatcher())
+
+				order := entity.NewOrder(uuid.New(), usd("10.00"), status)
+				item := entity.NewOrderitem(order.ID, uuid.New(), 1, usd("10.00"))
+				orderRepo.On("FindByID", mock.Anything, order.ID).Return(order, nil)
+				itemRepo.On("FindByID", mock.Anything, item.ID).Return(item, nil)
+				return h, itemRepo, orderRepo, order, item
+			}
+
+			t.Run("create", func(t *testing.T) {
+				h, itemRepo, orderRepo, order, _ := setup()
+
+				_, err := h.HandleOrderitemCreate(context.Background(), &command.CreateOrderitemCommand{OrderID: order.ID, ProductID: uuid.New(), Quantity: 1, Price: usd("5.00")})
+
+				assert.ErrorIs(t, err, domain.ErrEntityConflict)
+				itemRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+				orderRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+			})
+
+			t.Run("update", func(t *testing.T) {
+				h, itemRepo, orderRepo, order, item := setup()
+
+				_, err := h.HandleOrderitemUpdate(context.Background(), &command.UpdateOrderitemCommand{ID: item.ID, OrderID: order.ID, ProductID: item.ProductID, Quantity: 5, Price: usd("1.00")})
+
+				assert.ErrorIs(t, err, domain.ErrEntityConflict)
+				itemRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+				orderRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+			})
+
+			t.Run("move into the order", func(t *testing.T) {
+				h, itemRepo, orderRepo, order, _ := setup()
+				source := entity.NewOrder(uuid.New(), usd("10.00"), entity.OrderStatusPending)
+				moved := entity.NewOrderitem(source.ID, uuid.New(), 1, usd("10.00"))
+				orderRepo.On("FindByID", mock.Anything, source.ID).Return(source, nil)
+				itemRepo.On("FindByID", mock.Anything, moved.ID).Return(moved, nil)
+
+				_, err := h.HandleOrderitemUpdate(context.Background(), &command.UpdateOrderitemCommand{ID: moved.ID, OrderID: order.ID, ProductID: moved.ProductID, Quantity: 1, Price: usd("10.00")})
+
+				assert.ErrorIs(t, err, domain.ErrEntityConflict)
+				itemRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+				orderRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+			})
+
+			t.Run("delete", func(t *testing.T) {
+				h, itemRepo, orderRepo, _, item := setup()
+
+				err := h.HandleOrderitemDelete(context.Background(), &command.DeleteOrderitemCommand{ID: item.ID})
+
+				assert.ErrorIs(t, err, domain.ErrEntityConflict)
+				itemRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+				orderRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+			})
+		})
+	}
+}
+
+// =============================================================================
+// Domain Event Publication Tests
+//
+// Tests that command handlers publish the events raised by the aggregates
+// only after the changes have been persisted.
+// =============================================================================
+
+// MockEventPublisher implements eventbus.Publisher for testing.
+type MockEventPublisher struct {
+	mock.Mock
+}
+
+func (m *MockEventPublisher) Publish(ctx context.Context, events ...event.Event) {
+	m.Called(ctx, events)
+}
+
+// publishedNames returns the names of the events passed to Publish.
+func publishedNames(m *MockEventPublisher) []string {
+	var names []string
+	for _, call := range m.Calls {
+		for _, e := range call.Arguments.Get(1).([]event.Event) {
+			names = append(names, e.EventName())
+		}
+	}
+	return names
+}
+
+// TestCommandHandlers_PublishEvents verifies events are published after commit.
+func TestCommandHandlers_PublishEvents(t *testing.T) {
+	t.Run("create publishes order and item events", func(t *testing.T) {
+		repo := new(MockOrderRepository)
+		itemRepo := new(MockOrderitemRepository)
+		events := new(MockEventPublisher)
+		h := handler.NewOrderCommandHandler(repo, itemRepo, new(inlineUnitOfWork), new(memoryOutbox), events)
+
+		cmd := &command.CreateOrderCommand{
+			CustomerID: uuid.New(),
+			Status:     "pending",
+			Items:      []command.CreateOrderItem{{ProductID: uuid.New(), Quantity: 1, Price: usd("2.00")}},
+		}
+		repo.On("Create", mock.Anything, mock.AnythingOfType("*entity.Order")).Return(nil)
+		itemRepo.On("CreateBatch", mock.Anything, mock.Anything).Return(nil)
+		events.On("Publish", mock.Anything, mock.Anything).Return()
+
+		_, err := h.HandleOrderCreate(context.Background(), cmd)
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{event.OrderCreatedName, event.OrderItemAddedName}, publishedNames(events))
+	})
+
+	t.Run("failed create publishes nothing", func(t *testing.T) {
+		repo := new(MockOrderRepository)
+		events := new(MockEventPublisher)
+		h := handler.NewOrderCommandHandler(repo, new(MockOrderitemRepository), new(inlineUnitOfWork), new(memoryOutbox), events)
+
+		repo.On("Create", mock.Anything, mock.AnythingOfType("*entity.Order")).Return(errors.New("database error"))
+
+		_, err := h.HandleOrderCreate(context.Background(), &command.CreateOrderCommand{CustomerID: uuid.New(), Status: "pending"})
+
+		assert.Error(t, err)
+		events.AssertNotCalled(t, "Publish", mock.Anything, mock.Anything)
+	})
+
+	t.Run("cancel publishes status change and cancellation", func(t *testing.T) {
+		repo := new(MockOrderRepository)
+		events := new(MockEventPublisher)
+		h := handler.NewOrderCommandHandler(repo, new(MockOrderitemRepository), new(inlineUnitOfWork), new(memoryOutbox), events)
+
+		order := entity.NewOrder(uuid.New(), usd("0.00"), "pending")
+		order.PullEvents()
+		repo.On("FindByID", mock.Anything, order.ID).Return(order, nil)
+		repo.On("Update", mock.Anything, order).Return(nil)
+		events.On("Publish", mock.Anything, mock.Anything).Return()
+
+		err := h.HandleOrderCancel(context.Background(), &command.CancelOrderCommand{ID: order.ID})
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{event.OrderStatusChangedName, event.OrderCancelledName}, publishedNames(events))
+	})
+
+	t.Run("failed update publishes nothing", func(t *testing.T) {
+		repo := new(MockOrderRepository)
+		events := new(MockEventPublisher)
+		h := handler.NewOrderCommandHandler(repo, new(MockOrderitemRepository), new(inlineUnitOfWork), new(memoryOutbox), events)
+
+		order := entity.NewOrder(uuid.New(), usd("0.00"), "pending")
+		repo.On("FindByID", mock.Anything, order.ID).Return(order, nil)
+		repo.On("Update", mock.Anything, order).Return(errors.New("database error"))
+
+		err := h.HandleOrderConfirm(context.Background(), &command.ConfirmOrderCommand{ID: order.ID})
+
+		assert.Error(t, err)
+		events.AssertNotCalled(t, "Publish", mock.Anything, mock.Anything)
+	})
+
+	t.Run("item delete publishes OrderItemRemoved", func(t *testing.T) {
+		itemRepo := new(MockOrderitemRepository)
+		orderRepo := new(MockOrderRepository)
+		events := new(MockEventPublisher)
+		h := handler.NewOrderitemCommandHandler(itemRepo, orderRepo, new(inlineUnitOfWork), new(memoryOutbox), events)
+
+		order := entity.NewOrder(uuid.New(), usd("0.00"), "pending")
+		item := entity.NewOrderitem(order.ID, uuid.New(), 1, usd("1.00"))
+		item.PullEvents()
+
+		itemRepo.On("FindByID", mock.Anything, item.ID).Return(item, nil)
+		itemRepo.On("Delete", mock.Anything, item.ID).Return(nil)
+		orderRepo.On("FindByID", mock.Anything, order.ID).Return(order, nil)
+		orderRepo.On("FindWithItems", mock.Anything, order.ID).Return(order, nil)
+		orderRepo.On("Update", mock.Anything, order).Return(nil)
+		events.On("Publish", mock.Anything, mock.Anything).Return()
+
+		err := h.HandleOrderitemDelete(context.Background(), &command.DeleteOrderitemCommand{ID: item.ID})
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{event.OrderItemRemovedName}, publishedNames(events))
+	})
+}
+
+// TestCommandHandlers_Version verifies expected versions are checked before changes.
+func TestCommandHandlers_Version(t *testing.T) {
+	t.Run("order update with stale version fails the precondition", func(t *testing.T) {
+		repo := new(MockOrderRepository)
+		h := handler.NewOrderCommandHandler(repo, new(MockOrderitemRepository), new(inlineUnitOfWork), new(memoryOutbox), eventbus.NewDispatcher())
+
+		order := entity.NewOrder(uuid.New(), usd("0.00"), "pending")
+		order.Version = 5
+		repo.On("FindWithItems", mock.Anything, order.ID).Return(order, nil)
+
+		_, err := h.HandleOrderUpdate(context.Background(), &command.UpdateOrderCommand{
+			ID: order.ID, Version: 4, CustomerID: order.CustomerID, Status: "pending",
+		})
+
+		assert.ErrorIs(t, err, command.ErrPreconditionFailed)
+		repo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+
+	t.Run("order delete with stale version fails the precondition", func(t *testing.T) {
+		repo := new(MockOrderRepository)
+		h := handler.NewOrderCommandHandler(repo, new(MockOrderitemRepository), new(inlineUnitOfWork), new(memoryOutbox), eventbus.NewDispatcher())
+
+		order := entity.NewOrder(uuid.New(), usd("0.00"), "pending")
+		order.Version = 3
+		repo.On("FindByID", mock.Anything, order.ID).Return(order, nil)
+
+		err := h.HandleOrderDelete(context.Background(), &command.DeleteOrderCommand{ID: order.ID, Version: 2})
+
+		assert.ErrorIs(t, err, command.ErrPreconditionFailed)
+		repo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+	})
+
+	t.Run("order delete of missing order returns not found", func(t *testing.T) {
+		repo := new(MockOrderRepository)
+		h := handler.NewOrderCommandHandler(repo, new(MockOrderitemRepository), new(inlineUnitOfWork), new(memoryOutbox), eventbus.NewDispatcher())
+
+		id := uuid.New()
+		repo.On("FindByID", mock.Anything, id).Return(nil, domain.ErrEntityNotFound)
+
+		err := h.HandleOrderDelete(context.Background(), &command.DeleteOrderCommand{ID: id, Version: 1})
+
+		assert.ErrorIs(t, err, command.ErrNotFound)
+	})
+
+	t.Run("item update with stale version fails the precondition", func(t *testing.T) {
+		itemRepo := new(MockOrderitemRepository)
+		h := handler.NewOrderitemCommandHandler(itemRepo, new(MockOrderRepository), new(inlineUnitOfWork), new(memoryOutbox), eventbus.NewDispatcher())
+
+		item := entity.NewOrderitem(uuid.New(), uuid.New(), 1, usd("1.00"))
+		item.Version = 2
+		itemRepo.On("FindByID", mock.Anything, item.ID).Return(item, nil)
+
+		_, err := h.HandleOrderitemUpdate(context.Background(), &command.UpdateOrderitemCommand{
+			ID: item.ID, Version: 1, OrderID: item.OrderID, ProductID: item.ProductID, Quantity: 2,
+		})
+
+		assert.ErrorIs(t, err, command.ErrPreconditionFailed)
+		itemRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+
+	t.Run("item delete with stale version fails the precondition", func(t *testing.T) {
+		itemRepo := new(MockOrderitemRepository)
+		h := handler.NewOrderitemCommandHandler(itemRepo, new(MockOrderRepository), new(inlineUnitOfWork), new(memoryOutbox), eventbus.NewDispatcher())
+
+		item := entity.NewOrderitem(uuid.New(), uuid.New(), 1, usd("1.00"))
+		itemRepo.On("FindByID", mock.Anything, item.ID).Return(item, nil)
+
+		err := h.HandleOrderitemDelete(context.Background(), &command.DeleteOrderitemCommand{ID: item.ID, Version: 7})
+
+		assert.ErrorIs(t, err, command.ErrPreconditionFailed)
+		itemRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+	})
+}
+
+// TestCommandHandlers_Outbox verifies events are stored in the outbox within the unit of work.
+func TestCommandHandlers_Outbox(t *testing.T) {
+	t.Run("create appends events in the same transaction", func(t *testing.T) {
+		repo := new(MockOrderRepository)
+		itemRepo := new(MockOrderitemRepository)
+		outbox := new(memoryOutbox)
+		h := handler.NewOrderCommandHandler(repo, itemRepo, new(inlineUnitOfWork), outbox, eventbus.NewDispatcher())
+
+		cmd := &command.CreateOrderCommand{
+			CustomerID: uuid.New(),
+			Status:     "pending",
+			Items:      []command.CreateOrderItem{{ProductID: uuid.New(), Quantity: 2, Price: usd("3.00")}},
+		}
+		repo.On("Create", mock.MatchedBy(inUnitOfWork), mock.AnythingOfType("*entity.Order")).Return(nil)
+		itemRepo.On("CreateBatch", mock.MatchedBy(inUnitOfWork), mock.Anything).Return(nil)
+
+		_, err := h.HandleOrderCreate(context.Background(), cmd)
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{event.OrderCreatedName, event.OrderItemAddedName}, outbox.names())
+		assert.Zero(t, outbox.outsideTx)
+	})
+
+	t.Run("transition appends events in the same transaction", func(t *testing.T) {
+		repo := new(MockOrderRepository)
+		outbox := new(memoryOutbox)
+		uow := new(inlineUnitOfWork)
+		h := handler.NewOrderCommandHandler(repo, new(MockOrderitemRepository), uow, outbox, eventbus.NewDispatcher())
+
+		order := entity.NewOrder(uuid.New(), usd("0.00"), "pending")
+		order.PullEvents()
+		repo.On("FindByID", mock.Anything, order.ID).Return(order, nil)
+		repo.On("Update", mock.MatchedBy(inUnitOfWork), order).Return(nil)
+
+		err := h.HandleOrderConfirm(context.Background(), &command.ConfirmOrderCommand{ID: order.ID})
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, uow.calls)
+		assert.Equal(t, []string{event.OrderStatusChangedName}, outbox.names())
+		assert.Zero(t, outbox.outsideTx)
+	})
+
+	t.Run("delete appends events in the same transaction", func(t *testing.T) {
+		repo := new(MockOrderRepository)
+		outbox := new(memoryOutbox)
+		h := handler.NewOrderCommandHandler(repo, new(MockOrderitemRepository), new(inlineUnitOfWork), outbox, eventbus.NewDispatcher())
+
+		order := entity.NewOrder(uuid.New(), usd("0.00"), "pending")
+		order.PullEvents()
+		repo.On("FindByID", mock.Anything, order.ID).Return(order, nil)
+		repo.On("Delete", mock.MatchedBy(inUnitOfWork), order.ID).Return(nil)
+
+		err := h.HandleOrderDelete(context.Background(), &command.DeleteOrderCommand{ID: order.ID})
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{event.OrderDeletedName}, outbox.names())
+		assert.Zero(t, outbox.outsideTx)
+	})
+
+	t.Run("item update appends events in the same transaction", func(t *testing.T) {
+		itemRepo := new(MockOrderitemRepository)
+		orderRepo := new(MockOrderRepository)
+		outbox := new(memoryOutbox)
+		h := handler.NewOrderitemCommandHandler(itemRepo, orderRepo, new(inlineUnitOfWork), outbox, eventbus.NewDispatcher())
+
+		order := entity.NewOrder(uuid.New(), usd("0.00"), "pending")
+		item := entity.NewOrderitem(order.ID, uuid.New(), 1, usd("1.00"))
+		item.PullEvents()
+
+		itemRepo.On("FindByID", mock.Anything, item.ID).Return(item, nil)
+		itemRepo.On("Update", mock.Anything, item).Return(nil)
+		orderRepo.On("FindByID", mock.Anything, order.ID).Return(order, nil)
+		orderRepo.On("FindWithItems", mock.Anything, order.ID).Return(order, nil)
+		orderRepo.On("Update", mock.Anything, order).Return(nil)
+
+		_, err := h.HandleOrderitemUpdate(context.Background(), &command.UpdateOrderitemCommand{
+			ID: item.ID, OrderID: order.ID, ProductID: item.ProductID, Quantity: 3, Price: usd("1.00"),
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{event.OrderItemUpdatedName}, outbox.names())
+		assert.Zero(t, outbox.outsideTx)
+	})
+
+	t.Run("outbox failure fails the command and publishes nothing", func(t *testing.T) {
+		repo := new(MockOrderRepository)
+		events := new(MockEventPublisher)
+		outbox := &memoryOutbox{err: errors.New("outbox unavailable")}
+		h := handler.NewOrderCommandHandler(repo, new(MockOrderitemRepository), new(inlineUnitOfWork), outbox, events)
+
+		repo.On("Create", mock.Anything, mock.AnythingOfType("*entity.Order")).Return(nil)
+
+		_, err := h.HandleOrderCreate(context.Background(), &command.CreateOrderCommand{CustomerID: uuid.New(), Status: "pending"})
+
+		assert.EqualError(t, err, "outbox unavailable")
+		events.AssertNotCalled(t, "Publish", mock.Anything, mock.Anything)
+	})
 }
 
 // =============================================================================
@@ -251,7 +1333,7 @@ func TestOrderCommandHandler_HandleOrderDelete(t *testing.T) {
 func TestNewOrderQueryHandler(t *testing.T) {
 	t.Run("creates handler with repository", func(t *testing.T) {
 		repo := new(MockOrderRepository)
-		h := handler.NewOrderQueryHandler(repo)
+		h := handler.NewOrderQueryHandler(repo, testCursors)
 
 		require.NotNil(t, h)
 	})
@@ -260,11 +1342,11 @@ func TestNewOrderQueryHandler(t *testing.T) {
 func TestOrderQueryHandler_HandleOrderGetByID(t *testing.T) {
 	t.Run("successfully gets order by ID", func(t *testing.T) {
 		repo := new(MockOrderRepository)
-		h := handler.NewOrderQueryHandler(repo)
+		h := handler.NewOrderQueryHandler(repo, testCursors)
 
 		orderID := uuid.New()
 		customerID := uuid.New()
-		expectedOrder := entity.NewOrder(customerID, 100.0, "pending")
+		expectedOrder := entity.NewOrder(customerID, usd("100.00"), "pending")
 		expectedOrder.ID = orderID
 
 		qry := &query.GetOrderByIDQuery{ID: orderID}
@@ -277,43 +1359,65 @@ func TestOrderQueryHandler_HandleOrderGetByID(t *testing.T) {
 		require.NotNil(t, result)
 		assert.Equal(t, orderID, result.ID)
 		assert.Equal(t, customerID, result.CustomerID)
-		assert.Equal(t, 100.0, result.Total)
+		assert.Equal(t, usd("100.00"), result.Total)
 		assert.Equal(t, "pending", result.Status)
 		repo.AssertExpectations(t)
 	})
 
 	t.Run("returns error when order not found", func(t *testing.T) {
 		repo := new(MockOrderRepository)
-		h := handler.NewOrderQueryHandler(repo)
+		h := handler.NewOrderQueryHandler(repo, testCursors)
+
+		orderID := uuid.New()
+		qry := &query.GetOrderByIDQuery{ID: orderID}
+
+		repo.On("FindByID", mock.Anything, orderID).Return(nil, fmt.Errorf("%w: order", domain.ErrEntityNotFound))
+
+		result, err := h.HandleOrderGetByID(context.Background(), qry)
+
+		assert.Nil(t, result)
+		assert.Equal(t, query.ErrNotFound, err)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("returns repository error unchanged", func(t *testing.T) {
+		repo := new(MockOrderRepository)
+		h := handler.NewOrderQueryHandler(repo, testCursors)
 
 		orderID := uuid.New()
 		qry := &query.GetOrderByIDQuery{ID: orderID}
 
-		expectedErr := errors.New("order not found")
+		expectedErr := errors.New("connection refused")
 		repo.On("FindByID", mock.Anything, orderID).Return(nil, expectedErr)
 
 		result, err := h.HandleOrderGetByID(context.Background(), qry)
 
-		assert.Error(t, err)
 		assert.Nil(t, result)
 		assert.Equal(t, expectedErr, err)
 		repo.AssertExpectations(t)
 	})
 }
 
+// pageFilter matches an order filter requesting the given page
+func pageFilter(offset, limit int) interface{} {
+	return mock.MatchedBy(func(f repository.OrderFilter) bool {
+		return f.Offset == offset && f.Limit == limit
+	})
+}
+
 func TestOrderQueryHandler_HandleOrderGetAll(t *testing.T) {
 	t.Run("successfully gets all orders", func(t *testing.T) {
 		repo := new(MockOrderRepository)
-		h := handler.NewOrderQueryHandler(repo)
+		h := handler.NewOrderQueryHandler(repo, testCursors)
 
 		orders := []entity.Order{
-			*entity.NewOrder(uuid.New(), 100.0, "pending"),
-			*entity.NewOrder(uuid.New(), 200.0, "confirmed"),
+			*entity.NewOrder(uuid.New(), usd("100.00"), "pending"),
+			*entity.NewOrder(uuid.New(), usd("200.00"), "confirmed"),
 		}
 
 		qry := &query.GetAllOrdersQuery{Offset: 0, Limit: 10}
 
-		repo.On("FindAll", mock.Anything, 0, 10).Return(orders, int64(2), nil)
+		repo.On("List", mock.Anything, pageFilter(0, 10)).Return(orders, int64(2), nil)
 
 		result, err := h.HandleOrderGetAll(context.Background(), qry)
 
@@ -328,11 +1432,11 @@ func TestOrderQueryHandler_HandleOrderGetAll(t *testing.T) {
 
 	t.Run("returns empty list when no orders", func(t *testing.T) {
 		repo := new(MockOrderRepository)
-		h := handler.NewOrderQueryHandler(repo)
+		h := handler.NewOrderQueryHandler(repo, testCursors)
 
 		qry := &query.GetAllOrdersQuery{Offset: 0, Limit: 10}
 
-		repo.On("FindAll", mock.Anything, 0, 10).Return([]entity.Order{}, int64(0), nil)
+		repo.On("List", mock.Anything, pageFilter(0, 10)).Return([]entity.Order{}, int64(0), nil)
 
 		result, err := h.HandleOrderGetAll(context.Background(), qry)
 
@@ -345,12 +1449,12 @@ func TestOrderQueryHandler_HandleOrderGetAll(t *testing.T) {
 
 	t.Run("returns error on repository failure", func(t *testing.T) {
 		repo := new(MockOrderRepository)
-		h := handler.NewOrderQueryHandler(repo)
+		h := handler.NewOrderQueryHandler(repo, testCursors)
 
 		qry := &query.GetAllOrdersQuery{Offset: 0, Limit: 10}
 
 		expectedErr := errors.New("database error")
-		repo.On("FindAll", mock.Anything, 0, 10).Return(nil, int64(0), expectedErr)
+		repo.On("List", mock.Anything, pageFilter(0, 10)).Return(nil, int64(0), expectedErr)
 
 		result, err := h.HandleOrderGetAll(context.Background(), qry)
 
@@ -362,15 +1466,15 @@ func TestOrderQueryHandler_HandleOrderGetAll(t *testing.T) {
 
 	t.Run("handles pagination correctly", func(t *testing.T) {
 		repo := new(MockOrderRepository)
-		h := handler.NewOrderQueryHandler(repo)
+		h := handler.NewOrderQueryHandler(repo, testCursors)
 
 		orders := []entity.Order{
-			*entity.NewOrder(uuid.New(), 300.0, "shipped"),
+			*entity.NewOrder(uuid.New(), usd("300.00"), "shipped"),
 		}
 
 		qry := &query.GetAllOrdersQuery{Offset: 10, Limit: 5}
 
-		repo.On("FindAll", mock.Anything, 10, 5).Return(orders, int64(15), nil)
+		repo.On("List", mock.Anything, pageFilter(10, 5)).Return(orders, int64(15), nil)
 
 		result, err := h.HandleOrderGetAll(context.Background(), qry)
 
@@ -384,6 +1488,188 @@ func TestOrderQueryHandler_HandleOrderGetAll(t *testing.T) {
 	})
 }
 
+func TestOrderQueryHandler_HandleOrderSearch(t *testing.T) {
+	t.Run("returns ranked hits with highlights", func(t *testing.T) {
+		repo := new(MockOrderRepository)
+		h := handler.NewOrderQueryHandler(repo, testCursors)
+
+		order := entity.NewOrder(uuid.New(), usd("10.00"), "pending")
+		order.Annotate([]string{"vip"}, "")
+		hits := []repository.OrderSearchHit{{
+			Order:      *order,
+			Rank:       0.5,
+			Highlights: map[string]string{repository.OrderSearchFieldTags: "<mark>vip</mark>"},
+		}}
+		repo.On("Search", mock.Anything, "vip", 20, 10).Return(hits, int64(21), nil)
+
+		result, err := h.HandleOrderSearch(context.Background(), &query.SearchOrdersQuery{Query: "vip", Offset: 20, Limit: 10})
+
+		require.NoError(t, err)
+		require.Len(t, result.Data, 1)
+		assert.Equal(t, order.ID, result.Data[0].ID)
+		assert.Equal(t, []string{"vip"}, result.Data[0].Tags)
+		assert.Equal(t, 0.5, result.Data[0].Rank)
+		assert.Equal(t, "<mark>vip</mark>", result.Data[0].Highlights["tags"])
+		assert.Equal(t, 21, result.Total)
+		assert.Equal(t, 20, result.Offset)
+		assert.Equal(t, 10, result.Limit)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("returns error on repository failure", func(t *testing.T) {
+		repo := new(MockOrderRepository)
+		h := handler.NewOrderQueryHandler(repo, testCursors)
+
+		expectedErr := errors.New("database error")
+		repo.On("Search", mock.Anything, "vip", 0, 10).Return(nil, int64(0), expectedErr)
+
+		result, err := h.HandleOrderSearch(context.Background(), &query.SearchOrdersQuery{Query: "vip", Limit: 10})
+
+		assert.Nil(t, result)
+		assert.Equal(t, expectedErr, err)
+	})
+}
+
+func TestOrderQueryHandler_HandleOrderGetPage(t *testing.T) {
+	// orderAt returns an order created at the given minute past midnight
+	orderAt := func(minute int) entity.Order {
+		o := entity.NewOrder(uuid.New(), usd("10.00"), "pending")
+		o.CreatedAt = time.Date(2026, 1, 1, 0, minute, 0, 0, time.UTC)
+		return *o
+	}
+	pageQuery := func(cursor string) *query.GetAllOrdersQuery {
+		qry := &query.GetAllOrdersQuery{Cursor: &cursor, Limit: 2}
+		require.NoError(t, qry.Validate())
+		return qry
+	}
+	cursorOf := func(o entity.Order, backward bool) string {
+		scope := pageQuery("").CursorScope()
+		return testCursors.Encode(pagination.Cursor{CreatedAt: o.CreatedAt, ID: o.ID, Backward: backward, Scope: scope})
+	}
+
+	t.Run("first page issues only a next cursor", func(t *testing.T) {
+		repo := new(MockOrderRepository)
+		h := handler.NewOrderQueryHandler(repo, testCursors)
+		orders := []entity.Order{orderAt(3), orderAt(2)}
+
+		repo.On("FindPage", mock.Anything, mock.Anything, repository.KeysetPage{Limit: 2, Direction: repository.SortDesc}).
+			Return(orders, true, nil)
+
+		result, err := h.HandleOrderGetPage(context.Background(), pageQuery(""))
+
+		require.NoError(t, err)
+		assert.Len(t, result.Data, 2)
+		assert.Equal(t, 2, result.Limit)
+		assert.Equal(t, cursorOf(orders[1], false), result.NextCursor)
+		assert.Empty(t, result.PrevCursor)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("next cursor continues after the last row", func(t *testing.T) {
+		repo := new(MockOrderRepository)
+		h := handler.NewOrderQueryHandler(repo, testCursors)
+		last := orderAt(2)
+		orders := []entity.Order{orderAt(1)}
+
+		repo.On("FindPage", mock.Anything, mock.Anything, mock.MatchedBy(func(p repository.KeysetPage) bool {
+			return p.Before == nil && p.After != nil && p.After.ID == last.ID && p.After.CreatedAt.Equal(last.CreatedAt)
+		})).Return(orders, false, nil)
+
+		result, err := h.HandleOrderGetPage(context.Background(), pageQuery(cursorOf(last, false)))
+
+		require.NoError(t, err)
+		assert.Empty(t, result.NextCursor)
+		assert.Equal(t, cursorOf(orders[0], true), result.PrevCursor)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("previous cursor pages backwards", func(t *testing.T) {
+		repo := new(MockOrderRepository)
+		h := handler.NewOrderQueryHandler(repo, testCursors)
+		first := orderAt(1)
+		orders := []entity.Order{orderAt(3), orderAt(2)}
+
+		repo.On("FindPage", mock.Anything, mock.Anything, mock.MatchedBy(func(p repository.KeysetPage) bool {
+			return p.After == nil && p.Before != nil && p.Before.ID == first.ID
+		})).Return(orders, false, nil)
+
+		result, err := h.HandleOrderGetPage(context.Background(), pageQuery(cursorOf(first, true)))
+
+		require.NoError(t, err)
+		assert.Equal(t, cursorOf(orders[1], false), result.NextCursor)
+		assert.Empty(t, result.PrevCursor, "no rows precede the first page")
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("rejects invalid cursor", func(t *testing.T) {
+		repo := new(MockOrderRepository)
+		h := handler.NewOrderQueryHandler(repo, testCursors)
+		forged := pagination.NewCodec("other").Encode(pagination.Cursor{CreatedAt: time.Now(), ID: uuid.New()})
+
+		result, err := h.HandleOrderGetPage(context.Background(), pageQuery(forged))
+
+		assert.Nil(t, result)
+		assert.Equal(t, query.ErrInvalidCursor, err)
+		repo.AssertNotCalled(t, "FindPage", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("rejects a cursor issued for another sort or filter", func(t *testing.T) {
+		customerID := uuid.New()
+		minTotal := usd("5")
+		for name, change := range map[string]func(*query.GetAllOrdersQuery){
+			"sort direction": func(q *query.GetAllOrdersQuery) { q.SortDir = "asc" },
+			"status":         func(q *query.GetAllOrdersQuery) { q.Status = []string{"pending"} },
+			"customer":       func(q *query.GetAllOrdersQuery) { q.CustomerID = &customerID },
+			"total range":    func(q *query.GetAllOrdersQuery) { q.MinTotal = &minTotal },
+			"search":         func(q *query.GetAllOrdersQuery) { q.Search = "rush" },
+		} {
+			t.Run(name, func(t *testing.T) {
+				repo := new(MockOrderRepository)
+				h := handler.NewOrderQueryHandler(repo, testCursors)
+				qry := pageQuery(cursorOf(orderAt(1), false))
+				change(qry)
+				require.NoError(t, qry.Validate())
+
+				result, err := h.HandleOrderGetPage(context.Background(), qry)
+
+				assert.Nil(t, result)
+				assert.Equal(t, query.ErrInvalidCursor, err)
+				repo.AssertNotCalled(t, "FindPage", mock.Anything, mock.Anything, mock.Anything)
+			})
+		}
+	})
+
+	t.Run("accepts a cursor for the same filter in another order", func(t *testing.T) {
+		repo := new(MockOrderRepository)
+		h := handler.NewOrderQueryHandler(repo, testCursors)
+		issuedFor := &query.GetAllOrdersQuery{Status: []string{"pending,confirmed"}, Search: "rush"}
+		require.NoError(t, issuedFor.Validate())
+		token := testCursors.Encode(pagination.Cursor{CreatedAt: time.Now(), ID: uuid.New(), Scope: issuedFor.CursorScope()})
+
+		qry := &query.GetAllOrdersQuery{Cursor: &token, Status: []string{"confirmed", "pending"}, Search: " rush "}
+		require.NoError(t, qry.Validate())
+		repo.On("FindPage", mock.Anything, mock.Anything, mock.Anything).Return([]entity.Order{}, false, nil)
+
+		_, err := h.HandleOrderGetPage(context.Background(), qry)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("empty page has no cursors", func(t *testing.T) {
+		repo := new(MockOrderRepository)
+		h := handler.NewOrderQueryHandler(repo, testCursors)
+
+		repo.On("FindPage", mock.Anything, mock.Anything, mock.Anything).Return([]entity.Order{}, false, nil)
+
+		result, err := h.HandleOrderGetPage(context.Background(), pageQuery(cursorOf(orderAt(0), false)))
+
+		require.NoError(t, err)
+		assert.Empty(t, result.Data)
+		assert.Empty(t, result.NextCursor)
+		assert.Empty(t, result.PrevCursor)
+	})
+}
+
 // =============================================================================
 // Integration-style Handler Tests
 //
@@ -395,8 +1681,8 @@ func TestOrderQueryHandler_HandleOrderGetAll(t *testing.T) {
 func TestOrderHandler_FullWorkflow(t *testing.T) {
 	t.Run("create, read, update, delete workflow", func(t *testing.T) {
 		repo := new(MockOrderRepository)
-		cmdHandler := handler.NewOrderCommandHandler(repo)
-		qryHandler := handler.NewOrderQueryHandler(repo)
+		cmdHandler := handler.NewOrderCommandHandler(repo, new(MockOrderitemRepository), new(inlineUnitOfWork), new(memoryOutbox), eventbus.NewDispatcher())
+		qryHandler := handler.NewOrderQueryHandler(repo, testCursors)
 
 		orderID := uuid.New()
 		customerID := uuid.New()
@@ -404,16 +1690,17 @@ func TestOrderHandler_FullWorkflow(t *testing.T) {
 		// Create
 		createCmd := &command.CreateOrderCommand{
 			CustomerID: customerID,
-			Total:      100.0,
+			Shipping:   usd("10.00"),
+			Total:      usdPtr("10.00"),
 			Status:     "pending",
 		}
 		repo.On("Create", mock.Anything, mock.AnythingOfType("*entity.Order")).Return(nil).Once()
 
-		err := cmdHandler.HandleOrderCreate(context.Background(), createCmd)
+		_, err := cmdHandler.HandleOrderCreate(context.Background(), createCmd)
 		assert.NoError(t, err)
 
 		// Read
-		order := entity.NewOrder(customerID, 100.0, "pending")
+		order := entity.NewOrder(customerID, usd("100.00"), "pending")
 		order.ID = orderID
 		repo.On("FindByID", mock.Anything, orderID).Return(order, nil).Once()
 
@@ -423,19 +1710,21 @@ func TestOrderHandler_FullWorkflow(t *testing.T) {
 		assert.NotNil(t, result)
 
 		// Update
+		repo.On("FindWithItems", mock.Anything, orderID).Return(order, nil).Once()
 		updateCmd := &command.UpdateOrderCommand{
 			ID:         orderID,
 			CustomerID: customerID,
-			Total:      150.0,
+			Shipping:   usd("15.00"),
 			Status:     "confirmed",
 		}
 		repo.On("Update", mock.Anything, mock.AnythingOfType("*entity.Order")).Return(nil).Once()
 
-		err = cmdHandler.HandleOrderUpdate(context.Background(), updateCmd)
+		_, err = cmdHandler.HandleOrderUpdate(context.Background(), updateCmd)
 		assert.NoError(t, err)
 
 		// Delete
 		deleteCmd := &command.DeleteOrderCommand{ID: orderID}
+		repo.On("FindByID", mock.Anything, orderID).Return(order, nil).Once()
 		repo.On("Delete", mock.Anything, orderID).Return(nil).Once()
 
 		err = cmdHandler.HandleOrderDelete(context.Background(), deleteCmd)
@@ -455,11 +1744,12 @@ func TestOrderHandler_FullWorkflow(t *testing.T) {
 // BenchmarkOrderCommandHandler_HandleOrderCreate measures create handler performance.
 func BenchmarkOrderCommandHandler_HandleOrderCreate(b *testing.B) {
 	repo := new(MockOrderRepository)
-	h := handler.NewOrderCommandHandler(repo)
+	h := handler.NewOrderCommandHandler(repo, new(MockOrderitemRepository), new(inlineUnitOfWork), new(memoryOutbox), eventbus.NewDispatcher())
 
 	cmd := &command.CreateOrderCommand{
 		CustomerID: uuid.New(),
-		Total:      100.0,
+		Shipping:   usd("10.00"),
+		Total:      usdPtr("10.00"),
 		Status:     "pending",
 	}
 
@@ -467,16 +1757,16 @@ func BenchmarkOrderCommandHandler_HandleOrderCreate(b *testing.B) {
 
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
-		_ = h.HandleOrderCreate(context.Background(), cmd)
+		_, _ = h.HandleOrderCreate(context.Background(), cmd)
 	}
 }
 
 func BenchmarkOrderQueryHandler_HandleOrderGetByID(b *testing.B) {
 	repo := new(MockOrderRepository)
-	h := handler.NewOrderQueryHandler(repo)
+	h := handler.NewOrderQueryHandler(repo, testCursors)
 
 	orderID := uuid.New()
-	order := entity.NewOrder(uuid.New(), 100.0, "pending")
+	order := entity.NewOrder(uuid.New(), usd("100.00"), "pending")
 	order.ID = orderID
 
 	qry := &query.GetOrderByIDQuery{ID: orderID}
@@ -7,9 +7,8 @@
 // # Test Coverage
 //
 // The tests cover the following queries:
-//   - GetOrderByIDQuery: Single order retrieval by UUID
-//   - ListOrdersQuery: Paginated order listing with sorting
-//   - GetAllOrdersQuery: Offset/limit based order retrieval
+//   - GetOrderByIDQuery: Single order retrieval by UUID, with expand
+//   - GetAllOrdersQuery: Offset/limit based order retrieval with filters
 //   - SearchOrdersQuery: Full-text search with pagination
 //
 // # Validation Behavior
@@ -23,10 +22,14 @@ package query_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/telemetryflow/order-service/internal/application/query"
+	"github.com/telemetryflow/order-service/internal/domain"
+	"github.com/telemetryflow/order-service/internal/domain/repository"
 )
 
 // =============================================================================
@@ -67,163 +70,32 @@ func TestGetOrderByIDQuery_Validate(t *testing.T) {
 	}
 }
 
-// =============================================================================
-// ListOrdersQuery Tests
-//
-// Tests for paginated order listing with sorting and page size validation.
-// =============================================================================
+// TestGetOrderByIDQuery_Expand verifies the relations expand can load.
+func TestGetOrderByIDQuery_Expand(t *testing.T) {
+	t.Run("items loads the order items", func(t *testing.T) {
+		q := &query.GetOrderByIDQuery{ID: uuid.New(), Expand: " items, "}
+		require.NoError(t, q.Validate())
+		assert.True(t, q.WithItems)
+	})
 
-// TestListOrdersQuery_Validate verifies pagination and sorting validation.
-func TestListOrdersQuery_Validate(t *testing.T) {
-	tests := []struct {
-		name             string
-		query            *query.ListOrdersQuery
-		expectedPage     int
-		expectedPageSize int
-		expectedSortDir  string
-		expectedSortBy   string
-	}{
-		{
-			name: "valid query",
-			query: &query.ListOrdersQuery{
-				Page:     1,
-				PageSize: 20,
-				SortDir:  "asc",
-				SortBy:   "total",
-			},
-			expectedPage:     1,
-			expectedPageSize: 20,
-			expectedSortDir:  "asc",
-			expectedSortBy:   "total",
-		},
-		{
-			name:             "defaults for invalid page",
-			query:            &query.ListOrdersQuery{Page: 0},
-			expectedPage:     1,
-			expectedPageSize: 10,
-			expectedSortDir:  "desc",
-			expectedSortBy:   "created_at",
-		},
-		{
-			name:             "defaults for negative page",
-			query:            &query.ListOrdersQuery{Page: -5},
-			expectedPage:     1,
-			expectedPageSize: 10,
-			expectedSortDir:  "desc",
-			expectedSortBy:   "created_at",
-		},
-		{
-			name:             "defaults for invalid page size (0)",
-			query:            &query.ListOrdersQuery{PageSize: 0},
-			expectedPage:     1,
-			expectedPageSize: 10,
-			expectedSortDir:  "desc",
-			expectedSortBy:   "created_at",
-		},
-		{
-			name:             "defaults for page size over limit",
-			query:            &query.ListOrdersQuery{PageSize: 200},
-			expectedPage:     1,
-			expectedPageSize: 10,
-			expectedSortDir:  "desc",
-			expectedSortBy:   "created_at",
-		},
-		{
-			name:             "defaults for invalid sort direction",
-			query:            &query.ListOrdersQuery{SortDir: "invalid"},
-			expectedPage:     1,
-			expectedPageSize: 10,
-			expectedSortDir:  "desc",
-			expectedSortBy:   "created_at",
-		},
-		{
-			name:             "defaults for empty sort by",
-			query:            &query.ListOrdersQuery{SortBy: ""},
-			expectedPage:     1,
-			expectedPageSize: 10,
-			expectedSortDir:  "desc",
-			expectedSortBy:   "created_at",
-		},
-		{
-			name: "asc sort direction",
-			query: &query.ListOrdersQuery{
-				Page:     1,
-				PageSize: 10,
-				SortDir:  "asc",
-			},
-			expectedPage:     1,
-			expectedPageSize: 10,
-			expectedSortDir:  "asc",
-			expectedSortBy:   "created_at",
-		},
-		{
-			name: "desc sort direction",
-			query: &query.ListOrdersQuery{
-				Page:     1,
-				PageSize: 10,
-				SortDir:  "desc",
-			},
-			expectedPage:     1,
-			expectedPageSize: 10,
-			expectedSortDir:  "desc",
-			expectedSortBy:   "created_at",
-		},
-	}
+	t.Run("empty expand loads the order only", func(t *testing.T) {
+		q := &query.GetOrderByIDQuery{ID: uuid.New()}
+		require.NoError(t, q.Validate())
+		assert.False(t, q.WithItems)
+	})
 
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			err := tt.query.Validate()
-			assert.NoError(t, err)
-			assert.Equal(t, tt.expectedPage, tt.query.Page)
-			assert.Equal(t, tt.expectedPageSize, tt.query.PageSize)
-			assert.Equal(t, tt.expectedSortDir, tt.query.SortDir)
-			assert.Equal(t, tt.expectedSortBy, tt.query.SortBy)
-		})
-	}
+	t.Run("unsupported relation is rejected", func(t *testing.T) {
+		q := &query.GetOrderByIDQuery{ID: uuid.New(), Expand: "items,customer"}
+		err := q.Validate()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "customer")
+	})
 }
 
-func TestListOrdersQuery_Offset(t *testing.T) {
-	tests := []struct {
-		name           string
-		page           int
-		pageSize       int
-		expectedOffset int
-	}{
-		{
-			name:           "first page",
-			page:           1,
-			pageSize:       10,
-			expectedOffset: 0,
-		},
-		{
-			name:           "second page",
-			page:           2,
-			pageSize:       10,
-			expectedOffset: 10,
-		},
-		{
-			name:           "third page",
-			page:           3,
-			pageSize:       10,
-			expectedOffset: 20,
-		},
-		{
-			name:           "custom page size",
-			page:           5,
-			pageSize:       25,
-			expectedOffset: 100,
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			q := &query.ListOrdersQuery{
-				Page:     tt.page,
-				PageSize: tt.pageSize,
-			}
-			assert.Equal(t, tt.expectedOffset, q.Offset())
-		})
-	}
+// TestGetOrderItemsByOrderQuery_Validate verifies the order ID is required.
+func TestGetOrderItemsByOrderQuery_Validate(t *testing.T) {
+	assert.NoError(t, (&query.GetOrderItemsByOrderQuery{OrderID: uuid.New()}).Validate())
+	assert.Equal(t, query.ErrInvalidID, (&query.GetOrderItemsByOrderQuery{}).Validate())
 }
 
 // =============================================================================
@@ -288,6 +160,78 @@ func TestGetAllOrdersQuery_Validate(t *testing.T) {
 	}
 }
 
+// TestGetAllOrdersQuery_Filters verifies filter normalization, rejection of
+// unsupported sort fields and inverted ranges, and the repository filter.
+func TestGetAllOrdersQuery_Filters(t *testing.T) {
+	t.Run("defaults to created_at descending", func(t *testing.T) {
+		q := &query.GetAllOrdersQuery{}
+
+		require.NoError(t, q.Validate())
+
+		assert.Equal(t, repository.Sort{Field: "created_at", Direction: repository.SortDesc}, q.Filter().Sort)
+	})
+
+	t.Run("splits comma separated statuses", func(t *testing.T) {
+		q := &query.GetAllOrdersQuery{Status: []string{"pending, paid", "shipped", ""}}
+
+		require.NoError(t, q.Validate())
+
+		assert.Equal(t, []string{"pending", "paid", "shipped"}, q.Filter().Statuses)
+	})
+
+	t.Run("rejects unsupported sort field", func(t *testing.T) {
+		q := &query.GetAllOrdersQuery{SortBy: "customer_id; DROP TABLE orders"}
+
+		err := q.Validate()
+
+		var qryErr *query.QueryError
+		require.ErrorAs(t, err, &qryErr)
+		assert.Equal(t, "VALIDATION_ERROR", qryErr.Code)
+	})
+
+	t.Run("rejects inverted created_at range", func(t *testing.T) {
+		from := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+		to := from.Add(-time.Hour)
+		q := &query.GetAllOrdersQuery{CreatedFrom: &from, CreatedTo: &to}
+
+		assert.Error(t, q.Validate())
+	})
+
+	t.Run("rejects inverted total range", func(t *testing.T) {
+		min := domain.MustParseMoney("100.00", domain.DefaultCurrency)
+		max := domain.MustParseMoney("10.00", domain.DefaultCurrency)
+		q := &query.GetAllOrdersQuery{MinTotal: &min, MaxTotal: &max}
+
+		assert.Error(t, q.Validate())
+	})
+
+	t.Run("builds repository filter", func(t *testing.T) {
+		customerID := uuid.New()
+		min := domain.MustParseMoney("10.00", domain.DefaultCurrency)
+		q := &query.GetAllOrdersQuery{
+			Offset:     20,
+			Limit:      5,
+			Status:     []string{"paid"},
+			CustomerID: &customerID,
+			MinTotal:   &min,
+			Search:     "abc",
+			SortBy:     "total",
+			SortDir:    "asc",
+		}
+
+		require.NoError(t, q.Validate())
+		f := q.Filter()
+
+		assert.Equal(t, []string{"paid"}, f.Statuses)
+		assert.Equal(t, &customerID, f.CustomerID)
+		assert.Equal(t, &min, f.MinTotal)
+		assert.Equal(t, "abc", f.Search)
+		assert.Equal(t, repository.Sort{Field: "total", Direction: repository.SortAsc}, f.Sort)
+		assert.Equal(t, 20, f.Offset)
+		assert.Equal(t, 5, f.Limit)
+	})
+}
+
 // =============================================================================
 // SearchOrdersQuery Tests
 //
@@ -326,12 +270,6 @@ func TestSearchOrdersQuery_Validate(t *testing.T) {
 			expectedOffset: 0,
 			expectedLimit:  10,
 		},
-		{
-			name:           "empty search query",
-			query:          &query.SearchOrdersQuery{Query: "", Offset: 0, Limit: 10},
-			expectedOffset: 0,
-			expectedLimit:  10,
-		},
 	}
 
 	for _, tt := range tests {
@@ -342,6 +280,23 @@ func TestSearchOrdersQuery_Validate(t *testing.T) {
 			assert.Equal(t, tt.expectedLimit, tt.query.Limit)
 		})
 	}
+
+	t.Run("trims search text", func(t *testing.T) {
+		q := &query.SearchOrdersQuery{Query: "  rush delivery "}
+		require.NoError(t, q.Validate())
+		assert.Equal(t, "rush delivery", q.Query)
+	})
+
+	for name, text := range map[string]string{"empty": "", "blank": "   "} {
+		t.Run("rejects "+name+" search text", func(t *testing.T) {
+			q := &query.SearchOrdersQuery{Query: text, Limit: 10}
+			err := q.Validate()
+
+			var qryErr *query.QueryError
+			require.ErrorAs(t, err, &qryErr)
+			assert.Equal(t, "VALIDATION_ERROR", qryErr.Code)
+		})
+	}
 }
 
 // =============================================================================
@@ -352,17 +307,6 @@ func TestSearchOrdersQuery_Validate(t *testing.T) {
 
 // TestQuery_EdgeCases verifies query behavior under edge conditions.
 func TestQuery_EdgeCases(t *testing.T) {
-	t.Run("ListOrdersQuery with max page", func(t *testing.T) {
-		q := &query.ListOrdersQuery{
-			Page:     10000,
-			PageSize: 100,
-		}
-		_ = q.Validate()
-		assert.Equal(t, 10000, q.Page)
-		// PageSize 100 is valid (limit is <= 100), so it should be reset to default
-		assert.Equal(t, 100, q.PageSize)
-	})
-
 	t.Run("GetOrderByIDQuery with specific UUID", func(t *testing.T) {
 		specificID := uuid.MustParse("12345678-1234-1234-1234-123456789012")
 		q := &query.GetOrderByIDQuery{ID: specificID}
@@ -390,33 +334,7 @@ func TestQuery_EdgeCases(t *testing.T) {
 // Run with: go test -bench=. -benchmem
 // =============================================================================
 
-// BenchmarkListOrdersQuery_Validate measures validation performance.
-func BenchmarkListOrdersQuery_Validate(b *testing.B) {
-	q := &query.ListOrdersQuery{
-		Page:     1,
-		PageSize: 20,
-		SortDir:  "asc",
-		SortBy:   "created_at",
-	}
-
-	b.ResetTimer()
-	for i := 0; i < b.N; i++ {
-		_ = q.Validate()
-	}
-}
-
-func BenchmarkListOrdersQuery_Offset(b *testing.B) {
-	q := &query.ListOrdersQuery{
-		Page:     100,
-		PageSize: 50,
-	}
-
-	b.ResetTimer()
-	for i := 0; i < b.N; i++ {
-		_ = q.Offset()
-	}
-}
-
+// BenchmarkGetOrderByIDQuery_Validate measures validation performance.
 func BenchmarkGetOrderByIDQuery_Validate(b *testing.B) {
 	q := &query.GetOrderByIDQuery{ID: uuid.New()}
 
@@ -79,57 +79,6 @@ func TestGetAllOrdersQuery(t *testing.T) {
 	})
 }
 
-func TestListOrdersQuery(t *testing.T) {
-	t.Run("should set default page for invalid page", func(t *testing.T) {
-		q := &query.ListOrdersQuery{
-			Page:     0,
-			PageSize: 10,
-		}
-		err := q.Validate()
-		require.NoError(t, err)
-		assert.Equal(t, 1, q.Page)
-	})
-
-	t.Run("should set default page size for invalid size", func(t *testing.T) {
-		q := &query.ListOrdersQuery{
-			Page:     1,
-			PageSize: 0,
-		}
-		err := q.Validate()
-		require.NoError(t, err)
-		assert.Equal(t, 10, q.PageSize)
-	})
-
-	t.Run("should set default sort direction", func(t *testing.T) {
-		q := &query.ListOrdersQuery{
-			Page:     1,
-			PageSize: 10,
-			SortDir:  "invalid",
-		}
-		err := q.Validate()
-		require.NoError(t, err)
-		assert.Equal(t, "desc", q.SortDir)
-	})
-
-	t.Run("should set default sort by", func(t *testing.T) {
-		q := &query.ListOrdersQuery{
-			Page:     1,
-			PageSize: 10,
-		}
-		err := q.Validate()
-		require.NoError(t, err)
-		assert.Equal(t, "created_at", q.SortBy)
-	})
-
-	t.Run("should calculate offset correctly", func(t *testing.T) {
-		q := &query.ListOrdersQuery{
-			Page:     3,
-			PageSize: 10,
-		}
-		assert.Equal(t, 20, q.Offset())
-	})
-}
-
 func TestSearchOrdersQuery(t *testing.T) {
 	t.Run("should set default offset for invalid offset", func(t *testing.T) {
 		q := &query.SearchOrdersQuery{
@@ -219,36 +168,6 @@ func TestGetAllOrderItemsQuery(t *testing.T) {
 	})
 }
 
-func TestListOrderitemsQuery(t *testing.T) {
-	t.Run("should set default page for invalid page", func(t *testing.T) {
-		q := &query.ListOrderitemsQuery{
-			Page:     0,
-			PageSize: 10,
-		}
-		err := q.Validate()
-		require.NoError(t, err)
-		assert.Equal(t, 1, q.Page)
-	})
-
-	t.Run("should set default page size for invalid size", func(t *testing.T) {
-		q := &query.ListOrderitemsQuery{
-			Page:     1,
-			PageSize: 0,
-		}
-		err := q.Validate()
-		require.NoError(t, err)
-		assert.Equal(t, 10, q.PageSize)
-	})
-
-	t.Run("should calculate offset correctly", func(t *testing.T) {
-		q := &query.ListOrderitemsQuery{
-			Page:     3,
-			PageSize: 10,
-		}
-		assert.Equal(t, 20, q.Offset())
-	})
-}
-
 func TestSearchOrderItemsQuery(t *testing.T) {
 	t.Run("should set default offset for invalid offset", func(t *testing.T) {
 		q := &query.SearchOrderItemsQuery{
@@ -8,8 +8,9 @@
 //
 // The tests cover the following entity operations:
 //   - Base entity: ID generation, timestamps, soft delete, restore
-//   - Order entity: creation, update, validation, table name
-//   - Orderitem entity: creation, update, validation, table name
+//   - Order entity: creation, update, per-field invariant validation, annotations, table name
+//   - Orderitem entity: creation, update, per-field invariant validation, table name
+//   - Domain events: recording and pulling events raised by aggregates
 //   - GORM hooks: BeforeCreate for ID generation
 //   - Edge cases: large values, multiple cycles, nil handling
 //
@@ -29,10 +30,17 @@ import (
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/telemetryflow/order-service/internal/domain"
 	"github.com/telemetryflow/order-service/internal/domain/entity"
+	"github.com/telemetryflow/order-service/internal/domain/event"
 	"gorm.io/gorm"
 )
 
+// usd builds a US dollar amount from its decimal representation.
+func usd(amount string) domain.Money {
+	return domain.MustParseMoney(amount, domain.DefaultCurrency)
+}
+
 // =============================================================================
 // Base Entity Tests
 //
@@ -50,6 +58,7 @@ func TestNewBase(t *testing.T) {
 		assert.False(t, base.CreatedAt.IsZero(), "CreatedAt should be set")
 		assert.False(t, base.UpdatedAt.IsZero(), "UpdatedAt should be set")
 		assert.False(t, base.DeletedAt.Valid, "DeletedAt should not be valid")
+		assert.Equal(t, int64(1), base.Version, "Version should start at 1")
 	})
 
 	t.Run("creates unique IDs", func(t *testing.T) {
@@ -161,6 +170,13 @@ func TestBase_BeforeCreate(t *testing.T) {
 		assert.NoError(t, err)
 		assert.Equal(t, existingID, base.ID)
 	})
+
+	t.Run("initializes version", func(t *testing.T) {
+		base := &entity.Base{}
+
+		require.NoError(t, base.BeforeCreate(nil))
+		assert.Equal(t, int64(1), base.Version)
+	})
 }
 
 // =============================================================================
@@ -181,7 +197,7 @@ func TestOrder_TableName(t *testing.T) {
 func TestNewOrder(t *testing.T) {
 	t.Run("creates order with all fields", func(t *testing.T) {
 		customerID := uuid.New()
-		total := 99.99
+		total := usd("99.99")
 		status := "pending"
 
 		order := entity.NewOrder(customerID, total, status)
@@ -200,34 +216,34 @@ func TestNewOrder(t *testing.T) {
 		customerID := uuid.New()
 
 		for _, status := range statuses {
-			order := entity.NewOrder(customerID, 100.0, status)
+			order := entity.NewOrder(customerID, usd("100.00"), status)
 			assert.Equal(t, status, order.Status)
 		}
 	})
 
 	t.Run("creates order with zero total", func(t *testing.T) {
-		order := entity.NewOrder(uuid.New(), 0, "pending")
-		assert.Equal(t, float64(0), order.Total)
+		order := entity.NewOrder(uuid.New(), usd("0.00"), "pending")
+		assert.Equal(t, usd("0.00"), order.Total)
 	})
 
 	t.Run("creates order with negative total", func(t *testing.T) {
 		// This might be valid for refunds
-		order := entity.NewOrder(uuid.New(), -50.0, "refunded")
-		assert.Equal(t, -50.0, order.Total)
+		order := entity.NewOrder(uuid.New(), usd("-50.00"), "refunded")
+		assert.Equal(t, usd("-50.00"), order.Total)
 	})
 }
 
 func TestOrder_Update(t *testing.T) {
 	t.Run("updates all fields", func(t *testing.T) {
 		originalCustomerID := uuid.New()
-		order := entity.NewOrder(originalCustomerID, 100.0, "pending")
+		order := entity.NewOrder(originalCustomerID, usd("100.00"), "pending")
 		originalUpdatedAt := order.UpdatedAt
 
 		// Wait to ensure different timestamp
 		time.Sleep(time.Millisecond)
 
 		newCustomerID := uuid.New()
-		newTotal := 200.0
+		newTotal := usd("200.00")
 		newStatus := "confirmed"
 
 		order.Update(newCustomerID, newTotal, newStatus)
@@ -239,21 +255,181 @@ func TestOrder_Update(t *testing.T) {
 	})
 
 	t.Run("preserves ID", func(t *testing.T) {
-		order := entity.NewOrder(uuid.New(), 100.0, "pending")
+		order := entity.NewOrder(uuid.New(), usd("100.00"), "pending")
 		originalID := order.ID
 
-		order.Update(uuid.New(), 200.0, "confirmed")
+		order.Update(uuid.New(), usd("200.00"), "confirmed")
 
 		assert.Equal(t, originalID, order.ID)
 	})
 }
 
+func TestOrder_Annotate(t *testing.T) {
+	t.Run("new orders have no tags", func(t *testing.T) {
+		order := entity.NewOrder(uuid.New(), usd("10.00"), "pending")
+		assert.NotNil(t, order.Tags)
+		assert.Empty(t, order.Tags)
+	})
+
+	t.Run("normalizes tags and notes", func(t *testing.T) {
+		order := entity.NewOrder(uuid.New(), usd("10.00"), "pending")
+
+		order.Annotate([]string{" vip ", "rush", "", "vip", "  "}, "  leave at door ")
+
+		assert.Equal(t, []string{"vip", "rush"}, order.Tags)
+		assert.Equal(t, "leave at door", order.Notes)
+	})
+
+	t.Run("replaces previous annotations", func(t *testing.T) {
+		order := entity.NewOrder(uuid.New(), usd("10.00"), "pending")
+		order.Annotate([]string{"vip"}, "note")
+
+		order.Annotate(nil, "")
+
+		assert.NotNil(t, order.Tags)
+		assert.Empty(t, order.Tags)
+		assert.Empty(t, order.Notes)
+	})
+}
+
 func TestOrder_Validate(t *testing.T) {
 	t.Run("returns nil for valid order", func(t *testing.T) {
-		order := entity.NewOrder(uuid.New(), 100.0, "pending")
+		order := entity.NewOrder(uuid.New(), usd("100.00"), "pending")
 		err := order.Validate()
 		assert.NoError(t, err)
 	})
+
+	t.Run("reports violated invariants per field", func(t *testing.T) {
+		order := entity.NewOrder(uuid.Nil, usd("100.00"), "archived")
+		order.Tax = usd("-1.00")
+		order.Items = []entity.Orderitem{
+			*entity.NewOrderitem(order.ID, uuid.New(), 1, usd("5.00")),
+			*entity.NewOrderitem(uuid.New(), uuid.New(), 0, usd("5.00")),
+		}
+
+		err := order.Validate()
+
+		assert.ErrorIs(t, err, domain.ErrInvalidEntity)
+		var verr *domain.ValidationError
+		require.ErrorAs(t, err, &verr)
+		assert.Equal(t, map[string]string{
+			"customer_id":       "This field is required",
+			"status":            "Must be a known order status",
+			"tax":               "Must not be negative",
+			"items[1].order_id": "Must reference the order",
+			"items[1].quantity": "Must be greater than or equal to 1",
+		}, verr.Fields)
+		assert.Equal(t, "invalid entity: customer_id: This field is required; items[1].order_id: Must reference the order; "+
+			"items[1].quantity: Must be greater than or equal to 1; status: Must be a known order status; tax: Must not be negative", err.Error())
+	})
+
+	t.Run("rejects an invalid currency", func(t *testing.T) {
+		order := entity.NewOrder(uuid.New(), domain.NewMoney(100, "usd"), "pending")
+
+		var verr *domain.ValidationError
+		require.ErrorAs(t, order.Validate(), &verr)
+		assert.Contains(t, verr.Fields, "currency")
+	})
+
+	t.Run("rejects a discount larger than the rest of the total", func(t *testing.T) {
+		order := entity.NewOrder(uuid.New(), usd("0.00"), "pending")
+		order.Items = []entity.Orderitem{*entity.NewOrderitem(order.ID, uuid.New(), 1, usd("10.00"))}
+		require.NoError(t, order.SetCharges(usd("15.00"), usd("1.00"), usd("2.00")))
+		assert.Equal(t, usd("-2.00"), order.Total)
+
+		var verr *domain.ValidationError
+		require.ErrorAs(t, order.Validate(), &verr)
+		assert.Equal(t, map[string]string{"discount": "Must not exceed the subtotal plus tax and shipping"}, verr.Fields)
+
+		require.NoError(t, order.SetCharges(usd("13.00"), usd("1.00"), usd("2.00")))
+		assert.NoError(t, order.Validate(), "a zero total is allowed")
+	})
+}
+
+// TestOrder_TransitionTo verifies the order status state machine.
+func TestOrder_TransitionTo(t *testing.T) {
+	tests := []struct {
+		name        string
+		from        string
+		to          string
+		expectError bool
+	}{
+		{"pending to confirmed", entity.OrderStatusPending, entity.OrderStatusConfirmed, false},
+		{"pending to cancelled", entity.OrderStatusPending, entity.OrderStatusCancelled, false},
+		{"confirmed to paid", entity.OrderStatusConfirmed, entity.OrderStatusPaid, false},
+		{"paid to shipped", entity.OrderStatusPaid, entity.OrderStatusShipped, false},
+		{"paid to refunded", entity.OrderStatusPaid, entity.OrderStatusRefunded, false},
+		{"shipped to delivered", entity.OrderStatusShipped, entity.OrderStatusDelivered, false},
+		{"delivered to refunded", entity.OrderStatusDelivered, entity.OrderStatusRefunded, false},
+		{"pending to delivered", entity.OrderStatusPending, entity.OrderStatusDelivered, true},
+		{"delivered to pending", entity.OrderStatusDelivered, entity.OrderStatusPending, true},
+		{"shipped to cancelled", entity.OrderStatusShipped, entity.OrderStatusCancelled, true},
+		{"cancelled to confirmed", entity.OrderStatusCancelled, entity.OrderStatusConfirmed, true},
+		{"pending to unknown", entity.OrderStatusPending, "archived", true},
+		{"unknown to confirmed", "archived", entity.OrderStatusConfirmed, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			order := entity.NewOrder(uuid.New(), usd("100.00"), tt.from)
+
+			err := order.TransitionTo(tt.to)
+
+			if tt.expectError {
+				assert.ErrorIs(t, err, domain.ErrInvalidStateTransition)
+				assert.Equal(t, tt.from, order.Status)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.to, order.Status)
+			}
+		})
+	}
+}
+
+func TestOrder_Lifecycle(t *testing.T) {
+	t.Run("walks the happy path", func(t *testing.T) {
+		order := entity.NewOrder(uuid.New(), usd("100.00"), entity.OrderStatusPending)
+
+		require.NoError(t, order.Confirm())
+		require.NoError(t, order.Pay())
+		require.NoError(t, order.Ship())
+		require.NoError(t, order.Deliver())
+
+		assert.Equal(t, entity.OrderStatusDelivered, order.Status)
+		assert.False(t, order.IsFinal())
+	})
+
+	t.Run("cancelled order is final", func(t *testing.T) {
+		order := entity.NewOrder(uuid.New(), usd("100.00"), entity.OrderStatusPending)
+
+		require.NoError(t, order.Cancel())
+
+		assert.True(t, order.IsFinal())
+		assert.Error(t, order.Confirm())
+		assert.Error(t, order.Refund())
+	})
+}
+
+func TestOrder_CheckItemsEditable(t *testing.T) {
+	assert.NoError(t, entity.NewOrder(uuid.New(), usd("100.00"), entity.OrderStatusPending).CheckItemsEditable())
+
+	for _, status := range []string{
+		entity.OrderStatusConfirmed, entity.OrderStatusPaid, entity.OrderStatusShipped,
+		entity.OrderStatusDelivered, entity.OrderStatusCancelled, entity.OrderStatusRefunded,
+	} {
+		t.Run(status, func(t *testing.T) {
+			err := entity.NewOrder(uuid.New(), usd("100.00"), status).CheckItemsEditable()
+
+			assert.ErrorIs(t, err, domain.ErrEntityConflict)
+		})
+	}
+}
+
+func TestIsValidOrderStatus(t *testing.T) {
+	assert.True(t, entity.IsValidOrderStatus("pending"))
+	assert.True(t, entity.IsValidOrderStatus("refunded"))
+	assert.False(t, entity.IsValidOrderStatus(""))
+	assert.False(t, entity.IsValidOrderStatus("Pending"))
 }
 
 // =============================================================================
@@ -276,7 +452,7 @@ func TestNewOrderitem(t *testing.T) {
 		orderID := uuid.New()
 		productID := uuid.New()
 		quantity := 5
-		price := 29.99
+		price := usd("29.99")
 
 		item := entity.NewOrderitem(orderID, productID, quantity, price)
 
@@ -291,13 +467,13 @@ func TestNewOrderitem(t *testing.T) {
 	})
 
 	t.Run("creates orderitem with zero quantity", func(t *testing.T) {
-		item := entity.NewOrderitem(uuid.New(), uuid.New(), 0, 10.0)
+		item := entity.NewOrderitem(uuid.New(), uuid.New(), 0, usd("10.00"))
 		assert.Equal(t, 0, item.Quantity)
 	})
 
 	t.Run("creates orderitem with zero price", func(t *testing.T) {
-		item := entity.NewOrderitem(uuid.New(), uuid.New(), 1, 0)
-		assert.Equal(t, float64(0), item.Price)
+		item := entity.NewOrderitem(uuid.New(), uuid.New(), 1, usd("0.00"))
+		assert.Equal(t, usd("0.00"), item.Price)
 	})
 }
 
@@ -305,7 +481,7 @@ func TestOrderitem_Update(t *testing.T) {
 	t.Run("updates all fields", func(t *testing.T) {
 		originalOrderID := uuid.New()
 		originalProductID := uuid.New()
-		item := entity.NewOrderitem(originalOrderID, originalProductID, 1, 10.0)
+		item := entity.NewOrderitem(originalOrderID, originalProductID, 1, usd("10.00"))
 		originalUpdatedAt := item.UpdatedAt
 
 		// Wait to ensure different timestamp
@@ -314,7 +490,7 @@ func TestOrderitem_Update(t *testing.T) {
 		newOrderID := uuid.New()
 		newProductID := uuid.New()
 		newQuantity := 5
-		newPrice := 50.0
+		newPrice := usd("50.00")
 
 		item.Update(newOrderID, newProductID, newQuantity, newPrice)
 
@@ -326,10 +502,10 @@ func TestOrderitem_Update(t *testing.T) {
 	})
 
 	t.Run("preserves ID", func(t *testing.T) {
-		item := entity.NewOrderitem(uuid.New(), uuid.New(), 1, 10.0)
+		item := entity.NewOrderitem(uuid.New(), uuid.New(), 1, usd("10.00"))
 		originalID := item.ID
 
-		item.Update(uuid.New(), uuid.New(), 2, 20.0)
+		item.Update(uuid.New(), uuid.New(), 2, usd("20.00"))
 
 		assert.Equal(t, originalID, item.ID)
 	})
@@ -337,10 +513,39 @@ func TestOrderitem_Update(t *testing.T) {
 
 func TestOrderitem_Validate(t *testing.T) {
 	t.Run("returns nil for valid orderitem", func(t *testing.T) {
-		item := entity.NewOrderitem(uuid.New(), uuid.New(), 1, 10.0)
+		item := entity.NewOrderitem(uuid.New(), uuid.New(), 1, usd("10.00"))
 		err := item.Validate()
 		assert.NoError(t, err)
 	})
+
+	t.Run("accepts a zero price", func(t *testing.T) {
+		item := entity.NewOrderitem(uuid.New(), uuid.New(), 1, usd("0.00"))
+		assert.NoError(t, item.Validate())
+	})
+
+	tests := []struct {
+		name  string
+		item  *entity.Orderitem
+		field string
+	}{
+		{"nil order", entity.NewOrderitem(uuid.Nil, uuid.New(), 1, usd("10.00")), "order_id"},
+		{"nil product", entity.NewOrderitem(uuid.New(), uuid.Nil, 1, usd("10.00")), "product_id"},
+		{"zero quantity", entity.NewOrderitem(uuid.New(), uuid.New(), 0, usd("10.00")), "quantity"},
+		{"negative quantity", entity.NewOrderitem(uuid.New(), uuid.New(), -3, usd("10.00")), "quantity"},
+		{"negative price", entity.NewOrderitem(uuid.New(), uuid.New(), 1, usd("-10.00")), "price"},
+	}
+
+	for _, tt := range tests {
+		t.Run("rejects "+tt.name, func(t *testing.T) {
+			err := tt.item.Validate()
+
+			assert.ErrorIs(t, err, domain.ErrInvalidEntity)
+			var verr *domain.ValidationError
+			require.ErrorAs(t, err, &verr)
+			assert.Len(t, verr.Fields, 1)
+			assert.Contains(t, verr.Fields, tt.field)
+		})
+	}
 }
 
 // =============================================================================
@@ -353,11 +558,11 @@ func TestOrderitem_Validate(t *testing.T) {
 // TestOrder_WithItems verifies the one-to-many relationship between Order and Orderitem.
 func TestOrder_WithItems(t *testing.T) {
 	t.Run("order can have multiple items", func(t *testing.T) {
-		order := entity.NewOrder(uuid.New(), 100.0, "pending")
+		order := entity.NewOrder(uuid.New(), usd("100.00"), "pending")
 
 		items := []entity.Orderitem{
-			*entity.NewOrderitem(order.ID, uuid.New(), 2, 25.0),
-			*entity.NewOrderitem(order.ID, uuid.New(), 1, 50.0),
+			*entity.NewOrderitem(order.ID, uuid.New(), 2, usd("25.00")),
+			*entity.NewOrderitem(order.ID, uuid.New(), 1, usd("50.00")),
 		}
 		order.Items = items
 
@@ -368,11 +573,205 @@ func TestOrder_WithItems(t *testing.T) {
 	})
 
 	t.Run("order can have empty items", func(t *testing.T) {
-		order := entity.NewOrder(uuid.New(), 0, "pending")
+		order := entity.NewOrder(uuid.New(), usd("0.00"), "pending")
 		assert.Empty(t, order.Items)
 	})
 }
 
+// TestOrder_RecalculateTotal verifies totals are derived from items and charges.
+func TestOrder_RecalculateTotal(t *testing.T) {
+	t.Run("sums item line totals into subtotal", func(t *testing.T) {
+		order := entity.NewOrder(uuid.New(), usd("999.00"), "pending")
+		order.Items = []entity.Orderitem{
+			*entity.NewOrderitem(order.ID, uuid.New(), 3, usd("19.99")),
+			*entity.NewOrderitem(order.ID, uuid.New(), 1, usd("0.03")),
+		}
+
+		require.NoError(t, order.RecalculateTotal())
+
+		assert.Equal(t, usd("60.00"), order.Subtotal)
+		assert.Equal(t, usd("60.00"), order.Total)
+	})
+
+	t.Run("applies discount, tax and shipping", func(t *testing.T) {
+		order := entity.NewOrder(uuid.New(), usd("0.00"), "pending")
+		order.Items = []entity.Orderitem{
+			*entity.NewOrderitem(order.ID, uuid.New(), 2, usd("50.00")),
+		}
+
+		require.NoError(t, order.SetCharges(usd("10.00"), usd("9.00"), usd("5.50")))
+
+		assert.Equal(t, usd("100.00"), order.Subtotal)
+		assert.Equal(t, usd("104.50"), order.Total)
+	})
+
+	t.Run("order without items only carries charges", func(t *testing.T) {
+		order := entity.NewOrder(uuid.New(), usd("50.00"), "pending")
+
+		require.NoError(t, order.SetCharges(usd("0.00"), usd("0.00"), usd("7.25")))
+
+		assert.Equal(t, usd("0.00"), order.Subtotal)
+		assert.Equal(t, usd("7.25"), order.Total)
+	})
+
+	t.Run("rejects negative charges", func(t *testing.T) {
+		order := entity.NewOrder(uuid.New(), usd("0.00"), "pending")
+
+		err := order.SetCharges(usd("-1.00"), usd("0.00"), usd("0.00"))
+
+		assert.ErrorIs(t, err, domain.ErrInvalidAmount)
+	})
+
+	t.Run("rejects items in another currency", func(t *testing.T) {
+		order := entity.NewOrder(uuid.New(), domain.ZeroMoney("EUR"), "pending")
+		order.Items = []entity.Orderitem{
+			*entity.NewOrderitem(order.ID, uuid.New(), 1, usd("5.00")),
+		}
+
+		assert.ErrorIs(t, order.RecalculateTotal(), domain.ErrCurrencyMismatch)
+	})
+}
+
+func TestOrder_VerifyTotal(t *testing.T) {
+	order := entity.NewOrder(uuid.New(), usd("0.00"), "pending")
+	order.Items = []entity.Orderitem{*entity.NewOrderitem(order.ID, uuid.New(), 1, usd("10.10"))}
+	require.NoError(t, order.SetCharges(usd("0.00"), usd("0.20"), usd("0.00")))
+
+	total := func(m domain.Money) *domain.Money { return &m }
+
+	assert.NoError(t, order.VerifyTotal(total(usd("10.30"))))
+	assert.NoError(t, order.VerifyTotal(total(domain.MustParseMoney("10.30", ""))), "missing currency adopts the order currency")
+	assert.NoError(t, order.VerifyTotal(nil), "nil total is treated as not supplied")
+	assert.ErrorIs(t, order.VerifyTotal(total(usd("0.00"))), domain.ErrTotalMismatch, "an explicit zero total is verified")
+	assert.ErrorIs(t, order.VerifyTotal(total(usd("10.31"))), domain.ErrTotalMismatch)
+	assert.ErrorIs(t, order.VerifyTotal(total(domain.MustParseMoney("10.30", "EUR"))), domain.ErrTotalMismatch)
+}
+
+func TestOrderitem_LineTotal(t *testing.T) {
+	item := entity.NewOrderitem(uuid.New(), uuid.New(), 3, usd("0.10"))
+	total, err := item.LineTotal()
+	require.NoError(t, err)
+	assert.Equal(t, usd("0.30"), total)
+
+	t.Run("out of range amounts fail the total", func(t *testing.T) {
+		order := entity.NewOrder(uuid.New(), usd("0.00"), entity.OrderStatusPending)
+		order.Items = []entity.Orderitem{*entity.NewOrderitem(order.ID, uuid.New(), 1_000_000, usd("99999999999999.99"))}
+
+		assert.ErrorIs(t, order.RecalculateTotal(), domain.ErrInvalidAmount)
+	})
+}
+
+// =============================================================================
+// Domain Event Tests
+//
+// Tests for the domain events recorded by Order and Orderitem, which command
+// handlers pull and publish after a successful commit.
+// =============================================================================
+
+// eventNames returns the names of the given events in order.
+func eventNames(events []event.Event) []string {
+	names := make([]string, len(events))
+	for i, e := range events {
+		names[i] = e.EventName()
+	}
+	return names
+}
+
+// TestOrder_Events verifies the events recorded by the order aggregate.
+func TestOrder_Events(t *testing.T) {
+	t.Run("new order records OrderCreated", func(t *testing.T) {
+		customerID := uuid.New()
+		order := entity.NewOrder(customerID, usd("0.00"), entity.OrderStatusPending)
+
+		events := order.PullEvents()
+
+		require.Len(t, events, 1)
+		created, ok := events[0].(event.OrderCreated)
+		require.True(t, ok)
+		assert.Equal(t, order.ID, created.AggregateID())
+		assert.Equal(t, customerID, created.CustomerID)
+		assert.Equal(t, "USD", created.Currency)
+		assert.NotEqual(t, uuid.Nil, created.EventID)
+		assert.False(t, created.OccurredAt().IsZero())
+	})
+
+	t.Run("pulling clears recorded events", func(t *testing.T) {
+		order := entity.NewOrder(uuid.New(), usd("0.00"), entity.OrderStatusPending)
+
+		order.PullEvents()
+
+		assert.Empty(t, order.PullEvents())
+	})
+
+	t.Run("cancel records status change and cancellation", func(t *testing.T) {
+		order := entity.NewOrder(uuid.New(), usd("0.00"), entity.OrderStatusConfirmed)
+		order.PullEvents()
+
+		require.NoError(t, order.Cancel())
+		events := order.PullEvents()
+
+		assert.Equal(t, []string{event.OrderStatusChangedName, event.OrderCancelledName}, eventNames(events))
+		changed := events[0].(event.OrderStatusChanged)
+		assert.Equal(t, entity.OrderStatusConfirmed, changed.From)
+		assert.Equal(t, entity.OrderStatusCancelled, changed.To)
+		assert.Equal(t, entity.OrderStatusConfirmed, events[1].(event.OrderCancelled).PreviousStatus)
+	})
+
+	t.Run("rejected transition records nothing", func(t *testing.T) {
+		order := entity.NewOrder(uuid.New(), usd("0.00"), entity.OrderStatusDelivered)
+		order.PullEvents()
+
+		assert.Error(t, order.Ship())
+		assert.Empty(t, order.PullEvents())
+	})
+
+	t.Run("remove marks deleted and records OrderDeleted", func(t *testing.T) {
+		order := entity.NewOrder(uuid.New(), usd("0.00"), entity.OrderStatusShipped)
+		order.PullEvents()
+
+		order.Remove()
+		events := order.PullEvents()
+
+		assert.True(t, order.IsDeleted())
+		require.Len(t, events, 1)
+		deleted := events[0].(event.OrderDeleted)
+		assert.Equal(t, order.ID, deleted.AggregateID())
+		assert.Equal(t, entity.OrderStatusShipped, deleted.Status)
+	})
+
+	t.Run("order events include item events", func(t *testing.T) {
+		order := entity.NewOrder(uuid.New(), usd("0.00"), entity.OrderStatusPending)
+		order.Items = []entity.Orderitem{*entity.NewOrderitem(order.ID, uuid.New(), 1, usd("3.00"))}
+
+		events := order.PullEvents()
+
+		assert.Equal(t, []string{event.OrderCreatedName, event.OrderItemAddedName}, eventNames(events))
+		assert.Equal(t, order.ID, events[1].AggregateID())
+		assert.Empty(t, order.PullEvents())
+	})
+}
+
+// TestOrderitem_Events verifies the events recorded by order items.
+func TestOrderitem_Events(t *testing.T) {
+	fromOrderID := uuid.New()
+	toOrderID := uuid.New()
+	item := entity.NewOrderitem(fromOrderID, uuid.New(), 1, usd("5.00"))
+
+	added := item.PullEvents()
+	require.Len(t, added, 1)
+	assert.Equal(t, usd("5.00"), added[0].(event.OrderItemAdded).Price)
+
+	item.Update(toOrderID, item.ProductID, 2, usd("5.00"))
+	item.Remove()
+	events := item.PullEvents()
+
+	assert.Equal(t, []string{event.OrderItemUpdatedName, event.OrderItemRemovedName}, eventNames(events))
+	updated := events[0].(event.OrderItemUpdated)
+	assert.Equal(t, toOrderID, updated.OrderID)
+	assert.Equal(t, fromOrderID, updated.PreviousOrderID)
+	assert.True(t, item.IsDeleted())
+}
+
 // =============================================================================
 // Edge Cases and Boundary Tests
 //
@@ -383,14 +782,14 @@ func TestOrder_WithItems(t *testing.T) {
 // TestEntity_EdgeCases verifies entity behavior under extreme or unusual conditions.
 func TestEntity_EdgeCases(t *testing.T) {
 	t.Run("order with very large total", func(t *testing.T) {
-		largeTotal := 9999999999.99
+		largeTotal := usd("9999999999.99")
 		order := entity.NewOrder(uuid.New(), largeTotal, "pending")
 		assert.Equal(t, largeTotal, order.Total)
 	})
 
 	t.Run("orderitem with very large quantity", func(t *testing.T) {
 		largeQuantity := 999999
-		item := entity.NewOrderitem(uuid.New(), uuid.New(), largeQuantity, 1.0)
+		item := entity.NewOrderitem(uuid.New(), uuid.New(), largeQuantity, usd("1.00"))
 		assert.Equal(t, largeQuantity, item.Quantity)
 	})
 
@@ -450,7 +849,7 @@ func BenchmarkNewBase(b *testing.B) {
 func BenchmarkNewOrder(b *testing.B) {
 	customerID := uuid.New()
 	for i := 0; i < b.N; i++ {
-		_ = entity.NewOrder(customerID, 100.0, "pending")
+		_ = entity.NewOrder(customerID, usd("100.00"), "pending")
 	}
 }
 
@@ -458,16 +857,16 @@ func BenchmarkNewOrderitem(b *testing.B) {
 	orderID := uuid.New()
 	productID := uuid.New()
 	for i := 0; i < b.N; i++ {
-		_ = entity.NewOrderitem(orderID, productID, 1, 10.0)
+		_ = entity.NewOrderitem(orderID, productID, 1, usd("10.00"))
 	}
 }
 
 func BenchmarkOrder_Update(b *testing.B) {
-	order := entity.NewOrder(uuid.New(), 100.0, "pending")
+	order := entity.NewOrder(uuid.New(), usd("100.00"), "pending")
 	newCustomerID := uuid.New()
 
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
-		order.Update(newCustomerID, float64(i), "confirmed")
+		order.Update(newCustomerID, domain.NewMoney(int64(i), domain.DefaultCurrency), "confirmed")
 	}
 }
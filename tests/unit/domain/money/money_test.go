@@ -0,0 +1,222 @@
+// money_test.go - Money Value Object Unit Tests
+//
+// This file contains unit tests for the Money value object, which stores
+// monetary amounts exactly in minor units together with a currency code.
+//
+// # Test Coverage
+//
+// The tests cover the following operations:
+//   - Parsing: decimal strings, signs, precision limits, invalid input
+//   - Arithmetic: Add, Sub, Multiply, currency mismatch and overflow handling
+//   - Formatting: String output with a fixed scale of two decimals
+//   - JSON: string encoding and string/number decoding without float rounding
+//   - Text: decoding of query parameters
+//   - SQL: driver.Valuer and sql.Scanner for decimal columns
+//
+// # Test Patterns
+//
+// Tests use table-driven patterns for parsing and scanning scenarios.
+//
+// Generated by TelemetryFlow RESTful API Generator
+// Copyright (c) 2024-2026 DevOpsCorner Indonesia. All rights reserved.
+package money_test
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/telemetryflow/order-service/internal/domain"
+)
+
+// =============================================================================
+// Parsing Tests
+//
+// Tests for ParseMoney which converts decimal strings into minor units.
+// =============================================================================
+
+// TestParseMoney verifies decimal strings are converted to exact minor units.
+func TestParseMoney(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected int64
+		wantErr  bool
+	}{
+		{"whole number", "12", 1200, false},
+		{"two decimals", "12.50", 1250, false},
+		{"one decimal", "0.1", 10, false},
+		{"leading dot", ".05", 5, false},
+		{"negative", "-3.25", -325, false},
+		{"explicit plus", "+7", 700, false},
+		{"trailing zeros beyond scale", "1.500", 150, false},
+		{"surrounding whitespace", " 4.20 ", 420, false},
+		{"too many decimals", "1.005", 0, true},
+		{"empty", "", 0, true},
+		{"sign only", "-", 0, true},
+		{"letters", "12a", 0, true},
+		{"exponent", "1e3", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := domain.ParseMoney(tt.input, "USD")
+			if tt.wantErr {
+				assert.ErrorIs(t, err, domain.ErrInvalidAmount)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, m.Amount())
+			assert.Equal(t, "USD", m.Currency())
+		})
+	}
+}
+
+// TestValidateCurrency verifies ISO-4217 style currency code checks.
+func TestValidateCurrency(t *testing.T) {
+	assert.NoError(t, domain.ValidateCurrency("USD"))
+	assert.NoError(t, domain.ValidateCurrency("IDR"))
+	assert.ErrorIs(t, domain.ValidateCurrency("usd"), domain.ErrInvalidCurrency)
+	assert.ErrorIs(t, domain.ValidateCurrency("US"), domain.ErrInvalidCurrency)
+	assert.ErrorIs(t, domain.ValidateCurrency(""), domain.ErrInvalidCurrency)
+}
+
+// =============================================================================
+// Arithmetic Tests
+//
+// Tests for exact arithmetic on Money values.
+// =============================================================================
+
+// TestMoney_Arithmetic verifies exact addition, subtraction and multiplication.
+func TestMoney_Arithmetic(t *testing.T) {
+	t.Run("adds without float rounding", func(t *testing.T) {
+		sum := domain.MustParseMoney("0.10", "USD")
+		for i := 0; i < 2; i++ {
+			var err error
+			sum, err = sum.Add(domain.MustParseMoney("0.10", "USD"))
+			require.NoError(t, err)
+		}
+		assert.Equal(t, domain.MustParseMoney("0.30", "USD"), sum)
+	})
+
+	t.Run("subtracts below zero", func(t *testing.T) {
+		diff, err := domain.MustParseMoney("1.00", "USD").Sub(domain.MustParseMoney("2.50", "USD"))
+		require.NoError(t, err)
+		assert.True(t, diff.IsNegative())
+		assert.Equal(t, "-1.50", diff.String())
+	})
+
+	t.Run("multiplies by quantity", func(t *testing.T) {
+		m, err := domain.MustParseMoney("19.99", "EUR").Multiply(3)
+		require.NoError(t, err)
+		assert.Equal(t, "59.97", m.String())
+		assert.Equal(t, "EUR", m.Currency())
+	})
+
+	t.Run("multiplication overflow is rejected", func(t *testing.T) {
+		for name, tc := range map[string]struct {
+			amount string
+			n      int64
+		}{
+			"large quantity":  {"99999999999999.99", 1_000_000},
+			"negative amount": {"-0.02", math.MaxInt64},
+			"minimum amount":  {"-0.01", math.MinInt64},
+		} {
+			t.Run(name, func(t *testing.T) {
+				_, err := domain.MustParseMoney(tc.amount, "USD").Multiply(tc.n)
+				assert.ErrorIs(t, err, domain.ErrInvalidAmount)
+			})
+		}
+	})
+
+	t.Run("multiplying zero never overflows", func(t *testing.T) {
+		m, err := domain.ZeroMoney("USD").Multiply(math.MaxInt64)
+		require.NoError(t, err)
+		assert.True(t, m.IsZero())
+	})
+
+	t.Run("unspecified currency adopts the other operand", func(t *testing.T) {
+		sum, err := domain.MustParseMoney("1.00", "").Add(domain.MustParseMoney("2.00", "IDR"))
+		require.NoError(t, err)
+		assert.Equal(t, "IDR", sum.Currency())
+	})
+
+	t.Run("different currencies are rejected", func(t *testing.T) {
+		_, err := domain.MustParseMoney("1.00", "USD").Add(domain.MustParseMoney("1.00", "EUR"))
+		assert.ErrorIs(t, err, domain.ErrCurrencyMismatch)
+	})
+}
+
+// =============================================================================
+// Serialization Tests
+//
+// Tests for JSON and SQL round trips.
+// =============================================================================
+
+// TestMoney_JSON verifies amounts are encoded as strings and decoded exactly.
+func TestMoney_JSON(t *testing.T) {
+	t.Run("marshals as string", func(t *testing.T) {
+		data, err := json.Marshal(domain.NewMoney(1050, "USD"))
+		require.NoError(t, err)
+		assert.JSONEq(t, `"10.50"`, string(data))
+	})
+
+	t.Run("unmarshals string and number", func(t *testing.T) {
+		var payload struct {
+			A domain.Money `json:"a"`
+			B domain.Money `json:"b"`
+			C domain.Money `json:"c"`
+		}
+		err := json.Unmarshal([]byte(`{"a":"10.10","b":0.3,"c":null}`), &payload)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1010), payload.A.Amount())
+		assert.Equal(t, int64(30), payload.B.Amount())
+		assert.True(t, payload.C.IsZero())
+	})
+
+	t.Run("rejects sub-cent precision", func(t *testing.T) {
+		var m domain.Money
+		assert.ErrorIs(t, json.Unmarshal([]byte(`"0.001"`), &m), domain.ErrInvalidAmount)
+	})
+
+	t.Run("unmarshals text", func(t *testing.T) {
+		var m domain.Money
+		require.NoError(t, m.UnmarshalText([]byte("12.5")))
+		assert.Equal(t, int64(1250), m.Amount())
+		assert.ErrorIs(t, m.UnmarshalText([]byte("twelve")), domain.ErrInvalidAmount)
+	})
+}
+
+// TestMoney_SQL verifies the driver.Valuer and sql.Scanner implementations.
+func TestMoney_SQL(t *testing.T) {
+	value, err := domain.NewMoney(-1234, "USD").Value()
+	require.NoError(t, err)
+	assert.Equal(t, "-12.34", value)
+
+	tests := []struct {
+		name     string
+		src      interface{}
+		expected int64
+	}{
+		{"bytes", []byte("99.90"), 9990},
+		{"string", "0.07", 7},
+		{"int64", int64(5), 500},
+		{"float64", 1.1, 110},
+		{"nil", nil, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var m domain.Money
+			require.NoError(t, m.Scan(tt.src))
+			assert.Equal(t, tt.expected, m.Amount())
+		})
+	}
+
+	t.Run("unsupported type", func(t *testing.T) {
+		var m domain.Money
+		assert.ErrorIs(t, m.Scan(true), domain.ErrInvalidAmount)
+	})
+}
@@ -218,6 +218,19 @@ func TestConfig_Load(t *testing.T) {
 		assert.Equal(t, "disable", cfg.Database.SSLMode)
 		assert.Equal(t, 25, cfg.Database.MaxOpenConns)
 		assert.Equal(t, 5, cfg.Database.MaxIdleConns)
+		assert.False(t, cfg.Database.AutoMigrate)
+		assert.False(t, cfg.Database.StrictSchema)
+		assert.Empty(t, cfg.Database.Replicas)
+		assert.Equal(t, 10*time.Second, cfg.Database.ReplicaCheckInterval)
+		assert.Equal(t, 5*time.Second, cfg.Database.ReadYourWritesWindow)
+		assert.Equal(t, config.ResilienceConfig{
+			ReadTimeout:      5 * time.Second,
+			WriteTimeout:     10 * time.Second,
+			RetryAttempts:    3,
+			RetryBackoff:     100 * time.Millisecond,
+			BreakerThreshold: 5,
+			BreakerCooldown:  30 * time.Second,
+		}, cfg.Database.Resilience)
 		assert.Equal(t, 100, cfg.RateLimit.Requests)
 		assert.Equal(t, "info", cfg.Log.Level)
 		assert.Equal(t, "json", cfg.Log.Format)
@@ -228,6 +241,13 @@ func TestConfig_Load(t *testing.T) {
 		t.Setenv("SERVER_PORT", "9000")
 		t.Setenv("DB_HOST", "db.example.com")
 		t.Setenv("DB_NAME", "test_orders")
+		t.Setenv("DB_AUTO_MIGRATE", "true")
+		t.Setenv("DB_STRICT_SCHEMA", "true")
+		t.Setenv("DB_REPLICAS", "replica-1:5432,replica-2")
+		t.Setenv("DB_READ_YOUR_WRITES_WINDOW", "2s")
+		t.Setenv("DB_READ_TIMEOUT", "1s")
+		t.Setenv("DB_RETRY_ATTEMPTS", "5")
+		t.Setenv("DB_BREAKER_COOLDOWN", "1m")
 		t.Setenv("JWT_SECRET", "env-secret")
 		t.Setenv("LOG_LEVEL", "debug")
 
@@ -239,6 +259,13 @@ func TestConfig_Load(t *testing.T) {
 		assert.Equal(t, "9000", cfg.Server.Port)
 		assert.Equal(t, "db.example.com", cfg.Database.Host)
 		assert.Equal(t, "test_orders", cfg.Database.Name)
+		assert.True(t, cfg.Database.AutoMigrate)
+		assert.True(t, cfg.Database.StrictSchema)
+		assert.Equal(t, []string{"replica-1:5432", "replica-2"}, cfg.Database.Replicas)
+		assert.Equal(t, 2*time.Second, cfg.Database.ReadYourWritesWindow)
+		assert.Equal(t, time.Second, cfg.Database.Resilience.ReadTimeout)
+		assert.Equal(t, 5, cfg.Database.Resilience.RetryAttempts)
+		assert.Equal(t, time.Minute, cfg.Database.Resilience.BreakerCooldown)
 		assert.Equal(t, "env-secret", cfg.JWT.Secret)
 		assert.Equal(t, "debug", cfg.Log.Level)
 	})
@@ -8,25 +8,42 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	"github.com/telemetryflow/order-service/internal/application/bus"
 	"github.com/telemetryflow/order-service/internal/application/command"
 	"github.com/telemetryflow/order-service/internal/application/dto"
+	"github.com/telemetryflow/order-service/internal/application/eventbus"
 	apphandler "github.com/telemetryflow/order-service/internal/application/handler"
 	"github.com/telemetryflow/order-service/internal/application/query"
+	"github.com/telemetryflow/order-service/internal/domain"
 	"github.com/telemetryflow/order-service/internal/domain/entity"
+	"github.com/telemetryflow/order-service/internal/domain/event"
+	"github.com/telemetryflow/order-service/internal/domain/repository"
 	httphandler "github.com/telemetryflow/order-service/internal/infrastructure/http/handler"
+	"github.com/telemetryflow/order-service/pkg/pagination"
+	"github.com/telemetryflow/order-service/pkg/response"
 	"github.com/telemetryflow/order-service/pkg/validator"
 )
 
+// usd builds a US dollar amount from its decimal representation.
+func usd(amount string) domain.Money {
+	return domain.MustParseMoney(amount, domain.DefaultCurrency)
+}
+
+// testCursors signs pagination cursors in tests.
+var testCursors = pagination.NewCodec("test-secret")
+
 // =============================================================================
 // Mock Order Repository
 //
@@ -58,6 +75,30 @@ func (m *MockOrderRepository) FindAll(ctx context.Context, offset, limit int) ([
 	return args.Get(0).([]entity.Order), args.Get(1).(int64), args.Error(2)
 }
 
+func (m *MockOrderRepository) List(ctx context.Context, filter repository.OrderFilter) ([]entity.Order, int64, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, 0, args.Error(2)
+	}
+	return args.Get(0).([]entity.Order), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockOrderRepository) FindPage(ctx context.Context, filter repository.OrderFilter, page repository.KeysetPage) ([]entity.Order, bool, error) {
+	args := m.Called(ctx, filter, page)
+	if args.Get(0) == nil {
+		return nil, false, args.Error(2)
+	}
+	return args.Get(0).([]entity.Order), args.Bool(1), args.Error(2)
+}
+
+func (m *MockOrderRepository) Search(ctx context.Context, text string, offset, limit int) ([]repository.OrderSearchHit, int64, error) {
+	args := m.Called(ctx, text, offset, limit)
+	if args.Get(0) == nil {
+		return nil, 0, args.Error(2)
+	}
+	return args.Get(0).([]repository.OrderSearchHit), args.Get(1).(int64), args.Error(2)
+}
+
 func (m *MockOrderRepository) Update(ctx context.Context, e *entity.Order) error {
 	args := m.Called(ctx, e)
 	return args.Error(0)
@@ -97,6 +138,99 @@ func (m *MockOrderRepository) FindWithItems(ctx context.Context, id uuid.UUID) (
 	return args.Get(0).(*entity.Order), args.Error(1)
 }
 
+// MockOrderitemRepository implements repository.OrderitemRepository for testing.
+type MockOrderitemRepository struct {
+	mock.Mock
+}
+
+func (m *MockOrderitemRepository) Create(ctx context.Context, e *entity.Orderitem) error {
+	args := m.Called(ctx, e)
+	return args.Error(0)
+}
+
+func (m *MockOrderitemRepository) FindByID(ctx context.Context, id uuid.UUID) (*entity.Orderitem, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Orderitem), args.Error(1)
+}
+
+func (m *MockOrderitemRepository) FindAll(ctx context.Context, offset, limit int) ([]entity.Orderitem, int64, error) {
+	args := m.Called(ctx, offset, limit)
+	if args.Get(0) == nil {
+		return nil, 0, args.Error(2)
+	}
+	return args.Get(0).([]entity.Orderitem), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockOrderitemRepository) FindPage(ctx context.Context, page repository.KeysetPage) ([]entity.Orderitem, bool, error) {
+	args := m.Called(ctx, page)
+	if args.Get(0) == nil {
+		return nil, false, args.Error(2)
+	}
+	return args.Get(0).([]entity.Orderitem), args.Bool(1), args.Error(2)
+}
+
+func (m *MockOrderitemRepository) Update(ctx context.Context, e *entity.Orderitem) error {
+	args := m.Called(ctx, e)
+	return args.Error(0)
+}
+
+func (m *MockOrderitemRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockOrderitemRepository) HardDelete(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockOrderitemRepository) FindByOrderID(ctx context.Context, orderID uuid.UUID) ([]entity.Orderitem, error) {
+	args := m.Called(ctx, orderID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entity.Orderitem), args.Error(1)
+}
+
+func (m *MockOrderitemRepository) FindByProductID(ctx context.Context, productID uuid.UUID) ([]entity.Orderitem, error) {
+	args := m.Called(ctx, productID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entity.Orderitem), args.Error(1)
+}
+
+func (m *MockOrderitemRepository) CreateBatch(ctx context.Context, items []entity.Orderitem) error {
+	args := m.Called(ctx, items)
+	return args.Error(0)
+}
+
+func (m *MockOrderitemRepository) DeleteByOrderID(ctx context.Context, orderID uuid.UUID) error {
+	args := m.Called(ctx, orderID)
+	return args.Error(0)
+}
+
+// inlineUnitOfWork runs the work directly, standing in for
+// persistence.NewUnitOfWork, and counts how often it was used.
+type inlineUnitOfWork struct {
+	calls int
+}
+
+func (u *inlineUnitOfWork) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	u.calls++
+	return fn(ctx)
+}
+
+// memoryOutbox implements repository.OutboxRepository, discarding events.
+type memoryOutbox struct{}
+
+func (o *memoryOutbox) Append(_ context.Context, _ ...event.Event) error {
+	return nil
+}
+
 // =============================================================================
 // Mock Handlers for HTTP Handler Tests
 // =============================================================================
@@ -105,14 +239,14 @@ type MockOrderCommandHandler struct {
 	mock.Mock
 }
 
-func (m *MockOrderCommandHandler) HandleOrderCreate(ctx context.Context, cmd *command.CreateOrderCommand) error {
+func (m *MockOrderCommandHandler) HandleOrderCreate(ctx context.Context, cmd *command.CreateOrderCommand) (command.CommandResult, error) {
 	args := m.Called(ctx, cmd)
-	return args.Error(0)
+	return args.Get(0).(command.CommandResult), args.Error(1)
 }
 
-func (m *MockOrderCommandHandler) HandleOrderUpdate(ctx context.Context, cmd *command.UpdateOrderCommand) error {
+func (m *MockOrderCommandHandler) HandleOrderUpdate(ctx context.Context, cmd *command.UpdateOrderCommand) (command.CommandResult, error) {
 	args := m.Called(ctx, cmd)
-	return args.Error(0)
+	return args.Get(0).(command.CommandResult), args.Error(1)
 }
 
 func (m *MockOrderCommandHandler) HandleOrderDelete(ctx context.Context, cmd *command.DeleteOrderCommand) error {
@@ -144,6 +278,25 @@ func (m *MockOrderQueryHandler) HandleOrderGetAll(ctx context.Context, qry *quer
 // Order HTTP Handler Tests
 // =============================================================================
 
+// newOrderHandler builds an OrderHandler dispatching through validating
+// buses to cmdHandler and qryHandler
+func newOrderHandler(cmdHandler *apphandler.OrderCommandHandler, qryHandler *apphandler.OrderQueryHandler) *httphandler.OrderHandler {
+	commands := bus.NewCommandBus(bus.Validation())
+	queries := bus.NewQueryBus(bus.Validation())
+	cmdHandler.Register(commands)
+	qryHandler.Register(queries)
+	return httphandler.NewOrderHandler(commands, queries)
+}
+
+// expectReadBack makes FindWithItems return the order passed to create,
+// as the handlers read back the orders they create
+func expectReadBack(mockRepo *MockOrderRepository, create *mock.Call) {
+	find := mockRepo.On("FindWithItems", mock.Anything, mock.Anything)
+	create.Run(func(args mock.Arguments) {
+		find.Return(args.Get(1).(*entity.Order), nil)
+	})
+}
+
 func setupOrderHandlerTest() (*echo.Echo, *MockOrderRepository) {
 	e := echo.New()
 	e.Validator = validator.NewEchoValidator()
@@ -154,10 +307,10 @@ func setupOrderHandlerTest() (*echo.Echo, *MockOrderRepository) {
 func TestNewOrderHandler(t *testing.T) {
 	t.Run("creates handler with dependencies", func(t *testing.T) {
 		mockRepo := new(MockOrderRepository)
-		cmdHandler := apphandler.NewOrderCommandHandler(mockRepo)
-		qryHandler := apphandler.NewOrderQueryHandler(mockRepo)
+		cmdHandler := apphandler.NewOrderCommandHandler(mockRepo, new(MockOrderitemRepository), new(inlineUnitOfWork), new(memoryOutbox), eventbus.NewDispatcher())
+		qryHandler := apphandler.NewOrderQueryHandler(mockRepo, testCursors)
 
-		h := httphandler.NewOrderHandler(cmdHandler, qryHandler)
+		h := newOrderHandler(cmdHandler, qryHandler)
 
 		require.NotNil(t, h)
 	})
@@ -167,33 +320,73 @@ func TestOrderHandler_Create(t *testing.T) {
 	t.Run("successfully creates order", func(t *testing.T) {
 		e, mockRepo := setupOrderHandlerTest()
 
-		cmdHandler := apphandler.NewOrderCommandHandler(mockRepo)
-		qryHandler := apphandler.NewOrderQueryHandler(mockRepo)
-		h := httphandler.NewOrderHandler(cmdHandler, qryHandler)
+		cmdHandler := apphandler.NewOrderCommandHandler(mockRepo, new(MockOrderitemRepository), new(inlineUnitOfWork), new(memoryOutbox), eventbus.NewDispatcher())
+		qryHandler := apphandler.NewOrderQueryHandler(mockRepo, testCursors)
+		h := newOrderHandler(cmdHandler, qryHandler)
 
 		customerID := uuid.New()
-		reqBody := `{"customer_id":"` + customerID.String() + `","total":100.50,"status":"pending"}`
+		reqBody := `{"customer_id":"` + customerID.String() + `","shipping":15.50,"total":15.50,"status":"pending"}`
 
 		req := httptest.NewRequest(http.MethodPost, "/orders", strings.NewReader(reqBody))
 		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
 		rec := httptest.NewRecorder()
 		c := e.NewContext(req, rec)
 
-		mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*entity.Order")).Return(nil)
+		expectReadBack(mockRepo, mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*entity.Order")).Return(nil))
 
 		err := h.Create(c)
 
 		assert.NoError(t, err)
 		assert.Equal(t, http.StatusCreated, rec.Code)
+
+		var body struct {
+			Data dto.OrderResponse `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+		assert.NotEqual(t, uuid.Nil, body.Data.ID)
+		assert.Equal(t, customerID, body.Data.CustomerID)
+		assert.Equal(t, "/orders/"+body.Data.ID.String(), rec.Header().Get(echo.HeaderLocation))
+		assert.Equal(t, `"1"`, rec.Header().Get("ETag"))
 		mockRepo.AssertExpectations(t)
 	})
 
+	t.Run("returns no body when the client prefers a minimal response", func(t *testing.T) {
+		e, mockRepo := setupOrderHandlerTest()
+
+		cmdHandler := apphandler.NewOrderCommandHandler(mockRepo, new(MockOrderitemRepository), new(inlineUnitOfWork), new(memoryOutbox), eventbus.NewDispatcher())
+		qryHandler := apphandler.NewOrderQueryHandler(mockRepo, testCursors)
+		h := newOrderHandler(cmdHandler, qryHandler)
+
+		reqBody := `{"customer_id":"` + uuid.NewString() + `","status":"pending"}`
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/orders", strings.NewReader(reqBody))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		req.Header.Set("Prefer", "respond-async, return=minimal")
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		var created *entity.Order
+		mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*entity.Order")).
+			Run(func(args mock.Arguments) { created = args.Get(1).(*entity.Order) }).
+			Return(nil)
+
+		err := h.Create(c)
+
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusCreated, rec.Code)
+		assert.Empty(t, rec.Body.String())
+		assert.Equal(t, "return=minimal", rec.Header().Get("Preference-Applied"))
+		assert.Equal(t, "/api/v1/orders/"+created.ID.String(), rec.Header().Get(echo.HeaderLocation))
+		assert.Equal(t, `"1"`, rec.Header().Get("ETag"))
+		mockRepo.AssertNotCalled(t, "FindWithItems", mock.Anything, mock.Anything)
+	})
+
 	t.Run("returns 400 for invalid JSON", func(t *testing.T) {
 		e, mockRepo := setupOrderHandlerTest()
 
-		cmdHandler := apphandler.NewOrderCommandHandler(mockRepo)
-		qryHandler := apphandler.NewOrderQueryHandler(mockRepo)
-		h := httphandler.NewOrderHandler(cmdHandler, qryHandler)
+		cmdHandler := apphandler.NewOrderCommandHandler(mockRepo, new(MockOrderitemRepository), new(inlineUnitOfWork), new(memoryOutbox), eventbus.NewDispatcher())
+		qryHandler := apphandler.NewOrderQueryHandler(mockRepo, testCursors)
+		h := newOrderHandler(cmdHandler, qryHandler)
 
 		req := httptest.NewRequest(http.MethodPost, "/orders", strings.NewReader("invalid json"))
 		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
@@ -209,12 +402,12 @@ func TestOrderHandler_Create(t *testing.T) {
 	t.Run("returns 400 for validation error", func(t *testing.T) {
 		e, mockRepo := setupOrderHandlerTest()
 
-		cmdHandler := apphandler.NewOrderCommandHandler(mockRepo)
-		qryHandler := apphandler.NewOrderQueryHandler(mockRepo)
-		h := httphandler.NewOrderHandler(cmdHandler, qryHandler)
+		cmdHandler := apphandler.NewOrderCommandHandler(mockRepo, new(MockOrderitemRepository), new(inlineUnitOfWork), new(memoryOutbox), eventbus.NewDispatcher())
+		qryHandler := apphandler.NewOrderQueryHandler(mockRepo, testCursors)
+		h := newOrderHandler(cmdHandler, qryHandler)
 
 		// Missing required fields
-		reqBody := `{"total":100.50}`
+		reqBody := `{"shipping":15.50}`
 
 		req := httptest.NewRequest(http.MethodPost, "/orders", strings.NewReader(reqBody))
 		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
@@ -230,12 +423,12 @@ func TestOrderHandler_Create(t *testing.T) {
 	t.Run("returns 500 on repository error", func(t *testing.T) {
 		e, mockRepo := setupOrderHandlerTest()
 
-		cmdHandler := apphandler.NewOrderCommandHandler(mockRepo)
-		qryHandler := apphandler.NewOrderQueryHandler(mockRepo)
-		h := httphandler.NewOrderHandler(cmdHandler, qryHandler)
+		cmdHandler := apphandler.NewOrderCommandHandler(mockRepo, new(MockOrderitemRepository), new(inlineUnitOfWork), new(memoryOutbox), eventbus.NewDispatcher())
+		qryHandler := apphandler.NewOrderQueryHandler(mockRepo, testCursors)
+		h := newOrderHandler(cmdHandler, qryHandler)
 
 		customerID := uuid.New()
-		reqBody := `{"customer_id":"` + customerID.String() + `","total":100.50,"status":"pending"}`
+		reqBody := `{"customer_id":"` + customerID.String() + `","shipping":15.50,"total":15.50,"status":"pending"}`
 
 		req := httptest.NewRequest(http.MethodPost, "/orders", strings.NewReader(reqBody))
 		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
@@ -250,255 +443,1216 @@ func TestOrderHandler_Create(t *testing.T) {
 		assert.Equal(t, http.StatusInternalServerError, rec.Code)
 		mockRepo.AssertExpectations(t)
 	})
-}
 
-func TestOrderHandler_GetByID(t *testing.T) {
-	t.Run("successfully gets order", func(t *testing.T) {
+	t.Run("returns 422 when total disagrees with computed total", func(t *testing.T) {
 		e, mockRepo := setupOrderHandlerTest()
 
-		cmdHandler := apphandler.NewOrderCommandHandler(mockRepo)
-		qryHandler := apphandler.NewOrderQueryHandler(mockRepo)
-		h := httphandler.NewOrderHandler(cmdHandler, qryHandler)
+		cmdHandler := apphandler.NewOrderCommandHandler(mockRepo, new(MockOrderitemRepository), new(inlineUnitOfWork), new(memoryOutbox), eventbus.NewDispatcher())
+		qryHandler := apphandler.NewOrderQueryHandler(mockRepo, testCursors)
+		h := newOrderHandler(cmdHandler, qryHandler)
 
-		orderID := uuid.New()
 		customerID := uuid.New()
-		order := entity.NewOrder(customerID, 100.0, "pending")
-		order.ID = orderID
+		reqBody := `{"customer_id":"` + customerID.String() + `","shipping":15.50,"total":100.50,"status":"pending"}`
 
-		req := httptest.NewRequest(http.MethodGet, "/orders/"+orderID.String(), nil)
+		req := httptest.NewRequest(http.MethodPost, "/orders", strings.NewReader(reqBody))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
 		rec := httptest.NewRecorder()
 		c := e.NewContext(req, rec)
-		c.SetParamNames("id")
-		c.SetParamValues(orderID.String())
 
-		mockRepo.On("FindByID", mock.Anything, orderID).Return(order, nil)
+		err := h.Create(c)
 
-		err := h.GetByID(c)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+		mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("accepts string amounts in the requested currency", func(t *testing.T) {
+		e, mockRepo := setupOrderHandlerTest()
+
+		cmdHandler := apphandler.NewOrderCommandHandler(mockRepo, new(MockOrderitemRepository), new(inlineUnitOfWork), new(memoryOutbox), eventbus.NewDispatcher())
+		qryHandler := apphandler.NewOrderQueryHandler(mockRepo, testCursors)
+		h := newOrderHandler(cmdHandler, qryHandler)
+
+		customerID := uuid.New()
+		reqBody := `{"customer_id":"` + customerID.String() + `","currency":"EUR","shipping":"0.10","tax":"0.20","total":"0.30","status":"pending"}`
+
+		req := httptest.NewRequest(http.MethodPost, "/orders", strings.NewReader(reqBody))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		expectReadBack(mockRepo, mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(o *entity.Order) bool {
+			return o.Currency == "EUR" && o.Total.Equal(domain.MustParseMoney("0.30", "EUR"))
+		})).Return(nil))
+
+		err := h.Create(c)
 
 		assert.NoError(t, err)
-		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, http.StatusCreated, rec.Code)
 		mockRepo.AssertExpectations(t)
 	})
 
-	t.Run("returns 400 for invalid UUID", func(t *testing.T) {
+	t.Run("creates order with items and returns the aggregate", func(t *testing.T) {
 		e, mockRepo := setupOrderHandlerTest()
+		mockItemRepo := new(MockOrderitemRepository)
 
-		cmdHandler := apphandler.NewOrderCommandHandler(mockRepo)
-		qryHandler := apphandler.NewOrderQueryHandler(mockRepo)
-		h := httphandler.NewOrderHandler(cmdHandler, qryHandler)
+		cmdHandler := apphandler.NewOrderCommandHandler(mockRepo, mockItemRepo, new(inlineUnitOfWork), new(memoryOutbox), eventbus.NewDispatcher())
+		qryHandler := apphandler.NewOrderQueryHandler(mockRepo, testCursors)
+		h := newOrderHandler(cmdHandler, qryHandler)
 
-		req := httptest.NewRequest(http.MethodGet, "/orders/invalid-uuid", nil)
+		customerID := uuid.New()
+		productID := uuid.New()
+		reqBody := `{"customer_id":"` + customerID.String() + `","status":"pending","items":[{"product_id":"` + productID.String() + `","quantity":3,"price":"2.50"}]}`
+
+		req := httptest.NewRequest(http.MethodPost, "/orders", strings.NewReader(reqBody))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
 		rec := httptest.NewRecorder()
 		c := e.NewContext(req, rec)
-		c.SetParamNames("id")
-		c.SetParamValues("invalid-uuid")
 
-		err := h.GetByID(c)
+		expectReadBack(mockRepo, mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*entity.Order")).Return(nil))
+		mockItemRepo.On("CreateBatch", mock.Anything, mock.Anything).Return(nil)
+
+		err := h.Create(c)
+
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusCreated, rec.Code)
+
+		var body struct {
+			Data dto.OrderResponse `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+		assert.Equal(t, "7.50", body.Data.Total.String())
+		require.Len(t, body.Data.Items, 1)
+		assert.Equal(t, productID, body.Data.Items[0].ProductID)
+		assert.Equal(t, body.Data.ID, body.Data.Items[0].OrderID)
+		mockItemRepo.AssertExpectations(t)
+	})
+
+	t.Run("returns 400 for an invalid item", func(t *testing.T) {
+		e, mockRepo := setupOrderHandlerTest()
+
+		cmdHandler := apphandler.NewOrderCommandHandler(mockRepo, new(MockOrderitemRepository), new(inlineUnitOfWork), new(memoryOutbox), eventbus.NewDispatcher())
+		qryHandler := apphandler.NewOrderQueryHandler(mockRepo, testCursors)
+		h := newOrderHandler(cmdHandler, qryHandler)
+
+		customerID := uuid.New()
+		reqBody := `{"customer_id":"` + customerID.String() + `","status":"pending","items":[{"quantity":1,"price":"2.50"}]}`
+
+		req := httptest.NewRequest(http.MethodPost, "/orders", strings.NewReader(reqBody))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		err := h.Create(c)
 
 		assert.NoError(t, err)
 		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
 	})
 
-	t.Run("returns 404 when not found", func(t *testing.T) {
+	t.Run("returns 400 when the order skips the state machine", func(t *testing.T) {
 		e, mockRepo := setupOrderHandlerTest()
 
-		cmdHandler := apphandler.NewOrderCommandHandler(mockRepo)
-		qryHandler := apphandler.NewOrderQueryHandler(mockRepo)
-		h := httphandler.NewOrderHandler(cmdHandler, qryHandler)
+		cmdHandler := apphandler.NewOrderCommandHandler(mockRepo, new(MockOrderitemRepository), new(inlineUnitOfWork), new(memoryOutbox), eventbus.NewDispatcher())
+		qryHandler := apphandler.NewOrderQueryHandler(mockRepo, testCursors)
+		h := newOrderHandler(cmdHandler, qryHandler)
 
-		orderID := uuid.New()
+		reqBody := `{"customer_id":"` + uuid.NewString() + `","status":"delivered"}`
 
-		req := httptest.NewRequest(http.MethodGet, "/orders/"+orderID.String(), nil)
+		req := httptest.NewRequest(http.MethodPost, "/orders", strings.NewReader(reqBody))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
 		rec := httptest.NewRecorder()
 		c := e.NewContext(req, rec)
-		c.SetParamNames("id")
-		c.SetParamValues(orderID.String())
 
-		mockRepo.On("FindByID", mock.Anything, orderID).Return(nil, errors.New("not found"))
-
-		err := h.GetByID(c)
+		err := h.Create(c)
 
 		assert.NoError(t, err)
-		assert.Equal(t, http.StatusNotFound, rec.Code)
-		mockRepo.AssertExpectations(t)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+		var body response.Response
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+		require.NotNil(t, body.Error)
+		assert.Contains(t, body.Error.Details, "status")
+		mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
 	})
-}
 
-func TestOrderHandler_List(t *testing.T) {
-	t.Run("successfully lists orders", func(t *testing.T) {
+	t.Run("creates pending orders without a status", func(t *testing.T) {
 		e, mockRepo := setupOrderHandlerTest()
 
-		cmdHandler := apphandler.NewOrderCommandHandler(mockRepo)
-		qryHandler := apphandler.NewOrderQueryHandler(mockRepo)
-		h := httphandler.NewOrderHandler(cmdHandler, qryHandler)
+		cmdHandler := apphandler.NewOrderCommandHandler(mockRepo, new(MockOrderitemRepository), new(inlineUnitOfWork), new(memoryOutbox), eventbus.NewDispatcher())
+		qryHandler := apphandler.NewOrderQueryHandler(mockRepo, testCursors)
+		h := newOrderHandler(cmdHandler, qryHandler)
 
-		orders := []entity.Order{
-			*entity.NewOrder(uuid.New(), 100.0, "pending"),
-			*entity.NewOrder(uuid.New(), 200.0, "confirmed"),
-		}
+		reqBody := `{"customer_id":"` + uuid.NewString() + `"}`
 
-		req := httptest.NewRequest(http.MethodGet, "/orders?offset=0&limit=10", nil)
+		req := httptest.NewRequest(http.MethodPost, "/orders", strings.NewReader(reqBody))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
 		rec := httptest.NewRecorder()
 		c := e.NewContext(req, rec)
 
-		mockRepo.On("FindAll", mock.Anything, mock.Anything, mock.Anything).Return(orders, int64(2), nil)
+		create := mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(o *entity.Order) bool {
+			return o.Status == entity.OrderStatusPending
+		})).Return(nil)
+		expectReadBack(mockRepo, create)
 
-		err := h.List(c)
+		err := h.Create(c)
 
 		assert.NoError(t, err)
-		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, http.StatusCreated, rec.Code)
 		mockRepo.AssertExpectations(t)
 	})
 
-	t.Run("returns 500 on repository error", func(t *testing.T) {
+	t.Run("returns 400 with field details for a negative price and non-pending status", func(t *testing.T) {
 		e, mockRepo := setupOrderHandlerTest()
 
-		cmdHandler := apphandler.NewOrderCommandHandler(mockRepo)
-		qryHandler := apphandler.NewOrderQueryHandler(mockRepo)
-		h := httphandler.NewOrderHandler(cmdHandler, qryHandler)
+		cmdHandler := apphandler.NewOrderCommandHandler(mockRepo, new(MockOrderitemRepository), new(inlineUnitOfWork), new(memoryOutbox), eventbus.NewDispatcher())
+		qryHandler := apphandler.NewOrderQueryHandler(mockRepo, testCursors)
+		h := newOrderHandler(cmdHandler, qryHandler)
 
-		req := httptest.NewRequest(http.MethodGet, "/orders", nil)
+		reqBody := `{"customer_id":"` + uuid.NewString() + `","status":"archived","items":[{"product_id":"` + uuid.NewString() + `","quantity":1,"price":"-2.50"}]}`
+
+		req := httptest.NewRequest(http.MethodPost, "/orders", strings.NewReader(reqBody))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
 		rec := httptest.NewRecorder()
 		c := e.NewContext(req, rec)
 
-		mockRepo.On("FindAll", mock.Anything, mock.Anything, mock.Anything).Return(nil, int64(0), errors.New("database error"))
-
-		err := h.List(c)
+		err := h.Create(c)
 
 		assert.NoError(t, err)
-		assert.Equal(t, http.StatusInternalServerError, rec.Code)
-		mockRepo.AssertExpectations(t)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+		var body response.Response
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+		require.NotNil(t, body.Error)
+		assert.Equal(t, "VALIDATION_ERROR", body.Error.Code)
+		assert.Equal(t, map[string]string{
+			"items[0].price": "Must not be negative",
+			"status":         "New orders must be pending; use the transition endpoints",
+		}, body.Error.Details)
+		mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
 	})
-}
 
-func TestOrderHandler_Update(t *testing.T) {
-	t.Run("successfully updates order", func(t *testing.T) {
+	t.Run("returns 400 with field details for a zero quantity", func(t *testing.T) {
 		e, mockRepo := setupOrderHandlerTest()
 
-		cmdHandler := apphandler.NewOrderCommandHandler(mockRepo)
-		qryHandler := apphandler.NewOrderQueryHandler(mockRepo)
-		h := httphandler.NewOrderHandler(cmdHandler, qryHandler)
+		cmdHandler := apphandler.NewOrderCommandHandler(mockRepo, new(MockOrderitemRepository), new(inlineUnitOfWork), new(memoryOutbox), eventbus.NewDispatcher())
+		qryHandler := apphandler.NewOrderQueryHandler(mockRepo, testCursors)
+		h := newOrderHandler(cmdHandler, qryHandler)
 
-		orderID := uuid.New()
-		customerID := uuid.New()
-		reqBody := `{"customer_id":"` + customerID.String() + `","total":150.00,"status":"confirmed"}`
+		reqBody := `{"customer_id":"` + uuid.NewString() + `","status":"pending","items":[{"product_id":"` + uuid.NewString() + `","quantity":0,"price":"2.50"}]}`
 
-		req := httptest.NewRequest(http.MethodPut, "/orders/"+orderID.String(), strings.NewReader(reqBody))
+		req := httptest.NewRequest(http.MethodPost, "/orders", strings.NewReader(reqBody))
 		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
 		rec := httptest.NewRecorder()
 		c := e.NewContext(req, rec)
-		c.SetParamNames("id")
-		c.SetParamValues(orderID.String())
 
-		mockRepo.On("Update", mock.Anything, mock.AnythingOfType("*entity.Order")).Return(nil)
-
-		err := h.Update(c)
+		err := h.Create(c)
 
 		assert.NoError(t, err)
-		assert.Equal(t, http.StatusOK, rec.Code)
-		mockRepo.AssertExpectations(t)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+		var body response.Response
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+		require.NotNil(t, body.Error)
+		assert.Equal(t, "Must be greater than or equal to 1", body.Error.Details["quantity"])
+		mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
 	})
 
-	t.Run("returns 400 for invalid UUID", func(t *testing.T) {
+	t.Run("returns 400 with field details for an invalid currency", func(t *testing.T) {
 		e, mockRepo := setupOrderHandlerTest()
 
-		cmdHandler := apphandler.NewOrderCommandHandler(mockRepo)
-		qryHandler := apphandler.NewOrderQueryHandler(mockRepo)
-		h := httphandler.NewOrderHandler(cmdHandler, qryHandler)
+		cmdHandler := apphandler.NewOrderCommandHandler(mockRepo, new(MockOrderitemRepository), new(inlineUnitOfWork), new(memoryOutbox), eventbus.NewDispatcher())
+		qryHandler := apphandler.NewOrderQueryHandler(mockRepo, testCursors)
+		h := newOrderHandler(cmdHandler, qryHandler)
 
-		req := httptest.NewRequest(http.MethodPut, "/orders/invalid-uuid", strings.NewReader(`{}`))
+		customerID := uuid.New()
+		reqBody := `{"customer_id":"` + customerID.String() + `","currency":"eur","status":"pending"}`
+
+		req := httptest.NewRequest(http.MethodPost, "/orders", strings.NewReader(reqBody))
 		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
 		rec := httptest.NewRecorder()
 		c := e.NewContext(req, rec)
-		c.SetParamNames("id")
-		c.SetParamValues("invalid-uuid")
 
-		err := h.Update(c)
+		err := h.Create(c)
 
 		assert.NoError(t, err)
 		assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+		var body response.Response
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+		require.NotNil(t, body.Error)
+		assert.Equal(t, "VALIDATION_ERROR", body.Error.Code)
+		assert.Contains(t, body.Error.Details, "currency")
+		mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
 	})
 }
 
-func TestOrderHandler_Delete(t *testing.T) {
-	t.Run("successfully deletes order", func(t *testing.T) {
+func TestOrderHandler_GetByID(t *testing.T) {
+	t.Run("successfully gets order", func(t *testing.T) {
 		e, mockRepo := setupOrderHandlerTest()
 
-		cmdHandler := apphandler.NewOrderCommandHandler(mockRepo)
-		qryHandler := apphandler.NewOrderQueryHandler(mockRepo)
-		h := httphandler.NewOrderHandler(cmdHandler, qryHandler)
+		cmdHandler := apphandler.NewOrderCommandHandler(mockRepo, new(MockOrderitemRepository), new(inlineUnitOfWork), new(memoryOutbox), eventbus.NewDispatcher())
+		qryHandler := apphandler.NewOrderQueryHandler(mockRepo, testCursors)
+		h := newOrderHandler(cmdHandler, qryHandler)
 
 		orderID := uuid.New()
+		customerID := uuid.New()
+		order := entity.NewOrder(customerID, usd("100.00"), "pending")
+		order.ID = orderID
 
-		req := httptest.NewRequest(http.MethodDelete, "/orders/"+orderID.String(), nil)
+		req := httptest.NewRequest(http.MethodGet, "/orders/"+orderID.String(), nil)
 		rec := httptest.NewRecorder()
 		c := e.NewContext(req, rec)
 		c.SetParamNames("id")
 		c.SetParamValues(orderID.String())
 
-		mockRepo.On("Delete", mock.Anything, orderID).Return(nil)
+		mockRepo.On("FindByID", mock.Anything, orderID).Return(order, nil)
 
-		err := h.Delete(c)
+		err := h.GetByID(c)
 
 		assert.NoError(t, err)
-		assert.Equal(t, http.StatusNoContent, rec.Code)
+		assert.Equal(t, http.StatusOK, rec.Code)
 		mockRepo.AssertExpectations(t)
 	})
 
 	t.Run("returns 400 for invalid UUID", func(t *testing.T) {
 		e, mockRepo := setupOrderHandlerTest()
 
-		cmdHandler := apphandler.NewOrderCommandHandler(mockRepo)
-		qryHandler := apphandler.NewOrderQueryHandler(mockRepo)
-		h := httphandler.NewOrderHandler(cmdHandler, qryHandler)
+		cmdHandler := apphandler.NewOrderCommandHandler(mockRepo, new(MockOrderitemRepository), new(inlineUnitOfWork), new(memoryOutbox), eventbus.NewDispatcher())
+		qryHandler := apphandler.NewOrderQueryHandler(mockRepo, testCursors)
+		h := newOrderHandler(cmdHandler, qryHandler)
 
-		req := httptest.NewRequest(http.MethodDelete, "/orders/invalid-uuid", nil)
+		req := httptest.NewRequest(http.MethodGet, "/orders/invalid-uuid", nil)
 		rec := httptest.NewRecorder()
 		c := e.NewContext(req, rec)
 		c.SetParamNames("id")
 		c.SetParamValues("invalid-uuid")
 
-		err := h.Delete(c)
+		err := h.GetByID(c)
 
 		assert.NoError(t, err)
 		assert.Equal(t, http.StatusBadRequest, rec.Code)
 	})
 
-	t.Run("returns 500 on repository error", func(t *testing.T) {
+	t.Run("returns 404 when not found", func(t *testing.T) {
 		e, mockRepo := setupOrderHandlerTest()
 
-		cmdHandler := apphandler.NewOrderCommandHandler(mockRepo)
-		qryHandler := apphandler.NewOrderQueryHandler(mockRepo)
-		h := httphandler.NewOrderHandler(cmdHandler, qryHandler)
+		cmdHandler := apphandler.NewOrderCommandHandler(mockRepo, new(MockOrderitemRepository), new(inlineUnitOfWork), new(memoryOutbox), eventbus.NewDispatcher())
+		qryHandler := apphandler.NewOrderQueryHandler(mockRepo, testCursors)
+		h := newOrderHandler(cmdHandler, qryHandler)
 
 		orderID := uuid.New()
 
-		req := httptest.NewRequest(http.MethodDelete, "/orders/"+orderID.String(), nil)
+		req := httptest.NewRequest(http.MethodGet, "/orders/"+orderID.String(), nil)
 		rec := httptest.NewRecorder()
 		c := e.NewContext(req, rec)
 		c.SetParamNames("id")
 		c.SetParamValues(orderID.String())
 
-		mockRepo.On("Delete", mock.Anything, orderID).Return(errors.New("delete failed"))
+		mockRepo.On("FindByID", mock.Anything, orderID).Return(nil, domain.ErrEntityNotFound)
 
-		err := h.Delete(c)
+		err := h.GetByID(c)
 
 		assert.NoError(t, err)
-		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+		assert.Equal(t, http.StatusNotFound, rec.Code)
 		mockRepo.AssertExpectations(t)
 	})
-}
 
-func TestOrderHandler_RegisterRoutes(t *testing.T) {
-	t.Run("registers all routes", func(t *testing.T) {
-		e := echo.New()
-		mockRepo := new(MockOrderRepository)
-		cmdHandler := apphandler.NewOrderCommandHandler(mockRepo)
-		qryHandler := apphandler.NewOrderQueryHandler(mockRepo)
-		h := httphandler.NewOrderHandler(cmdHandler, qryHandler)
+	t.Run("returns 500 without leaking repository errors", func(t *testing.T) {
+		e, mockRepo := setupOrderHandlerTest()
 
-		g := e.Group("/api/v1")
-		h.RegisterRoutes(g)
+		cmdHandler := apphandler.NewOrderCommandHandler(mockRepo, new(MockOrderitemRepository), new(inlineUnitOfWork), new(memoryOutbox), eventbus.NewDispatcher())
+		qryHandler := apphandler.NewOrderQueryHandler(mockRepo, testCursors)
+		h := newOrderHandler(cmdHandler, qryHandler)
+
+		orderID := uuid.New()
+
+		req := httptest.NewRequest(http.MethodGet, "/orders/"+orderID.String(), nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames("id")
+		c.SetParamValues(orderID.String())
+
+		mockRepo.On("FindByID", mock.Anything, orderID).Return(nil, errors.New("dial tcp 10.0.0.5:5432: connection refused"))
+
+		err := h.GetByID(c)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+		assert.NotContains(t, rec.Body.String(), "10.0.0.5")
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("expands items", func(t *testing.T) {
+		e, mockRepo := setupOrderHandlerTest()
+
+		cmdHandler := apphandler.NewOrderCommandHandler(mockRepo, new(MockOrderitemRepository), new(inlineUnitOfWork), new(memoryOutbox), eventbus.NewDispatcher())
+		qryHandler := apphandler.NewOrderQueryHandler(mockRepo, testCursors)
+		h := newOrderHandler(cmdHandler, qryHandler)
+
+		order := entity.NewOrder(uuid.New(), usd("20.00"), "pending")
+		order.Items = []entity.Orderitem{*entity.NewOrderitem(order.ID, uuid.New(), 2, usd("10.00"))}
+
+		req := httptest.NewRequest(http.MethodGet, "/orders/"+order.ID.String()+"?expand=items", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames("id")
+		c.SetParamValues(order.ID.String())
+
+		mockRepo.On("FindWithItems", mock.Anything, order.ID).Return(order, nil)
+
+		err := h.GetByID(c)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		var body struct {
+			Data dto.OrderResponse `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+		assert.Len(t, body.Data.Items, 1)
+		mockRepo.AssertExpectations(t)
+		mockRepo.AssertNotCalled(t, "FindByID", mock.Anything, mock.Anything)
+	})
+
+	t.Run("returns 400 for unsupported expand", func(t *testing.T) {
+		e, mockRepo := setupOrderHandlerTest()
+
+		cmdHandler := apphandler.NewOrderCommandHandler(mockRepo, new(MockOrderitemRepository), new(inlineUnitOfWork), new(memoryOutbox), eventbus.NewDispatcher())
+		qryHandler := apphandler.NewOrderQueryHandler(mockRepo, testCursors)
+		h := newOrderHandler(cmdHandler, qryHandler)
+
+		orderID := uuid.New()
+
+		req := httptest.NewRequest(http.MethodGet, "/orders/"+orderID.String()+"?expand=customer", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames("id")
+		c.SetParamValues(orderID.String())
+
+		err := h.GetByID(c)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Contains(t, rec.Body.String(), "unsupported expand")
+		mockRepo.AssertNotCalled(t, "FindByID", mock.Anything, mock.Anything)
+	})
+}
+
+func TestOrderHandler_List(t *testing.T) {
+	t.Run("successfully lists orders", func(t *testing.T) {
+		e, mockRepo := setupOrderHandlerTest()
+
+		cmdHandler := apphandler.NewOrderCommandHandler(mockRepo, new(MockOrderitemRepository), new(inlineUnitOfWork), new(memoryOutbox), eventbus.NewDispatcher())
+		qryHandler := apphandler.NewOrderQueryHandler(mockRepo, testCursors)
+		h := newOrderHandler(cmdHandler, qryHandler)
+
+		orders := []entity.Order{
+			*entity.NewOrder(uuid.New(), usd("100.00"), "pending"),
+			*entity.NewOrder(uuid.New(), usd("200.00"), "confirmed"),
+		}
+
+		req := httptest.NewRequest(http.MethodGet, "/orders?offset=0&limit=10", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		mockRepo.On("List", mock.Anything, mock.Anything).Return(orders, int64(2), nil)
+
+		err := h.List(c)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("returns 500 on repository error", func(t *testing.T) {
+		e, mockRepo := setupOrderHandlerTest()
+
+		cmdHandler := apphandler.NewOrderCommandHandler(mockRepo, new(MockOrderitemRepository), new(inlineUnitOfWork), new(memoryOutbox), eventbus.NewDispatcher())
+		qryHandler := apphandler.NewOrderQueryHandler(mockRepo, testCursors)
+		h := newOrderHandler(cmdHandler, qryHandler)
+
+		req := httptest.NewRequest(http.MethodGet, "/orders", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		mockRepo.On("List", mock.Anything, mock.Anything).Return(nil, int64(0), errors.New("database error"))
+
+		err := h.List(c)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestOrderHandler_ListFilters(t *testing.T) {
+	t.Run("passes filters to the repository", func(t *testing.T) {
+		e, mockRepo := setupOrderHandlerTest()
+		h := newOrderHandler(apphandler.NewOrderCommandHandler(mockRepo, new(MockOrderitemRepository), new(inlineUnitOfWork), new(memoryOutbox), eventbus.NewDispatcher()), apphandler.NewOrderQueryHandler(mockRepo, testCursors))
+
+		customerID := uuid.New()
+		req := httptest.NewRequest(http.MethodGet, "/orders?status=pending,paid&status=shipped&customer_id="+customerID.String()+
+			"&created_from=2026-01-01T00:00:00Z&min_total=10.50&max_total=99&search=abc&sort_by=total&sort_dir=asc", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		mockRepo.On("List", mock.Anything, mock.MatchedBy(func(f repository.OrderFilter) bool {
+			return assert.ObjectsAreEqual([]string{"pending", "paid", "shipped"}, f.Statuses) &&
+				f.CustomerID != nil && *f.CustomerID == customerID &&
+				f.CreatedFrom != nil && f.CreatedFrom.Equal(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)) &&
+				f.CreatedTo == nil &&
+				f.MinTotal != nil && f.MinTotal.String() == "10.50" &&
+				f.MaxTotal != nil && f.MaxTotal.String() == "99.00" &&
+				f.Search == "abc" &&
+				f.Sort == repository.Sort{Field: "total", Direction: repository.SortAsc}
+		})).Return([]entity.Order{}, int64(0), nil)
+
+		err := h.List(c)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		mockRepo.AssertExpectations(t)
+	})
+
+	for name, rawQuery := range map[string]string{
+		"unsupported sort field": "sort_by=password",
+		"malformed customer id":  "customer_id=not-a-uuid",
+		"malformed total":        "min_total=abc",
+		"inverted total range":   "min_total=100&max_total=10",
+	} {
+		t.Run("returns 400 for "+name, func(t *testing.T) {
+			e, mockRepo := setupOrderHandlerTest()
+			h := newOrderHandler(apphandler.NewOrderCommandHandler(mockRepo, new(MockOrderitemRepository), new(inlineUnitOfWork), new(memoryOutbox), eventbus.NewDispatcher()), apphandler.NewOrderQueryHandler(mockRepo, testCursors))
+
+			req := httptest.NewRequest(http.MethodGet, "/orders?"+rawQuery, nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			err := h.List(c)
+
+			assert.NoError(t, err)
+			assert.Equal(t, http.StatusBadRequest, rec.Code)
+			mockRepo.AssertNotCalled(t, "List", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestOrderHandler_ListCursor(t *testing.T) {
+	newHandler := func(mockRepo *MockOrderRepository) *httphandler.OrderHandler {
+		return newOrderHandler(apphandler.NewOrderCommandHandler(mockRepo, new(MockOrderitemRepository), new(inlineUnitOfWork), new(memoryOutbox), eventbus.NewDispatcher()), apphandler.NewOrderQueryHandler(mockRepo, testCursors))
+	}
+
+	t.Run("empty cursor returns the first page with a next cursor", func(t *testing.T) {
+		e, mockRepo := setupOrderHandlerTest()
+		h := newHandler(mockRepo)
+
+		req := httptest.NewRequest(http.MethodGet, "/orders?cursor=&limit=1", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		order := entity.NewOrder(uuid.New(), usd("10.00"), "pending")
+		mockRepo.On("FindPage", mock.Anything, mock.Anything, repository.KeysetPage{Limit: 1, Direction: repository.SortDesc}).
+			Return([]entity.Order{*order}, true, nil)
+
+		err := h.List(c)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		var resp response.Response
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		require.NotNil(t, resp.Meta)
+		assert.NotEmpty(t, resp.Meta.NextCursor)
+		assert.Empty(t, resp.Meta.PrevCursor)
+		assert.Zero(t, resp.Meta.TotalCount)
+		mockRepo.AssertNotCalled(t, "List", mock.Anything, mock.Anything)
+	})
+
+	t.Run("returns 400 for a tampered cursor", func(t *testing.T) {
+		e, mockRepo := setupOrderHandlerTest()
+		h := newHandler(mockRepo)
+
+		req := httptest.NewRequest(http.MethodGet, "/orders?cursor=bogus.token", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		err := h.List(c)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Contains(t, rec.Body.String(), "INVALID_CURSOR")
+	})
+
+	t.Run("returns 400 for a cursor issued for another filter", func(t *testing.T) {
+		e, mockRepo := setupOrderHandlerTest()
+		h := newHandler(mockRepo)
+
+		order := entity.NewOrder(uuid.New(), usd("10.00"), "pending")
+		mockRepo.On("FindPage", mock.Anything, mock.Anything, mock.Anything).Return([]entity.Order{*order}, true, nil)
+		first := httptest.NewRecorder()
+		require.NoError(t, h.List(e.NewContext(httptest.NewRequest(http.MethodGet, "/orders?cursor=&limit=1&status=pending", nil), first)))
+		var resp response.Response
+		require.NoError(t, json.Unmarshal(first.Body.Bytes(), &resp))
+		require.NotEmpty(t, resp.Meta.NextCursor)
+
+		req := httptest.NewRequest(http.MethodGet, "/orders?limit=1&status=shipped&cursor="+resp.Meta.NextCursor, nil)
+		rec := httptest.NewRecorder()
+
+		err := h.List(e.NewContext(req, rec))
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Contains(t, rec.Body.String(), "INVALID_CURSOR")
+		mockRepo.AssertNumberOfCalls(t, "FindPage", 1)
+	})
+
+	t.Run("returns 400 when combined with another sort field", func(t *testing.T) {
+		e, mockRepo := setupOrderHandlerTest()
+		h := newHandler(mockRepo)
+
+		req := httptest.NewRequest(http.MethodGet, "/orders?cursor=&sort_by=total", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		err := h.List(c)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
+
+func TestOrderHandler_Search(t *testing.T) {
+	newHandler := func(mockRepo *MockOrderRepository) *httphandler.OrderHandler {
+		return newOrderHandler(apphandler.NewOrderCommandHandler(mockRepo, new(MockOrderitemRepository), new(inlineUnitOfWork), new(memoryOutbox), eventbus.NewDispatcher()), apphandler.NewOrderQueryHandler(mockRepo, testCursors))
+	}
+
+	t.Run("returns matches in the list envelope", func(t *testing.T) {
+		e, mockRepo := setupOrderHandlerTest()
+		h := newHandler(mockRepo)
+
+		req := httptest.NewRequest(http.MethodGet, "/orders/search?q=%22leave+at+door%22&limit=5", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		order := entity.NewOrder(uuid.New(), usd("10.00"), "pending")
+		order.Annotate(nil, "please leave at door")
+		mockRepo.On("Search", mock.Anything, `"leave at door"`, 0, 5).Return([]repository.OrderSearchHit{{
+			Order:      *order,
+			Rank:       0.1,
+			Highlights: map[string]string{"notes": "please <mark>leave</mark> <mark>at</mark> <mark>door</mark>"},
+		}}, int64(1), nil)
+
+		err := h.Search(c)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		var resp struct {
+			Data struct {
+				Data []struct {
+					ID         uuid.UUID         `json:"id"`
+					Notes      string            `json:"notes"`
+					Rank       float64           `json:"rank"`
+					Highlights map[string]string `json:"highlights"`
+				} `json:"data"`
+				Total int `json:"total"`
+				Limit int `json:"limit"`
+			} `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		require.Len(t, resp.Data.Data, 1)
+		assert.Equal(t, order.ID, resp.Data.Data[0].ID)
+		assert.Equal(t, "please leave at door", resp.Data.Data[0].Notes)
+		assert.Contains(t, resp.Data.Data[0].Highlights["notes"], "<mark>door</mark>")
+		assert.Equal(t, 1, resp.Data.Total)
+		assert.Equal(t, 5, resp.Data.Limit)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("returns 400 without search text", func(t *testing.T) {
+		e, mockRepo := setupOrderHandlerTest()
+		h := newHandler(mockRepo)
+
+		req := httptest.NewRequest(http.MethodGet, "/orders/search?q=++", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		err := h.Search(c)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		mockRepo.AssertNotCalled(t, "Search", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestOrderHandler_Update(t *testing.T) {
+	t.Run("successfully updates order", func(t *testing.T) {
+		e, mockRepo := setupOrderHandlerTest()
+
+		cmdHandler := apphandler.NewOrderCommandHandler(mockRepo, new(MockOrderitemRepository), new(inlineUnitOfWork), new(memoryOutbox), eventbus.NewDispatcher())
+		qryHandler := apphandler.NewOrderQueryHandler(mockRepo, testCursors)
+		h := newOrderHandler(cmdHandler, qryHandler)
+
+		orderID := uuid.New()
+		customerID := uuid.New()
+		reqBody := `{"customer_id":"` + customerID.String() + `","shipping":10.00,"status":"confirmed"}`
+
+		req := httptest.NewRequest(http.MethodPut, "/orders/"+orderID.String(), strings.NewReader(reqBody))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		req.Header.Set("If-Match", `"1"`)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames("id")
+		c.SetParamValues(orderID.String())
+
+		existing := entity.NewOrder(customerID, usd("100.00"), "pending")
+		existing.ID = orderID
+		mockRepo.On("FindWithItems", mock.Anything, orderID).Return(existing, nil)
+		mockRepo.On("Update", mock.Anything, mock.AnythingOfType("*entity.Order")).
+			Run(func(mock.Arguments) { existing.Version++ }).
+			Return(nil)
+		mockRepo.On("FindByID", mock.Anything, orderID).Return(existing, nil)
+
+		err := h.Update(c)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		var body struct {
+			Data dto.OrderResponse `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+		assert.Equal(t, orderID, body.Data.ID)
+		assert.Equal(t, "confirmed", body.Data.Status)
+		assert.Equal(t, int64(2), body.Data.Version)
+		assert.Equal(t, `"2"`, rec.Header().Get("ETag"))
+		assert.Empty(t, rec.Header().Get(echo.HeaderLocation))
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("returns 204 when the client prefers a minimal response", func(t *testing.T) {
+		e, mockRepo := setupOrderHandlerTest()
+
+		cmdHandler := apphandler.NewOrderCommandHandler(mockRepo, new(MockOrderitemRepository), new(inlineUnitOfWork), new(memoryOutbox), eventbus.NewDispatcher())
+		qryHandler := apphandler.NewOrderQueryHandler(mockRepo, testCursors)
+		h := newOrderHandler(cmdHandler, qryHandler)
+
+		existing := entity.NewOrder(uuid.New(), usd("0.00"), "pending")
+		reqBody := `{"customer_id":"` + existing.CustomerID.String() + `","status":"pending","notes":"ring twice"}`
+
+		req := httptest.NewRequest(http.MethodPut, "/orders/"+existing.ID.String(), strings.NewReader(reqBody))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		req.Header.Set("If-Match", `"1"`)
+		req.Header.Set("Prefer", "return=minimal")
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames("id")
+		c.SetParamValues(existing.ID.String())
+
+		mockRepo.On("FindWithItems", mock.Anything, existing.ID).Return(existing, nil)
+		mockRepo.On("Update", mock.Anything, existing).
+			Run(func(mock.Arguments) { existing.Version++ }).
+			Return(nil)
+
+		require.NoError(t, h.Update(c))
+
+		assert.Equal(t, http.StatusNoContent, rec.Code)
+		assert.Equal(t, `"2"`, rec.Header().Get("ETag"))
+		assert.Equal(t, "return=minimal", rec.Header().Get("Preference-Applied"))
+		mockRepo.AssertNotCalled(t, "FindByID", mock.Anything, mock.Anything)
+	})
+
+	t.Run("returns 409 for illegal status transition", func(t *testing.T) {
+		e, mockRepo := setupOrderHandlerTest()
+
+		cmdHandler := apphandler.NewOrderCommandHandler(mockRepo, new(MockOrderitemRepository), new(inlineUnitOfWork), new(memoryOutbox), eventbus.NewDispatcher())
+		qryHandler := apphandler.NewOrderQueryHandler(mockRepo, testCursors)
+		h := newOrderHandler(cmdHandler, qryHandler)
+
+		orderID := uuid.New()
+		customerID := uuid.New()
+		reqBody := `{"customer_id":"` + customerID.String() + `","total":150.00,"status":"delivered"}`
+
+		req := httptest.NewRequest(http.MethodPut, "/orders/"+orderID.String(), strings.NewReader(reqBody))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		req.Header.Set("If-Match", `"1"`)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames("id")
+		c.SetParamValues(orderID.String())
+
+		existing := entity.NewOrder(customerID, usd("100.00"), "pending")
+		existing.ID = orderID
+		mockRepo.On("FindWithItems", mock.Anything, orderID).Return(existing, nil)
+
+		err := h.Update(c)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusConflict, rec.Code)
+		mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+
+	t.Run("returns 400 for invalid UUID", func(t *testing.T) {
+		e, mockRepo := setupOrderHandlerTest()
+
+		cmdHandler := apphandler.NewOrderCommandHandler(mockRepo, new(MockOrderitemRepository), new(inlineUnitOfWork), new(memoryOutbox), eventbus.NewDispatcher())
+		qryHandler := apphandler.NewOrderQueryHandler(mockRepo, testCursors)
+		h := newOrderHandler(cmdHandler, qryHandler)
+
+		req := httptest.NewRequest(http.MethodPut, "/orders/invalid-uuid", strings.NewReader(`{}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames("id")
+		c.SetParamValues("invalid-uuid")
+
+		err := h.Update(c)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
+
+func TestOrderHandler_Patch(t *testing.T) {
+	// patchRequest builds a PATCH of order id with the given media type
+	patchRequest := func(e *echo.Echo, id uuid.UUID, contentType, body string) (echo.Context, *httptest.ResponseRecorder) {
+		req := httptest.NewRequest(http.MethodPatch, "/orders/"+id.String(), strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, contentType)
+		req.Header.Set("If-Match", "*")
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames("id")
+		c.SetParamValues(id.String())
+		return c, rec
+	}
+
+	t.Run("applies a merge patch to the current order", func(t *testing.T) {
+		e, mockRepo := setupOrderHandlerTest()
+
+		cmdHandler := apphandler.NewOrderCommandHandler(mockRepo, new(MockOrderitemRepository), new(inlineUnitOfWork), new(memoryOutbox), eventbus.NewDispatcher())
+		qryHandler := apphandler.NewOrderQueryHandler(mockRepo, testCursors)
+		h := newOrderHandler(cmdHandler, qryHandler)
+
+		existing := entity.NewOrder(uuid.New(), usd("0.00"), "pending")
+		existing.Annotate([]string{"gift"}, "leave at door")
+		c, rec := patchRequest(e, existing.ID, "application/merge-patch+json", `{"status":"confirmed","notes":null}`)
+
+		mockRepo.On("FindByID", mock.Anything, existing.ID).Return(existing, nil)
+		mockRepo.On("FindWithItems", mock.Anything, existing.ID).Return(existing, nil)
+		mockRepo.On("Update", mock.Anything, existing).
+			Run(func(mock.Arguments) { existing.Version++ }).
+			Return(nil)
+
+		err := h.Patch(c)
+
+		assert.NoError(t, err)
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+		var body struct {
+			Data dto.OrderResponse `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+		assert.Equal(t, "confirmed", body.Data.Status)
+		assert.Equal(t, []string{"gift"}, body.Data.Tags)
+		assert.Empty(t, body.Data.Notes)
+		assert.Equal(t, `"2"`, rec.Header().Get("ETag"))
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("applies a JSON patch to the current order", func(t *testing.T) {
+		e, mockRepo := setupOrderHandlerTest()
+
+		cmdHandler := apphandler.NewOrderCommandHandler(mockRepo, new(MockOrderitemRepository), new(inlineUnitOfWork), new(memoryOutbox), eventbus.NewDispatcher())
+		qryHandler := apphandler.NewOrderQueryHandler(mockRepo, testCursors)
+		h := newOrderHandler(cmdHandler, qryHandler)
+
+		existing := entity.NewOrder(uuid.New(), usd("0.00"), "pending")
+		patch := `[{"op":"test","path":"/status","value":"pending"},{"op":"add","path":"/tags/-","value":"rush"},{"op":"replace","path":"/shipping","value":"5.00"}]`
+		c, rec := patchRequest(e, existing.ID, "application/json-patch+json", patch)
+
+		mockRepo.On("FindByID", mock.Anything, existing.ID).Return(existing, nil)
+		mockRepo.On("FindWithItems", mock.Anything, existing.ID).Return(existing, nil)
+		mockRepo.On("Update", mock.Anything, existing).Return(nil)
+
+		err := h.Patch(c)
+
+		assert.NoError(t, err)
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+		assert.Equal(t, []string{"rush"}, existing.Tags)
+		assert.Equal(t, usd("5.00"), existing.Total)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("returns 415 for other media types", func(t *testing.T) {
+		e, mockRepo := setupOrderHandlerTest()
+
+		cmdHandler := apphandler.NewOrderCommandHandler(mockRepo, new(MockOrderitemRepository), new(inlineUnitOfWork), new(memoryOutbox), eventbus.NewDispatcher())
+		qryHandler := apphandler.NewOrderQueryHandler(mockRepo, testCursors)
+		h := newOrderHandler(cmdHandler, qryHandler)
+
+		existing := entity.NewOrder(uuid.New(), usd("0.00"), "pending")
+		c, rec := patchRequest(e, existing.ID, echo.MIMEApplicationJSON, `{"status":"confirmed"}`)
+
+		mockRepo.On("FindByID", mock.Anything, existing.ID).Return(existing, nil)
+
+		err := h.Patch(c)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusUnsupportedMediaType, rec.Code)
+		assert.Contains(t, rec.Header().Get("Accept-Patch"), "application/merge-patch+json")
+		mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+
+	t.Run("returns 409 when a test operation fails", func(t *testing.T) {
+		e, mockRepo := setupOrderHandlerTest()
+
+		cmdHandler := apphandler.NewOrderCommandHandler(mockRepo, new(MockOrderitemRepository), new(inlineUnitOfWork), new(memoryOutbox), eventbus.NewDispatcher())
+		qryHandler := apphandler.NewOrderQueryHandler(mockRepo, testCursors)
+		h := newOrderHandler(cmdHandler, qryHandler)
+
+		existing := entity.NewOrder(uuid.New(), usd("0.00"), "pending")
+		c, rec := patchRequest(e, existing.ID, "application/json-patch+json", `[{"op":"test","path":"/status","value":"shipped"}]`)
+
+		mockRepo.On("FindByID", mock.Anything, existing.ID).Return(existing, nil)
+
+		err := h.Patch(c)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusConflict, rec.Code)
+		mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+
+	t.Run("returns 422 when the patch touches read-only fields", func(t *testing.T) {
+		e, mockRepo := setupOrderHandlerTest()
+
+		cmdHandler := apphandler.NewOrderCommandHandler(mockRepo, new(MockOrderitemRepository), new(inlineUnitOfWork), new(memoryOutbox), eventbus.NewDispatcher())
+		qryHandler := apphandler.NewOrderQueryHandler(mockRepo, testCursors)
+		h := newOrderHandler(cmdHandler, qryHandler)
+
+		existing := entity.NewOrder(uuid.New(), usd("0.00"), "pending")
+		c, rec := patchRequest(e, existing.ID, "application/merge-patch+json", `{"currency":"EUR"}`)
+
+		mockRepo.On("FindByID", mock.Anything, existing.ID).Return(existing, nil)
+
+		err := h.Patch(c)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+		mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+
+	t.Run("returns 400 when the patched order is invalid", func(t *testing.T) {
+		e, mockRepo := setupOrderHandlerTest()
+
+		cmdHandler := apphandler.NewOrderCommandHandler(mockRepo, new(MockOrderitemRepository), new(inlineUnitOfWork), new(memoryOutbox), eventbus.NewDispatcher())
+		qryHandler := apphandler.NewOrderQueryHandler(mockRepo, testCursors)
+		h := newOrderHandler(cmdHandler, qryHandler)
+
+		existing := entity.NewOrder(uuid.New(), usd("0.00"), "pending")
+		c, rec := patchRequest(e, existing.ID, "application/json-patch+json", `[{"op":"replace","path":"/status","value":""}]`)
+
+		mockRepo.On("FindByID", mock.Anything, existing.ID).Return(existing, nil)
+
+		err := h.Patch(c)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Contains(t, rec.Body.String(), "VALIDATION_ERROR")
+		mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+
+	t.Run("returns 404 when the order does not exist", func(t *testing.T) {
+		e, mockRepo := setupOrderHandlerTest()
+
+		cmdHandler := apphandler.NewOrderCommandHandler(mockRepo, new(MockOrderitemRepository), new(inlineUnitOfWork), new(memoryOutbox), eventbus.NewDispatcher())
+		qryHandler := apphandler.NewOrderQueryHandler(mockRepo, testCursors)
+		h := newOrderHandler(cmdHandler, qryHandler)
+
+		orderID := uuid.New()
+		c, rec := patchRequest(e, orderID, "application/merge-patch+json", `{"status":"confirmed"}`)
+
+		mockRepo.On("FindByID", mock.Anything, orderID).Return(nil, domain.ErrEntityNotFound)
+
+		err := h.Patch(c)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+}
+
+func TestOrderHandler_Delete(t *testing.T) {
+	t.Run("successfully deletes order", func(t *testing.T) {
+		e, mockRepo := setupOrderHandlerTest()
+
+		cmdHandler := apphandler.NewOrderCommandHandler(mockRepo, new(MockOrderitemRepository), new(inlineUnitOfWork), new(memoryOutbox), eventbus.NewDispatcher())
+		qryHandler := apphandler.NewOrderQueryHandler(mockRepo, testCursors)
+		h := newOrderHandler(cmdHandler, qryHandler)
+
+		orderID := uuid.New()
+
+		req := httptest.NewRequest(http.MethodDelete, "/orders/"+orderID.String(), nil)
+		req.Header.Set("If-Match", "*")
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames("id")
+		c.SetParamValues(orderID.String())
+
+		mockRepo.On("FindByID", mock.Anything, orderID).Return(entity.NewOrder(uuid.New(), usd("100.00"), "pending"), nil)
+		mockRepo.On("Delete", mock.Anything, orderID).Return(nil)
+
+		err := h.Delete(c)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNoContent, rec.Code)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("returns 400 for invalid UUID", func(t *testing.T) {
+		e, mockRepo := setupOrderHandlerTest()
+
+		cmdHandler := apphandler.NewOrderCommandHandler(mockRepo, new(MockOrderitemRepository), new(inlineUnitOfWork), new(memoryOutbox), eventbus.NewDispatcher())
+		qryHandler := apphandler.NewOrderQueryHandler(mockRepo, testCursors)
+		h := newOrderHandler(cmdHandler, qryHandler)
+
+		req := httptest.NewRequest(http.MethodDelete, "/orders/invalid-uuid", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames("id")
+		c.SetParamValues("invalid-uuid")
+
+		err := h.Delete(c)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("returns 500 on repository error", func(t *testing.T) {
+		e, mockRepo := setupOrderHandlerTest()
+
+		cmdHandler := apphandler.NewOrderCommandHandler(mockRepo, new(MockOrderitemRepository), new(inlineUnitOfWork), new(memoryOutbox), eventbus.NewDispatcher())
+		qryHandler := apphandler.NewOrderQueryHandler(mockRepo, testCursors)
+		h := newOrderHandler(cmdHandler, qryHandler)
+
+		orderID := uuid.New()
+
+		req := httptest.NewRequest(http.MethodDelete, "/orders/"+orderID.String(), nil)
+		req.Header.Set("If-Match", "*")
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames("id")
+		c.SetParamValues(orderID.String())
+
+		mockRepo.On("FindByID", mock.Anything, orderID).Return(entity.NewOrder(uuid.New(), usd("100.00"), "pending"), nil)
+		mockRepo.On("Delete", mock.Anything, orderID).Return(errors.New("delete failed"))
+
+		err := h.Delete(c)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+// TestOrderHandler_OptimisticConcurrency verifies ETag and If-Match handling.
+func TestOrderHandler_OptimisticConcurrency(t *testing.T) {
+	newHandler := func() (*echo.Echo, *MockOrderRepository, *httphandler.OrderHandler) {
+		e, mockRepo := setupOrderHandlerTest()
+		cmdHandler := apphandler.NewOrderCommandHandler(mockRepo, new(MockOrderitemRepository), new(inlineUnitOfWork), new(memoryOutbox), eventbus.NewDispatcher())
+		return e, mockRepo, newOrderHandler(cmdHandler, apphandler.NewOrderQueryHandler(mockRepo, testCursors))
+	}
+	newRequest := func(e *echo.Echo, method string, orderID uuid.UUID, ifMatch string) (echo.Context, *httptest.ResponseRecorder) {
+		body := `{"customer_id":"` + uuid.New().String() + `","status":"pending"}`
+		req := httptest.NewRequest(method, "/orders/"+orderID.String(), strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		if ifMatch != "" {
+			req.Header.Set("If-Match", ifMatch)
+		}
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames("id")
+		c.SetParamValues(orderID.String())
+		return c, rec
+	}
+	existingOrder := func(version int64) *entity.Order {
+		order := entity.NewOrder(uuid.New(), usd("0.00"), "pending")
+		order.Version = version
+		return order
+	}
+
+	t.Run("GET returns the version as ETag", func(t *testing.T) {
+		e, mockRepo, h := newHandler()
+		order := existingOrder(3)
+		mockRepo.On("FindByID", mock.Anything, order.ID).Return(order, nil)
+
+		c, rec := newRequest(e, http.MethodGet, order.ID, "")
+		require.NoError(t, h.GetByID(c))
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, `"3"`, rec.Header().Get("ETag"))
+		assert.Contains(t, rec.Body.String(), `"version":3`)
+	})
+
+	t.Run("PUT without If-Match returns 428", func(t *testing.T) {
+		e, mockRepo, h := newHandler()
+
+		c, rec := newRequest(e, http.MethodPut, uuid.New(), "")
+		require.NoError(t, h.Update(c))
+
+		assert.Equal(t, http.StatusPreconditionRequired, rec.Code)
+		mockRepo.AssertNotCalled(t, "FindWithItems", mock.Anything, mock.Anything)
+	})
+
+	t.Run("PUT with malformed If-Match returns 400", func(t *testing.T) {
+		e, _, h := newHandler()
+
+		c, rec := newRequest(e, http.MethodPut, uuid.New(), `W/"1"`)
+		require.NoError(t, h.Update(c))
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("PUT with stale If-Match returns 412", func(t *testing.T) {
+		e, mockRepo, h := newHandler()
+		order := existingOrder(2)
+		mockRepo.On("FindWithItems", mock.Anything, order.ID).Return(order, nil)
+
+		c, rec := newRequest(e, http.MethodPut, order.ID, `"1"`)
+		require.NoError(t, h.Update(c))
+
+		assert.Equal(t, http.StatusPreconditionFailed, rec.Code)
+		mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+
+	t.Run("PUT losing a concurrent update returns 409", func(t *testing.T) {
+		e, mockRepo, h := newHandler()
+		order := existingOrder(1)
+		mockRepo.On("FindWithItems", mock.Anything, order.ID).Return(order, nil)
+		mockRepo.On("Update", mock.Anything, order).Return(&repository.ConflictError{Entity: "order", ID: order.ID, Version: 1})
+
+		c, rec := newRequest(e, http.MethodPut, order.ID, `"1"`)
+		require.NoError(t, h.Update(c))
+
+		assert.Equal(t, http.StatusConflict, rec.Code)
+	})
+
+	t.Run("DELETE with matching If-Match deletes the order", func(t *testing.T) {
+		e, mockRepo, h := newHandler()
+		order := existingOrder(4)
+		mockRepo.On("FindByID", mock.Anything, order.ID).Return(order, nil)
+		mockRepo.On("Delete", mock.Anything, order.ID).Return(nil)
+
+		c, rec := newRequest(e, http.MethodDelete, order.ID, `"4"`)
+		require.NoError(t, h.Delete(c))
+
+		assert.Equal(t, http.StatusNoContent, rec.Code)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("DELETE with stale If-Match returns 412", func(t *testing.T) {
+		e, mockRepo, h := newHandler()
+		order := existingOrder(4)
+		mockRepo.On("FindByID", mock.Anything, order.ID).Return(order, nil)
+
+		c, rec := newRequest(e, http.MethodDelete, order.ID, `"3"`)
+		require.NoError(t, h.Delete(c))
+
+		assert.Equal(t, http.StatusPreconditionFailed, rec.Code)
+		mockRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+	})
+
+	t.Run("DELETE without If-Match returns 428", func(t *testing.T) {
+		e, mockRepo, h := newHandler()
+
+		c, rec := newRequest(e, http.MethodDelete, uuid.New(), "")
+		require.NoError(t, h.Delete(c))
+
+		assert.Equal(t, http.StatusPreconditionRequired, rec.Code)
+		mockRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+	})
+}
+
+func TestOrderHandler_StatusTransitions(t *testing.T) {
+	newTransitionContext := func(e *echo.Echo, orderID uuid.UUID, action string) (echo.Context, *httptest.ResponseRecorder) {
+		req := httptest.NewRequest(http.MethodPost, "/orders/"+orderID.String()+"/"+action, nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames("id")
+		c.SetParamValues(orderID.String())
+		return c, rec
+	}
+
+	t.Run("confirms pending order", func(t *testing.T) {
+		e, mockRepo := setupOrderHandlerTest()
+		h := newOrderHandler(apphandler.NewOrderCommandHandler(mockRepo, new(MockOrderitemRepository), new(inlineUnitOfWork), new(memoryOutbox), eventbus.NewDispatcher()), apphandler.NewOrderQueryHandler(mockRepo, testCursors))
+
+		order := entity.NewOrder(uuid.New(), usd("100.00"), "pending")
+		mockRepo.On("FindByID", mock.Anything, order.ID).Return(order, nil)
+		mockRepo.On("Update", mock.Anything, order).Return(nil)
+
+		c, rec := newTransitionContext(e, order.ID, "confirm")
+		err := h.Confirm(c)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "confirmed", order.Status)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("returns 409 when delivering pending order", func(t *testing.T) {
+		e, mockRepo := setupOrderHandlerTest()
+		h := newOrderHandler(apphandler.NewOrderCommandHandler(mockRepo, new(MockOrderitemRepository), new(inlineUnitOfWork), new(memoryOutbox), eventbus.NewDispatcher()), apphandler.NewOrderQueryHandler(mockRepo, testCursors))
+
+		order := entity.NewOrder(uuid.New(), usd("100.00"), "pending")
+		mockRepo.On("FindByID", mock.Anything, order.ID).Return(order, nil)
+
+		c, rec := newTransitionContext(e, order.ID, "deliver")
+		err := h.Deliver(c)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusConflict, rec.Code)
+		mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+
+	t.Run("returns 404 when order is missing", func(t *testing.T) {
+		e, mockRepo := setupOrderHandlerTest()
+		h := newOrderHandler(apphandler.NewOrderCommandHandler(mockRepo, new(MockOrderitemRepository), new(inlineUnitOfWork), new(memoryOutbox), eventbus.NewDispatcher()), apphandler.NewOrderQueryHandler(mockRepo, testCursors))
+
+		orderID := uuid.New()
+		mockRepo.On("FindByID", mock.Anything, orderID).Return(nil, domain.ErrEntityNotFound)
+
+		c, rec := newTransitionContext(e, orderID, "cancel")
+		err := h.Cancel(c)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+
+	t.Run("returns 400 for invalid UUID", func(t *testing.T) {
+		e, mockRepo := setupOrderHandlerTest()
+		h := newOrderHandler(apphandler.NewOrderCommandHandler(mockRepo, new(MockOrderitemRepository), new(inlineUnitOfWork), new(memoryOutbox), eventbus.NewDispatcher()), apphandler.NewOrderQueryHandler(mockRepo, testCursors))
+
+		req := httptest.NewRequest(http.MethodPost, "/orders/invalid-uuid/ship", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames("id")
+		c.SetParamValues("invalid-uuid")
+
+		err := h.Ship(c)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
+
+func TestOrderHandler_RegisterRoutes(t *testing.T) {
+	t.Run("registers all routes", func(t *testing.T) {
+		e := echo.New()
+		mockRepo := new(MockOrderRepository)
+		cmdHandler := apphandler.NewOrderCommandHandler(mockRepo, new(MockOrderitemRepository), new(inlineUnitOfWork), new(memoryOutbox), eventbus.NewDispatcher())
+		qryHandler := apphandler.NewOrderQueryHandler(mockRepo, testCursors)
+		h := newOrderHandler(cmdHandler, qryHandler)
+
+		g := e.Group("/api/v1")
+		h.RegisterRoutes(g)
 
 		routes := e.Routes()
 		require.NotEmpty(t, routes)
@@ -513,7 +1667,444 @@ func TestOrderHandler_RegisterRoutes(t *testing.T) {
 		assert.True(t, routePaths["GET:/api/v1/orders"])
 		assert.True(t, routePaths["GET:/api/v1/orders/:id"])
 		assert.True(t, routePaths["PUT:/api/v1/orders/:id"])
+		assert.True(t, routePaths["PATCH:/api/v1/orders/:id"])
 		assert.True(t, routePaths["DELETE:/api/v1/orders/:id"])
+		assert.True(t, routePaths["POST:/api/v1/orders/:id/confirm"])
+		assert.True(t, routePaths["POST:/api/v1/orders/:id/pay"])
+		assert.True(t, routePaths["POST:/api/v1/orders/:id/ship"])
+		assert.True(t, routePaths["POST:/api/v1/orders/:id/deliver"])
+		assert.True(t, routePaths["POST:/api/v1/orders/:id/cancel"])
+	})
+}
+
+// =============================================================================
+// Nested Order Item HTTP Handler Tests
+//
+// Items under /orders/:id/items belong to the order of the path; items of
+// other orders are not found.
+// =============================================================================
+
+// setupNestedItemTest builds an OrderitemHandler dispatching through
+// validating buses to handlers backed by the returned mocks
+func setupNestedItemTest() (*echo.Echo, *httphandler.OrderitemHandler, *MockOrderRepository, *MockOrderitemRepository) {
+	e := echo.New()
+	e.Validator = validator.NewEchoValidator()
+	orderRepo := new(MockOrderRepository)
+	itemRepo := new(MockOrderitemRepository)
+
+	commands := bus.NewCommandBus(bus.Validation())
+	queries := bus.NewQueryBus(bus.Validation())
+	apphandler.NewOrderitemCommandHandler(itemRepo, orderRepo, new(inlineUnitOfWork), new(memoryOutbox), eventbus.NewDispatcher()).Register(commands)
+	apphandler.NewOrderitemQueryHandler(itemRepo, orderRepo, testCursors).Register(queries)
+	return e, httphandler.NewOrderitemHandler(commands, queries), orderRepo, itemRepo
+}
+
+// nestedItemContext builds the context of a request to a nested item path
+func nestedItemContext(e *echo.Echo, req *http.Request, rec *httptest.ResponseRecorder, orderID, itemID string) echo.Context {
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id", "itemId")
+	c.SetParamValues(orderID, itemID)
+	return c
+}
+
+func TestOrderitemHandler_CreateInOrder(t *testing.T) {
+	t.Run("creates the item in the order of the path", func(t *testing.T) {
+		e, h, orderRepo, itemRepo := setupNestedItemTest()
+
+		order := entity.NewOrder(uuid.New(), usd("0.00"), "pending")
+		productID := uuid.New()
+		body := fmt.Sprintf(`{"product_id":%q,"quantity":2,"price":"10.00"}`, productID)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/orders/"+order.ID.String()+"/items", strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames("id")
+		c.SetParamValues(order.ID.String())
+
+		orderRepo.On("FindByID", mock.Anything, order.ID).Return(order, nil)
+		find := itemRepo.On("FindByID", mock.Anything, mock.Anything)
+		itemRepo.On("Create", mock.Anything, mock.MatchedBy(func(item *entity.Orderitem) bool {
+			return item.OrderID == order.ID && item.ProductID == productID
+		})).Run(func(args mock.Arguments) {
+			find.Return(args.Get(1).(*entity.Orderitem), nil)
+		}).Return(nil)
+		orderRepo.On("FindWithItems", mock.Anything, order.ID).Return(order, nil)
+		orderRepo.On("Update", mock.Anything, order).Return(nil)
+
+		err := h.CreateInOrder(c)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusCreated, rec.Code)
+		assert.True(t, strings.HasPrefix(rec.Header().Get(echo.HeaderLocation), "/api/v1/orders/"+order.ID.String()+"/items/"))
+		orderRepo.AssertExpectations(t)
+		itemRepo.AssertExpectations(t)
+	})
+
+	t.Run("returns 404 when the order does not exist", func(t *testing.T) {
+		e, h, orderRepo, itemRepo := setupNestedItemTest()
+
+		orderID := uuid.New()
+		body := fmt.Sprintf(`{"product_id":%q,"quantity":1,"price":"10.00"}`, uuid.New())
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/orders/"+orderID.String()+"/items", strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames("id")
+		c.SetParamValues(orderID.String())
+
+		orderRepo.On("FindByID", mock.Anything, orderID).Return(nil, domain.ErrEntityNotFound)
+
+		err := h.CreateInOrder(c)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+		itemRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("returns 400 for invalid order ID", func(t *testing.T) {
+		e, h, _, _ := setupNestedItemTest()
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/orders/invalid-uuid/items", strings.NewReader(`{}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames("id")
+		c.SetParamValues("invalid-uuid")
+
+		err := h.CreateInOrder(c)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
+
+func TestOrderitemHandler_ListByOrder(t *testing.T) {
+	t.Run("lists the items of the order", func(t *testing.T) {
+		e, h, orderRepo, itemRepo := setupNestedItemTest()
+
+		order := entity.NewOrder(uuid.New(), usd("30.00"), "pending")
+		items := []entity.Orderitem{
+			*entity.NewOrderitem(order.ID, uuid.New(), 1, usd("10.00")),
+			*entity.NewOrderitem(order.ID, uuid.New(), 2, usd("10.00")),
+		}
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/orders/"+order.ID.String()+"/items", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames("id")
+		c.SetParamValues(order.ID.String())
+
+		orderRepo.On("FindByID", mock.Anything, order.ID).Return(order, nil)
+		itemRepo.On("FindByOrderID", mock.Anything, order.ID).Return(items, nil)
+
+		err := h.ListByOrder(c)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		var body struct {
+			Data []dto.OrderitemResponse `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+		assert.Len(t, body.Data, 2)
+		orderRepo.AssertExpectations(t)
+		itemRepo.AssertExpectations(t)
+	})
+
+	t.Run("returns 404 when the order does not exist", func(t *testing.T) {
+		e, h, orderRepo, itemRepo := setupNestedItemTest()
+
+		orderID := uuid.New()
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/orders/"+orderID.String()+"/items", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames("id")
+		c.SetParamValues(orderID.String())
+
+		orderRepo.On("FindByID", mock.Anything, orderID).Return(nil, domain.ErrEntityNotFound)
+
+		err := h.ListByOrder(c)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+		itemRepo.AssertNotCalled(t, "FindByOrderID", mock.Anything, mock.Anything)
+	})
+}
+
+func TestOrderitemHandler_Ownership(t *testing.T) {
+	t.Run("gets an item of the order", func(t *testing.T) {
+		e, h, _, itemRepo := setupNestedItemTest()
+
+		item := entity.NewOrderitem(uuid.New(), uuid.New(), 1, usd("10.00"))
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		rec := httptest.NewRecorder()
+		c := nestedItemContext(e, req, rec, item.OrderID.String(), item.ID.String())
+
+		itemRepo.On("FindByID", mock.Anything, item.ID).Return(item, nil)
+
+		err := h.GetInOrder(c)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.NotEmpty(t, rec.Header().Get("ETag"))
+	})
+
+	t.Run("does not get an item of another order", func(t *testing.T) {
+		e, h, _, itemRepo := setupNestedItemTest()
+
+		item := entity.NewOrderitem(uuid.New(), uuid.New(), 1, usd("10.00"))
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		rec := httptest.NewRecorder()
+		c := nestedItemContext(e, req, rec, uuid.New().String(), item.ID.String())
+
+		itemRepo.On("FindByID", mock.Anything, item.ID).Return(item, nil)
+
+		err := h.GetInOrder(c)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+
+	t.Run("does not update an item of another order", func(t *testing.T) {
+		e, h, orderRepo, itemRepo := setupNestedItemTest()
+
+		item := entity.NewOrderitem(uuid.New(), uuid.New(), 1, usd("10.00"))
+		body := fmt.Sprintf(`{"product_id":%q,"quantity":3,"price":"10.00"}`, item.ProductID)
+
+		req := httptest.NewRequest(http.MethodPut, "/", strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		req.Header.Set("If-Match", "*")
+		rec := httptest.NewRecorder()
+		c := nestedItemContext(e, req, rec, uuid.New().String(), item.ID.String())
+
+		itemRepo.On("FindByID", mock.Anything, item.ID).Return(item, nil)
+
+		err := h.UpdateInOrder(c)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+		itemRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+		orderRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+
+	t.Run("does not delete an item of another order", func(t *testing.T) {
+		e, h, orderRepo, itemRepo := setupNestedItemTest()
+
+		item := entity.NewOrderitem(uuid.New(), uuid.New(), 1, usd("10.00"))
+
+		req := httptest.NewRequest(http.MethodDelete, "/", nil)
+		req.Header.Set("If-Match", "*")
+		rec := httptest.NewRecorder()
+		c := nestedItemContext(e, req, rec, uuid.New().String(), item.ID.String())
+
+		itemRepo.On("FindByID", mock.Anything, item.ID).Return(item, nil)
+
+		err := h.DeleteInOrder(c)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+		itemRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+		orderRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+
+	t.Run("deletes an item of the order and recalculates its total", func(t *testing.T) {
+		e, h, orderRepo, itemRepo := setupNestedItemTest()
+
+		order := entity.NewOrder(uuid.New(), usd("10.00"), "pending")
+		item := entity.NewOrderitem(order.ID, uuid.New(), 1, usd("10.00"))
+
+		req := httptest.NewRequest(http.MethodDelete, "/", nil)
+		req.Header.Set("If-Match", "*")
+		rec := httptest.NewRecorder()
+		c := nestedItemContext(e, req, rec, order.ID.String(), item.ID.String())
+
+		itemRepo.On("FindByID", mock.Anything, item.ID).Return(item, nil)
+		itemRepo.On("Delete", mock.Anything, item.ID).Return(nil)
+		orderRepo.On("FindByID", mock.Anything, order.ID).Return(order, nil)
+		orderRepo.On("FindWithItems", mock.Anything, order.ID).Return(order, nil)
+		orderRepo.On("Update", mock.Anything, order).Return(nil)
+
+		err := h.DeleteInOrder(c)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNoContent, rec.Code)
+		assert.True(t, order.Total.IsZero())
+		orderRepo.AssertExpectations(t)
+		itemRepo.AssertExpectations(t)
+	})
+}
+
+func TestOrderitemHandler_Patch(t *testing.T) {
+	t.Run("applies a merge patch to the current item", func(t *testing.T) {
+		e, h, orderRepo, itemRepo := setupNestedItemTest()
+
+		order := entity.NewOrder(uuid.New(), usd("10.00"), "pending")
+		item := entity.NewOrderitem(order.ID, uuid.New(), 1, usd("10.00"))
+
+		req := httptest.NewRequest(http.MethodPatch, "/order-items/"+item.ID.String(), strings.NewReader(`{"quantity":3}`))
+		req.Header.Set(echo.HeaderContentType, "application/merge-patch+json")
+		req.Header.Set("If-Match", `"1"`)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames("id")
+		c.SetParamValues(item.ID.String())
+
+		itemRepo.On("FindByID", mock.Anything, item.ID).Return(item, nil)
+		itemRepo.On("Update", mock.Anything, item).Return(nil)
+		orderRepo.On("FindByID", mock.Anything, order.ID).Return(order, nil)
+		orderRepo.On("FindWithItems", mock.Anything, order.ID).Return(order, nil)
+		orderRepo.On("Update", mock.Anything, order).Return(nil)
+
+		err := h.Patch(c)
+
+		assert.NoError(t, err)
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+		assert.Equal(t, 3, item.Quantity)
+		assert.Equal(t, "10.00", item.Price.String())
+		itemRepo.AssertExpectations(t)
+		orderRepo.AssertExpectations(t)
+	})
+
+	t.Run("returns 400 when the patched item is invalid", func(t *testing.T) {
+		e, h, _, itemRepo := setupNestedItemTest()
+
+		item := entity.NewOrderitem(uuid.New(), uuid.New(), 1, usd("10.00"))
+
+		req := httptest.NewRequest(http.MethodPatch, "/order-items/"+item.ID.String(), strings.NewReader(`[{"op":"replace","path":"/quantity","value":0}]`))
+		req.Header.Set(echo.HeaderContentType, "application/json-patch+json")
+		req.Header.Set("If-Match", "*")
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames("id")
+		c.SetParamValues(item.ID.String())
+
+		itemRepo.On("FindByID", mock.Anything, item.ID).Return(item, nil)
+
+		err := h.Patch(c)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Contains(t, rec.Body.String(), "quantity")
+		itemRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+
+	t.Run("returns 428 without If-Match", func(t *testing.T) {
+		e, h, _, itemRepo := setupNestedItemTest()
+
+		itemID := uuid.New()
+		req := httptest.NewRequest(http.MethodPatch, "/order-items/"+itemID.String(), strings.NewReader(`{"quantity":3}`))
+		req.Header.Set(echo.HeaderContentType, "application/merge-patch+json")
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames("id")
+		c.SetParamValues(itemID.String())
+
+		err := h.Patch(c)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusPreconditionRequired, rec.Code)
+		itemRepo.AssertNotCalled(t, "FindByID", mock.Anything, mock.Anything)
+	})
+}
+
+func TestOrderitemHandler_RegisterRoutes(t *testing.T) {
+	t.Run("registers patch and nested item routes", func(t *testing.T) {
+		e, h, _, _ := setupNestedItemTest()
+
+		h.RegisterRoutes(e.Group("/api/v1"))
+
+		routePaths := make(map[string]bool)
+		for _, r := range e.Routes() {
+			routePaths[r.Method+":"+r.Path] = true
+		}
+
+		assert.True(t, routePaths["PATCH:/api/v1/order-items/:id"])
+		assert.True(t, routePaths["GET:/api/v1/orders/:id/items"])
+		assert.True(t, routePaths["POST:/api/v1/orders/:id/items"])
+		assert.True(t, routePaths["GET:/api/v1/orders/:id/items/:itemId"])
+		assert.True(t, routePaths["PUT:/api/v1/orders/:id/items/:itemId"])
+		assert.True(t, routePaths["DELETE:/api/v1/orders/:id/items/:itemId"])
+	})
+}
+
+// =============================================================================
+// Error Handler Tests
+//
+// HTTPErrorHandler renders errors escaping handlers and middleware.
+// =============================================================================
+
+func TestHTTPErrorHandler(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantCode   string
+	}{
+		{"echo error", echo.NewHTTPError(http.StatusTooManyRequests, "rate limit exceeded"), http.StatusTooManyRequests, "TOO_MANY_REQUESTS"},
+		{"echo not found", echo.ErrNotFound, http.StatusNotFound, "NOT_FOUND"},
+		{"command not found", command.ErrNotFound, http.StatusNotFound, "NOT_FOUND"},
+		{"command precondition failed", command.ErrPreconditionFailed, http.StatusPreconditionFailed, "PRECONDITION_FAILED"},
+		{"query forbidden", query.ErrForbidden, http.StatusForbidden, "FORBIDDEN"},
+		{"entity not found", fmt.Errorf("%w: order", domain.ErrEntityNotFound), http.StatusNotFound, "NOT_FOUND"},
+		{"duplicate entity", fmt.Errorf("%w: order already exists", domain.ErrEntityConflict), http.StatusConflict, "CONFLICT"},
+		{"version conflict", &repository.ConflictError{Entity: "order", ID: uuid.New(), Version: 2}, http.StatusConflict, "CONFLICT"},
+		{"invalid state transition", domain.ErrInvalidStateTransition, http.StatusConflict, "CONFLICT"},
+		{"invalid entity", fmt.Errorf("%w: orderitem references a missing entity", domain.ErrInvalidEntity), http.StatusUnprocessableEntity, "UNPROCESSABLE_ENTITY"},
+		{"forbidden", fmt.Errorf("dispatch: %w", bus.ErrForbidden), http.StatusForbidden, "FORBIDDEN"},
+		{"storage unavailable", fmt.Errorf("%w: %w", repository.ErrUnavailable, errors.New("pq: the database system is shutting down")), http.StatusServiceUnavailable, "SERVICE_UNAVAILABLE"},
+		{"unknown error", errors.New(`pq: duplicate key value violates unique constraint "orders_pkey"`), http.StatusInternalServerError, "INTERNAL_ERROR"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/orders", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			httphandler.HTTPErrorHandler(tt.err, c)
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			var resp struct {
+				Success bool `json:"success"`
+				Error   struct {
+					Code    string `json:"code"`
+					Message string `json:"message"`
+				} `json:"error"`
+			}
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+			assert.False(t, resp.Success)
+			assert.Equal(t, tt.wantCode, resp.Error.Code)
+			assert.NotContains(t, resp.Error.Message, "pq:")
+		})
+	}
+
+	t.Run("unavailable storage asks clients to retry", func(t *testing.T) {
+		e := echo.New()
+		rec := httptest.NewRecorder()
+		c := e.NewContext(httptest.NewRequest(http.MethodGet, "/orders", nil), rec)
+
+		httphandler.HTTPErrorHandler(repository.ErrUnavailable, c)
+
+		assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+		assert.NotEmpty(t, rec.Header().Get("Retry-After"))
+	})
+
+	t.Run("skips committed responses", func(t *testing.T) {
+		e := echo.New()
+		req := httptest.NewRequest(http.MethodGet, "/orders", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		require.NoError(t, c.NoContent(http.StatusNoContent))
+
+		httphandler.HTTPErrorHandler(errors.New("late failure"), c)
+
+		assert.Equal(t, http.StatusNoContent, rec.Code)
+		assert.Empty(t, rec.Body.String())
 	})
 }
 
@@ -550,11 +2141,11 @@ func BenchmarkOrderHandler_Create(b *testing.B) {
 	e := echo.New()
 	e.Validator = validator.NewEchoValidator()
 	mockRepo := new(MockOrderRepository)
-	mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*entity.Order")).Return(nil)
+	expectReadBack(mockRepo, mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*entity.Order")).Return(nil))
 
-	cmdHandler := apphandler.NewOrderCommandHandler(mockRepo)
-	qryHandler := apphandler.NewOrderQueryHandler(mockRepo)
-	h := httphandler.NewOrderHandler(cmdHandler, qryHandler)
+	cmdHandler := apphandler.NewOrderCommandHandler(mockRepo, new(MockOrderitemRepository), new(inlineUnitOfWork), new(memoryOutbox), eventbus.NewDispatcher())
+	qryHandler := apphandler.NewOrderQueryHandler(mockRepo, testCursors)
+	h := newOrderHandler(cmdHandler, qryHandler)
 
 	customerID := uuid.New()
 	reqBody := `{"customer_id":"` + customerID.String() + `","total":100.50,"status":"pending"}`
@@ -574,14 +2165,14 @@ func BenchmarkOrderHandler_GetByID(b *testing.B) {
 	mockRepo := new(MockOrderRepository)
 
 	orderID := uuid.New()
-	order := entity.NewOrder(uuid.New(), 100.0, "pending")
+	order := entity.NewOrder(uuid.New(), usd("100.00"), "pending")
 	order.ID = orderID
 
 	mockRepo.On("FindByID", mock.Anything, orderID).Return(order, nil)
 
-	cmdHandler := apphandler.NewOrderCommandHandler(mockRepo)
-	qryHandler := apphandler.NewOrderQueryHandler(mockRepo)
-	h := httphandler.NewOrderHandler(cmdHandler, qryHandler)
+	cmdHandler := apphandler.NewOrderCommandHandler(mockRepo, new(MockOrderitemRepository), new(inlineUnitOfWork), new(memoryOutbox), eventbus.NewDispatcher())
+	qryHandler := apphandler.NewOrderQueryHandler(mockRepo, testCursors)
+	h := newOrderHandler(cmdHandler, qryHandler)
 
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
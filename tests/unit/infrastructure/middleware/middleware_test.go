@@ -9,6 +9,9 @@
 //   - Auth: JWT token validation and user context extraction
 //   - RequireRole: Role-based access control
 //   - RateLimit: Request rate limiting per client IP
+//   - Idempotency: Idempotency-Key response replay and key reuse detection
+//   - CleanupIdempotencyKeys: periodic deletion of expired keys until cancelled
+//   - ReadYourWrites: primary reads after mutations and on request
 //   - Context helpers: GetUserID, GetUserEmail, GetUserRole
 //
 // # Security Testing
@@ -21,8 +24,11 @@
 package middleware_test
 
 import (
+	"context"
 	"net/http"
 	"net/http/httptest"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -30,6 +36,8 @@ import (
 	"github.com/labstack/echo/v4"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/telemetryflow/order-service/internal/application/bus"
+	"github.com/telemetryflow/order-service/internal/domain/repository"
 	"github.com/telemetryflow/order-service/internal/infrastructure/config"
 	"github.com/telemetryflow/order-service/internal/infrastructure/http/middleware"
 )
@@ -82,6 +90,10 @@ func TestAuth_ValidToken(t *testing.T) {
 	assert.Equal(t, "user-123", c.Get("user_id"))
 	assert.Equal(t, "test@example.com", c.Get("email"))
 	assert.Equal(t, "admin", c.Get("role"))
+
+	actor, ok := bus.ActorFrom(c.Request().Context())
+	require.True(t, ok, "the caller is identified to the buses")
+	assert.Equal(t, bus.Actor{UserID: "user-123", Role: "admin"}, actor)
 }
 
 func TestAuth_MissingAuthorizationHeader(t *testing.T) {
@@ -495,6 +507,313 @@ func TestRateLimit(t *testing.T) {
 	})
 }
 
+// =============================================================================
+// Idempotency Middleware Tests
+//
+// Tests for the Idempotency-Key middleware that stores the first response
+// for a key and replays it for retried requests.
+// =============================================================================
+
+// memoryIdempotencyStore implements middleware.IdempotencyStore in memory.
+type memoryIdempotencyStore struct {
+	mu       sync.Mutex
+	records  map[string]*middleware.IdempotencyRecord
+	cleanups int
+}
+
+func newMemoryIdempotencyStore() *memoryIdempotencyStore {
+	return &memoryIdempotencyStore{records: make(map[string]*middleware.IdempotencyRecord)}
+}
+
+func (s *memoryIdempotencyStore) Reserve(_ context.Context, key, fingerprint string, _ time.Duration) (*middleware.IdempotencyRecord, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if existing, ok := s.records[key]; ok {
+		copied := *existing
+		return &copied, nil
+	}
+	s.records[key] = &middleware.IdempotencyRecord{Key: key, Fingerprint: fingerprint}
+	return nil, nil
+}
+
+func (s *memoryIdempotencyStore) Complete(_ context.Context, key string, status int, header http.Header, body []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	record := s.records[key]
+	record.StatusCode = status
+	record.Header = header
+	record.Body = append([]byte(nil), body...)
+	return nil
+}
+
+func (s *memoryIdempotencyStore) Release(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.records, key)
+	return nil
+}
+
+func (s *memoryIdempotencyStore) DeleteExpired(_ context.Context) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.cleanups++
+	return 0, nil
+}
+
+func TestIdempotency(t *testing.T) {
+	cfg := config.IdempotencyConfig{TTL: time.Hour}
+
+	sendTo := func(e *echo.Echo, handler echo.HandlerFunc, method, target, key, body, userID string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, target, strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		if key != "" {
+			req.Header.Set(middleware.HeaderIdempotencyKey, key)
+		}
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		if userID != "" {
+			c.Set("user_id", userID)
+		}
+		if err := handler(c); err != nil {
+			e.HTTPErrorHandler(err, c)
+		}
+		return rec
+	}
+	send := func(e *echo.Echo, handler echo.HandlerFunc, method, key, body, userID string) *httptest.ResponseRecorder {
+		return sendTo(e, handler, method, "/api/v1/orders", key, body, userID)
+	}
+
+	// countingHandler creates an order-like resource and counts executions
+	countingHandler := func(calls *int) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			*calls++
+			c.Response().Header().Set(echo.HeaderLocation, "/api/v1/orders/1")
+			return c.JSON(http.StatusCreated, map[string]int{"call": *calls})
+		}
+	}
+
+	t.Run("replays the stored response for a repeated key", func(t *testing.T) {
+		e := echo.New()
+		calls := 0
+		handler := middleware.Idempotency(newMemoryIdempotencyStore(), cfg)(countingHandler(&calls))
+
+		first := send(e, handler, http.MethodPost, "key-1", `{"a":1}`, "user-1")
+		second := send(e, handler, http.MethodPost, "key-1", `{"a":1}`, "user-1")
+
+		assert.Equal(t, 1, calls)
+		assert.Equal(t, http.StatusCreated, second.Code)
+		assert.JSONEq(t, first.Body.String(), second.Body.String())
+		assert.Equal(t, "/api/v1/orders/1", second.Header().Get(echo.HeaderLocation))
+		assert.Equal(t, "true", second.Header().Get(middleware.HeaderIdempotentReplayed))
+		assert.Empty(t, first.Header().Get(middleware.HeaderIdempotentReplayed))
+	})
+
+	t.Run("rejects key reuse with a different body", func(t *testing.T) {
+		e := echo.New()
+		calls := 0
+		handler := middleware.Idempotency(newMemoryIdempotencyStore(), cfg)(countingHandler(&calls))
+
+		send(e, handler, http.MethodPost, "key-1", `{"a":1}`, "user-1")
+		rec := send(e, handler, http.MethodPost, "key-1", `{"a":2}`, "user-1")
+
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("rejects key reuse with a different query string", func(t *testing.T) {
+		e := echo.New()
+		calls := 0
+		handler := middleware.Idempotency(newMemoryIdempotencyStore(), cfg)(countingHandler(&calls))
+
+		sendTo(e, handler, http.MethodPost, "/api/v1/orders?dry_run=true", "key-1", `{"a":1}`, "user-1")
+		rec := sendTo(e, handler, http.MethodPost, "/api/v1/orders", "key-1", `{"a":1}`, "user-1")
+
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("rejects a repeat while the first request is in progress", func(t *testing.T) {
+		e := echo.New()
+		var handler echo.HandlerFunc
+		var concurrent *httptest.ResponseRecorder
+		handler = middleware.Idempotency(newMemoryIdempotencyStore(), cfg)(func(c echo.Context) error {
+			// A retry arrives before the first request has finished
+			concurrent = send(e, handler, http.MethodPost, "key-1", `{"a":1}`, "user-1")
+			return c.NoContent(http.StatusCreated)
+		})
+
+		first := send(e, handler, http.MethodPost, "key-1", `{"a":1}`, "user-1")
+
+		assert.Equal(t, http.StatusCreated, first.Code)
+		require.NotNil(t, concurrent)
+		assert.Equal(t, http.StatusConflict, concurrent.Code)
+	})
+
+	t.Run("does not store server errors", func(t *testing.T) {
+		e := echo.New()
+		store := newMemoryIdempotencyStore()
+		calls := 0
+		handler := middleware.Idempotency(store, cfg)(func(c echo.Context) error {
+			calls++
+			return echo.NewHTTPError(http.StatusInternalServerError, "database unavailable")
+		})
+
+		first := send(e, handler, http.MethodPost, "key-1", `{}`, "user-1")
+		second := send(e, handler, http.MethodPost, "key-1", `{}`, "user-1")
+
+		assert.Equal(t, http.StatusInternalServerError, first.Code)
+		assert.Equal(t, http.StatusInternalServerError, second.Code)
+		assert.Equal(t, 2, calls)
+		assert.Empty(t, store.records)
+	})
+
+	t.Run("stores and replays client errors returned as HTTP errors", func(t *testing.T) {
+		e := echo.New()
+		calls := 0
+		handler := middleware.Idempotency(newMemoryIdempotencyStore(), cfg)(func(c echo.Context) error {
+			calls++
+			return echo.NewHTTPError(http.StatusBadRequest, "invalid order")
+		})
+
+		send(e, handler, http.MethodPost, "key-1", `{}`, "user-1")
+		rec := send(e, handler, http.MethodPost, "key-1", `{}`, "user-1")
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Contains(t, rec.Body.String(), "invalid order")
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("scopes keys to the authenticated user", func(t *testing.T) {
+		e := echo.New()
+		calls := 0
+		handler := middleware.Idempotency(newMemoryIdempotencyStore(), cfg)(countingHandler(&calls))
+
+		send(e, handler, http.MethodPost, "key-1", `{}`, "user-1")
+		rec := send(e, handler, http.MethodPost, "key-1", `{}`, "user-2")
+
+		assert.Equal(t, http.StatusCreated, rec.Code)
+		assert.Equal(t, 2, calls)
+	})
+
+	t.Run("passes through requests without a key or with other methods", func(t *testing.T) {
+		e := echo.New()
+		store := newMemoryIdempotencyStore()
+		calls := 0
+		handler := middleware.Idempotency(store, cfg)(countingHandler(&calls))
+
+		send(e, handler, http.MethodPost, "", `{}`, "user-1")
+		send(e, handler, http.MethodPost, "", `{}`, "user-1")
+		send(e, handler, http.MethodPut, "key-1", `{}`, "user-1")
+
+		assert.Equal(t, 3, calls)
+		assert.Empty(t, store.records)
+	})
+
+	t.Run("rejects keys longer than 255 characters", func(t *testing.T) {
+		e := echo.New()
+		calls := 0
+		handler := middleware.Idempotency(newMemoryIdempotencyStore(), cfg)(countingHandler(&calls))
+
+		rec := send(e, handler, http.MethodPost, strings.Repeat("k", 256), `{}`, "user-1")
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Zero(t, calls)
+	})
+}
+
+func TestCleanupIdempotencyKeys(t *testing.T) {
+	store := newMemoryIdempotencyStore()
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+		middleware.CleanupIdempotencyKeys(ctx, store, time.Millisecond)
+	}()
+
+	assert.Eventually(t, func() bool {
+		store.mu.Lock()
+		defer store.mu.Unlock()
+		return store.cleanups >= 2
+	}, time.Second, time.Millisecond, "expired keys are deleted every interval")
+
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("cleanup did not stop when its context was cancelled")
+	}
+}
+
+// =============================================================================
+// ReadYourWrites Middleware Tests
+//
+// Tests for the middleware that sends a client's reads to the primary
+// database after it changed data.
+// =============================================================================
+
+func TestReadYourWrites(t *testing.T) {
+	e := echo.New()
+	mw := middleware.ReadYourWrites(5 * time.Second)
+
+	// serve runs req and reports whether the handler may read from a replica
+	serve := func(req *http.Request, status int) (*httptest.ResponseRecorder, bool) {
+		var replicaReads bool
+		handler := mw(func(c echo.Context) error {
+			replicaReads = repository.ReplicaReadsAllowed(repository.WithReplicaReads(c.Request().Context()))
+			return c.NoContent(status)
+		})
+		rec := httptest.NewRecorder()
+		require.NoError(t, handler(e.NewContext(req, rec)))
+		return rec, replicaReads
+	}
+
+	t.Run("reads without recent writes may use replicas", func(t *testing.T) {
+		rec, replicaReads := serve(httptest.NewRequest(http.MethodGet, "/orders", nil), http.StatusOK)
+
+		assert.True(t, replicaReads)
+		assert.Empty(t, rec.Result().Cookies())
+	})
+
+	t.Run("successful mutations read from the primary and set the cookie", func(t *testing.T) {
+		rec, replicaReads := serve(httptest.NewRequest(http.MethodPost, "/orders", nil), http.StatusCreated)
+
+		assert.False(t, replicaReads)
+		cookies := rec.Result().Cookies()
+		require.Len(t, cookies, 1)
+		assert.Equal(t, middleware.ReadYourWritesCookie, cookies[0].Name)
+		assert.Equal(t, 5, cookies[0].MaxAge)
+
+		// The client's next read goes to the primary
+		req := httptest.NewRequest(http.MethodGet, "/orders", nil)
+		req.AddCookie(cookies[0])
+		_, replicaReads = serve(req, http.StatusOK)
+		assert.False(t, replicaReads)
+	})
+
+	t.Run("failed mutations do not set the cookie", func(t *testing.T) {
+		rec, _ := serve(httptest.NewRequest(http.MethodPut, "/orders/1", nil), http.StatusPreconditionFailed)
+
+		assert.Empty(t, rec.Result().Cookies())
+	})
+
+	t.Run("expired cookies are ignored", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/orders", nil)
+		req.AddCookie(&http.Cookie{Name: middleware.ReadYourWritesCookie, Value: "1"})
+
+		_, replicaReads := serve(req, http.StatusOK)
+		assert.True(t, replicaReads)
+	})
+
+	t.Run("strong consistency header reads from the primary", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/orders", nil)
+		req.Header.Set(middleware.HeaderConsistency, middleware.ConsistencyStrong)
+
+		_, replicaReads := serve(req, http.StatusOK)
+		assert.False(t, replicaReads)
+	})
+}
+
 // =============================================================================
 // JWTClaims Tests
 // =============================================================================
@@ -0,0 +1,330 @@
+// migration_test.go - Schema Migration Unit Tests
+//
+// This file contains unit tests for the migration loader and the migrator
+// that applies the embedded SQL migrations.
+//
+// # Test Coverage
+//
+// The tests cover the following behavior:
+//   - Load: file name parsing, ordering, missing up files, duplicate versions
+//   - Load: the embedded migrations are complete and reversible
+//   - Migrator.Up: applies pending migrations in order, tolerates newer versions
+//   - Migrator.Down: reverts the latest migrations, refuses irreversible ones
+//   - Migrator.Goto: migrates in both directions and rejects unknown versions
+//   - Migrator.Status: reports applied, pending and unknown migrations
+//   - Locking: every run holds the lock and stops at the first failure
+//
+// # Mocking Strategy
+//
+// The migration store is replaced by an in-memory fake that records every
+// applied step, so no database is required.
+//
+// Generated by TelemetryFlow RESTful API Generator
+// Copyright (c) 2024-2026 DevOpsCorner Indonesia. All rights reserved.
+package migration_test
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/telemetryflow/order-service/internal/infrastructure/migration"
+	"github.com/telemetryflow/order-service/migrations"
+)
+
+// =============================================================================
+// Test Doubles
+// =============================================================================
+
+// fakeStore implements migration.Store in memory.
+type fakeStore struct {
+	applied  map[int64]migration.Record
+	steps    []string
+	locked   bool
+	locks    int
+	prepared bool
+	failAt   int64
+}
+
+func newFakeStore(versions ...int64) *fakeStore {
+	s := &fakeStore{applied: make(map[int64]migration.Record)}
+	for _, v := range versions {
+		s.applied[v] = migration.Record{Version: v, Name: "applied", AppliedAt: time.Now()}
+	}
+	return s
+}
+
+func (s *fakeStore) Lock(_ context.Context) (func() error, error) {
+	s.locked = true
+	s.locks++
+	return func() error {
+		s.locked = false
+		return nil
+	}, nil
+}
+
+func (s *fakeStore) Prepare(_ context.Context, _ []migration.Migration) error {
+	if !s.locked {
+		return errors.New("prepare without lock")
+	}
+	s.prepared = true
+	return nil
+}
+
+func (s *fakeStore) Applied(_ context.Context) ([]migration.Record, error) {
+	if !s.locked {
+		return nil, errors.New("applied without lock")
+	}
+	records := make([]migration.Record, 0, len(s.applied))
+	for _, r := range s.applied {
+		records = append(records, r)
+	}
+	sort.Slice(records, func(i, j int) bool { return records[i].Version < records[j].Version })
+	return records, nil
+}
+
+func (s *fakeStore) Apply(_ context.Context, m migration.Migration, dir migration.Direction) error {
+	if !s.locked {
+		return errors.New("apply without lock")
+	}
+	if m.Version == s.failAt {
+		return errors.New("syntax error")
+	}
+	s.steps = append(s.steps, dir.String()+" "+m.Name)
+	if dir == migration.DirectionDown {
+		delete(s.applied, m.Version)
+	} else {
+		s.applied[m.Version] = migration.Record{Version: m.Version, Name: m.Name, AppliedAt: time.Now()}
+	}
+	return nil
+}
+
+// testMigrations returns three reversible migrations.
+func testMigrations() []migration.Migration {
+	return []migration.Migration{
+		{Version: 1, Name: "one", Up: "UP 1", Down: "DOWN 1"},
+		{Version: 2, Name: "two", Up: "UP 2", Down: "DOWN 2"},
+		{Version: 3, Name: "three", Up: "UP 3", Down: "DOWN 3"},
+	}
+}
+
+// =============================================================================
+// Load Tests
+// =============================================================================
+
+func TestLoad(t *testing.T) {
+	t.Run("parses and orders migrations", func(t *testing.T) {
+		fsys := fstest.MapFS{
+			"000002_add_total.up.sql":       {Data: []byte("ALTER 2")},
+			"000002_add_total.down.sql":     {Data: []byte("REVERT 2")},
+			"000001_create_orders.up.sql":   {Data: []byte("CREATE 1")},
+			"000003_irreversible.up.sql":    {Data: []byte("ALTER 3")},
+			"migrations.go":                 {Data: []byte("package migrations")},
+			"000001_create_orders.down.sql": {Data: []byte("DROP 1")},
+		}
+
+		all, err := migration.Load(fsys)
+
+		require.NoError(t, err)
+		require.Len(t, all, 3)
+		assert.Equal(t, migration.Migration{Version: 1, Name: "create_orders", Up: "CREATE 1", Down: "DROP 1"}, all[0])
+		assert.Equal(t, migration.Migration{Version: 2, Name: "add_total", Up: "ALTER 2", Down: "REVERT 2"}, all[1])
+		assert.Equal(t, migration.Migration{Version: 3, Name: "irreversible", Up: "ALTER 3"}, all[2])
+	})
+
+	t.Run("rejects invalid sets", func(t *testing.T) {
+		cases := map[string]fstest.MapFS{
+			"missing up file": {
+				"000001_create.down.sql": {Data: []byte("DROP")},
+			},
+			"duplicate version": {
+				"000001_create.up.sql": {Data: []byte("CREATE")},
+				"000001_other.up.sql":  {Data: []byte("CREATE")},
+			},
+			"bad file name": {
+				"create_orders.sql": {Data: []byte("CREATE")},
+			},
+			"zero version": {
+				"000000_create.up.sql": {Data: []byte("CREATE")},
+			},
+		}
+
+		for name, fsys := range cases {
+			t.Run(name, func(t *testing.T) {
+				_, err := migration.Load(fsys)
+				assert.ErrorIs(t, err, migration.ErrInvalidMigrations)
+			})
+		}
+	})
+
+	t.Run("embedded migrations are sequential and reversible", func(t *testing.T) {
+		all, err := migration.Load(migrations.FS)
+
+		require.NoError(t, err)
+		require.NotEmpty(t, all)
+		for i, m := range all {
+			assert.Equal(t, int64(i+1), m.Version, "migration %s", m.Name)
+			assert.NotEmpty(t, m.Down, "migration %d_%s has no down file", m.Version, m.Name)
+		}
+	})
+}
+
+// =============================================================================
+// Migrator Tests
+// =============================================================================
+
+func TestMigrator_Up(t *testing.T) {
+	t.Run("applies pending migrations in order", func(t *testing.T) {
+		store := newFakeStore(1)
+		migrator := migration.NewMigrator(store, testMigrations())
+
+		steps, err := migrator.Up(context.Background())
+
+		require.NoError(t, err)
+		assert.Len(t, steps, 2)
+		assert.Equal(t, []string{"up two", "up three"}, store.steps)
+		assert.True(t, store.prepared)
+		assert.False(t, store.locked, "lock must be released")
+	})
+
+	t.Run("leaves migrations from newer builds alone", func(t *testing.T) {
+		store := newFakeStore(1, 2, 3, 4)
+		migrator := migration.NewMigrator(store, testMigrations())
+
+		steps, err := migrator.Up(context.Background())
+
+		require.NoError(t, err)
+		assert.Empty(t, steps)
+		assert.Empty(t, store.steps)
+	})
+
+	t.Run("stops at the first failure", func(t *testing.T) {
+		store := newFakeStore()
+		store.failAt = 2
+		migrator := migration.NewMigrator(store, testMigrations())
+
+		steps, err := migrator.Up(context.Background())
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "000002_two")
+		require.Len(t, steps, 1)
+		assert.Equal(t, int64(1), steps[0].Version)
+		assert.Equal(t, []string{"up one"}, store.steps)
+		assert.False(t, store.locked, "lock must be released")
+	})
+}
+
+func TestMigrator_Down(t *testing.T) {
+	t.Run("reverts the latest migrations", func(t *testing.T) {
+		store := newFakeStore(1, 2, 3)
+		migrator := migration.NewMigrator(store, testMigrations())
+
+		steps, err := migrator.Down(context.Background(), 2)
+
+		require.NoError(t, err)
+		assert.Len(t, steps, 2)
+		assert.Equal(t, []string{"down three", "down two"}, store.steps)
+	})
+
+	t.Run("stops when nothing is applied", func(t *testing.T) {
+		store := newFakeStore(1)
+		migrator := migration.NewMigrator(store, testMigrations())
+
+		_, err := migrator.Down(context.Background(), 5)
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"down one"}, store.steps)
+	})
+
+	t.Run("refuses irreversible migrations", func(t *testing.T) {
+		all := testMigrations()
+		all[2].Down = ""
+		store := newFakeStore(1, 2, 3)
+
+		_, err := migration.NewMigrator(store, all).Down(context.Background(), 1)
+
+		assert.ErrorIs(t, err, migration.ErrIrreversible)
+		assert.Empty(t, store.steps)
+	})
+
+	t.Run("refuses migrations from newer builds", func(t *testing.T) {
+		store := newFakeStore(1, 2, 3, 4)
+
+		_, err := migration.NewMigrator(store, testMigrations()).Down(context.Background(), 1)
+
+		assert.ErrorIs(t, err, migration.ErrUnknownVersion)
+		assert.Empty(t, store.steps)
+	})
+
+	t.Run("rejects non-positive steps", func(t *testing.T) {
+		store := newFakeStore(1)
+
+		_, err := migration.NewMigrator(store, testMigrations()).Down(context.Background(), 0)
+
+		assert.Error(t, err)
+		assert.Zero(t, store.locks)
+	})
+}
+
+func TestMigrator_Goto(t *testing.T) {
+	t.Run("migrates up to the version", func(t *testing.T) {
+		store := newFakeStore()
+
+		_, err := migration.NewMigrator(store, testMigrations()).Goto(context.Background(), 2)
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"up one", "up two"}, store.steps)
+	})
+
+	t.Run("migrates down to the version", func(t *testing.T) {
+		store := newFakeStore(1, 2, 3)
+
+		_, err := migration.NewMigrator(store, testMigrations()).Goto(context.Background(), 1)
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"down three", "down two"}, store.steps)
+	})
+
+	t.Run("version zero reverts everything", func(t *testing.T) {
+		store := newFakeStore(1, 2)
+
+		_, err := migration.NewMigrator(store, testMigrations()).Goto(context.Background(), 0)
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"down two", "down one"}, store.steps)
+		assert.Empty(t, store.applied)
+	})
+
+	t.Run("rejects unknown versions", func(t *testing.T) {
+		store := newFakeStore()
+
+		_, err := migration.NewMigrator(store, testMigrations()).Goto(context.Background(), 7)
+
+		assert.ErrorIs(t, err, migration.ErrUnknownVersion)
+		assert.Empty(t, store.steps)
+	})
+}
+
+func TestMigrator_Status(t *testing.T) {
+	store := newFakeStore(1, 4)
+	migrator := migration.NewMigrator(store, testMigrations())
+
+	statuses, err := migrator.Status(context.Background())
+
+	require.NoError(t, err)
+	require.Len(t, statuses, 4)
+	assert.True(t, statuses[0].Applied)
+	assert.Equal(t, "one", statuses[0].Name)
+	assert.False(t, statuses[1].Applied)
+	assert.False(t, statuses[2].Applied)
+	assert.Equal(t, int64(4), statuses[3].Version)
+	assert.True(t, statuses[3].Applied)
+	assert.True(t, statuses[3].Missing)
+	assert.Empty(t, store.steps)
+	assert.Equal(t, 1, store.locks)
+}
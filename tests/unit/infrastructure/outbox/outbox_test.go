@@ -0,0 +1,283 @@
+// outbox_test.go - Transactional Outbox Unit Tests
+//
+// This file contains unit tests for the outbox relay and the EventPublisher
+// adapters used to deliver stored domain events to downstream systems.
+//
+// # Test Coverage
+//
+// The tests cover the following behavior:
+//   - Relay: successful delivery, retry with backoff, dead-lettering
+//   - Relay: batches run inside a unit of work and abort on store errors
+//   - MemoryPublisher and NDJSONPublisher output
+//   - NewPublisher adapter selection
+//
+// # Mocking Strategy
+//
+// The relay store is replaced by an in-memory fake that records every
+// state change, so no database is required.
+//
+// Generated by TelemetryFlow RESTful API Generator
+// Copyright (c) 2024-2026 DevOpsCorner Indonesia. All rights reserved.
+package outbox_test
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/telemetryflow/order-service/internal/infrastructure/config"
+	"github.com/telemetryflow/order-service/internal/infrastructure/outbox"
+)
+
+// =============================================================================
+// Test Doubles
+// =============================================================================
+
+// fakeStore implements outbox.Store in memory.
+type fakeStore struct {
+	pending   []outbox.Message
+	claimErr  error
+	published []uuid.UUID
+	retried   map[uuid.UUID]time.Duration
+	dead      map[uuid.UUID]int
+	lastError map[uuid.UUID]string
+	limit     int
+}
+
+func newFakeStore(messages ...outbox.Message) *fakeStore {
+	return &fakeStore{
+		pending:   messages,
+		retried:   make(map[uuid.UUID]time.Duration),
+		dead:      make(map[uuid.UUID]int),
+		lastError: make(map[uuid.UUID]string),
+	}
+}
+
+func (s *fakeStore) ClaimPending(_ context.Context, limit int) ([]outbox.Message, error) {
+	s.limit = limit
+	if s.claimErr != nil {
+		return nil, s.claimErr
+	}
+	if len(s.pending) < limit {
+		limit = len(s.pending)
+	}
+	return s.pending[:limit], nil
+}
+
+func (s *fakeStore) MarkPublished(_ context.Context, id uuid.UUID) error {
+	s.published = append(s.published, id)
+	return nil
+}
+
+func (s *fakeStore) Retry(_ context.Context, id uuid.UUID, _ int, lastErr string, delay time.Duration) error {
+	s.retried[id] = delay
+	s.lastError[id] = lastErr
+	return nil
+}
+
+func (s *fakeStore) MarkDead(_ context.Context, id uuid.UUID, attempts int, lastErr string) error {
+	s.dead[id] = attempts
+	s.lastError[id] = lastErr
+	return nil
+}
+
+// countingUnitOfWork runs the work directly and counts how often it was used.
+type countingUnitOfWork struct {
+	calls int
+}
+
+func (u *countingUnitOfWork) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	u.calls++
+	return fn(ctx)
+}
+
+// failingPublisher rejects every message.
+type failingPublisher struct{}
+
+func (failingPublisher) Publish(_ context.Context, _ outbox.Message) error {
+	return errors.New("broker unavailable")
+}
+
+func newMessage(attempts int) outbox.Message {
+	return outbox.Message{
+		ID:          uuid.New(),
+		AggregateID: uuid.New(),
+		EventName:   "order.created",
+		Payload:     json.RawMessage(`{"status":"pending"}`),
+		OccurredAt:  time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+		Attempts:    attempts,
+	}
+}
+
+func relayConfig() config.OutboxConfig {
+	return config.OutboxConfig{
+		BatchSize:    10,
+		MaxAttempts:  3,
+		RetryBackoff: time.Second,
+	}
+}
+
+// =============================================================================
+// Relay Tests
+// =============================================================================
+
+// TestRelay_ProcessBatch verifies delivery outcomes are recorded.
+func TestRelay_ProcessBatch(t *testing.T) {
+	t.Run("publishes pending messages and marks them published", func(t *testing.T) {
+		first, second := newMessage(0), newMessage(0)
+		store := newFakeStore(first, second)
+		publisher := outbox.NewMemoryPublisher()
+		uow := new(countingUnitOfWork)
+		relay := outbox.NewRelay(store, uow, publisher, relayConfig())
+
+		n, err := relay.ProcessBatch(context.Background())
+
+		require.NoError(t, err)
+		assert.Equal(t, 2, n)
+		assert.Equal(t, 1, uow.calls)
+		assert.Equal(t, 10, store.limit)
+		assert.Equal(t, []uuid.UUID{first.ID, second.ID}, store.published)
+		assert.Equal(t, []outbox.Message{first, second}, publisher.Messages())
+	})
+
+	t.Run("failed delivery is retried with exponential backoff", func(t *testing.T) {
+		fresh, retried := newMessage(0), newMessage(1)
+		store := newFakeStore(fresh, retried)
+		relay := outbox.NewRelay(store, new(countingUnitOfWork), failingPublisher{}, relayConfig())
+
+		_, err := relay.ProcessBatch(context.Background())
+
+		require.NoError(t, err)
+		assert.Empty(t, store.published)
+		assert.Equal(t, time.Second, store.retried[fresh.ID])
+		assert.Equal(t, 2*time.Second, store.retried[retried.ID])
+		assert.Equal(t, "broker unavailable", store.lastError[fresh.ID])
+	})
+
+	t.Run("poison message is moved to dead letter after max attempts", func(t *testing.T) {
+		msg := newMessage(2)
+		store := newFakeStore(msg)
+		relay := outbox.NewRelay(store, new(countingUnitOfWork), failingPublisher{}, relayConfig())
+
+		_, err := relay.ProcessBatch(context.Background())
+
+		require.NoError(t, err)
+		assert.Equal(t, 3, store.dead[msg.ID])
+		assert.NotContains(t, store.retried, msg.ID)
+	})
+
+	t.Run("claim error aborts the batch", func(t *testing.T) {
+		store := newFakeStore()
+		store.claimErr = errors.New("connection refused")
+		relay := outbox.NewRelay(store, new(countingUnitOfWork), outbox.NewMemoryPublisher(), relayConfig())
+
+		n, err := relay.ProcessBatch(context.Background())
+
+		assert.ErrorContains(t, err, "connection refused")
+		assert.Zero(t, n)
+	})
+
+	t.Run("zero config falls back to defaults", func(t *testing.T) {
+		store := newFakeStore()
+		relay := outbox.NewRelay(store, new(countingUnitOfWork), outbox.NewMemoryPublisher(), config.OutboxConfig{})
+
+		_, err := relay.ProcessBatch(context.Background())
+
+		require.NoError(t, err)
+		assert.Equal(t, 100, store.limit)
+	})
+}
+
+// TestRelay_Run verifies the polling loop stops with its context.
+func TestRelay_Run(t *testing.T) {
+	store := newFakeStore(newMessage(0))
+	publisher := outbox.NewMemoryPublisher()
+	cfg := relayConfig()
+	cfg.PollInterval = 10 * time.Millisecond
+	relay := outbox.NewRelay(store, new(countingUnitOfWork), publisher, cfg)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		relay.Run(ctx)
+	}()
+
+	require.Eventually(t, func() bool { return len(publisher.Messages()) > 0 }, time.Second, 5*time.Millisecond)
+	cancel()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("relay did not stop after cancellation")
+	}
+}
+
+// =============================================================================
+// Publisher Tests
+// =============================================================================
+
+// TestMemoryPublisher verifies messages are kept in publish order.
+func TestMemoryPublisher(t *testing.T) {
+	p := outbox.NewMemoryPublisher()
+	first, second := newMessage(0), newMessage(0)
+
+	require.NoError(t, p.Publish(context.Background(), first))
+	require.NoError(t, p.Publish(context.Background(), second))
+	assert.Equal(t, []outbox.Message{first, second}, p.Messages())
+
+	p.Reset()
+	assert.Empty(t, p.Messages())
+}
+
+// TestNDJSONPublisher verifies each message is written as one JSON line.
+func TestNDJSONPublisher(t *testing.T) {
+	t.Run("writes one line per message", func(t *testing.T) {
+		var buf bytes.Buffer
+		p := outbox.NewNDJSONPublisher(&buf)
+		first, second := newMessage(0), newMessage(0)
+
+		require.NoError(t, p.Publish(context.Background(), first))
+		require.NoError(t, p.Publish(context.Background(), second))
+
+		scanner := bufio.NewScanner(&buf)
+		var decoded []outbox.Message
+		for scanner.Scan() {
+			var msg outbox.Message
+			require.NoError(t, json.Unmarshal(scanner.Bytes(), &msg))
+			decoded = append(decoded, msg)
+		}
+		require.Len(t, decoded, 2)
+		assert.Equal(t, first.ID, decoded[0].ID)
+		assert.Equal(t, second.ID, decoded[1].ID)
+		assert.JSONEq(t, `{"status":"pending"}`, string(decoded[0].Payload))
+	})
+
+	t.Run("appends to a file", func(t *testing.T) {
+		cfg := config.OutboxConfig{Publisher: "ndjson", FilePath: filepath.Join(t.TempDir(), "events.ndjson")}
+		p, err := outbox.NewPublisher(cfg)
+		require.NoError(t, err)
+		require.IsType(t, &outbox.NDJSONPublisher{}, p)
+
+		require.NoError(t, p.Publish(context.Background(), newMessage(0)))
+		require.NoError(t, p.(*outbox.NDJSONPublisher).Close())
+	})
+}
+
+// TestNewPublisher verifies adapter selection.
+func TestNewPublisher(t *testing.T) {
+	p, err := outbox.NewPublisher(config.OutboxConfig{Publisher: "memory"})
+	require.NoError(t, err)
+	assert.IsType(t, &outbox.MemoryPublisher{}, p)
+
+	_, err = outbox.NewPublisher(config.OutboxConfig{Publisher: "kafka"})
+	assert.EqualError(t, err, "unsupported outbox publisher: kafka")
+}
@@ -0,0 +1,214 @@
+// gorm_repository_test.go - Generic GORM Repository Unit Tests
+//
+// This file contains unit tests for persistence.GormRepository, the generic
+// implementation of repository.Repository[T] embedded by the concrete
+// repositories.
+//
+// # Test Coverage
+//
+// The tests cover the following behavior:
+//   - Reads: soft-deleted rows are excluded and repository scopes applied
+//   - Update: optimistic locking on the version column
+//   - Delete and HardDelete: soft versus permanent deletion
+//   - Concrete repositories: order finders built on the generic base
+//
+// # Mocking Strategy
+//
+// Repositories run against a GORM session in dry-run mode: statements are
+// built but never executed, and a logger records the generated SQL, so no
+// database is required.
+//
+// Generated by TelemetryFlow RESTful API Generator
+// Copyright (c) 2024-2026 DevOpsCorner Indonesia. All rights reserved.
+package persistence_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/telemetryflow/order-service/internal/domain"
+	"github.com/telemetryflow/order-service/internal/domain/entity"
+	"github.com/telemetryflow/order-service/internal/domain/repository"
+	"github.com/telemetryflow/order-service/internal/infrastructure/persistence"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// =============================================================================
+// Helper Functions
+// =============================================================================
+
+// usd builds a US dollar amount from its decimal representation.
+func usd(amount string) domain.Money {
+	return domain.MustParseMoney(amount, domain.DefaultCurrency)
+}
+
+// sqlRecorder is a GORM logger that records every statement
+type sqlRecorder struct {
+	logger.Interface
+	statements []string
+}
+
+func (r *sqlRecorder) Trace(_ context.Context, _ time.Time, fc func() (string, int64), _ error) {
+	sql, _ := fc()
+	r.statements = append(r.statements, sql)
+}
+
+// last returns the most recent statement
+func (r *sqlRecorder) last() string {
+	if len(r.statements) == 0 {
+		return ""
+	}
+	return r.statements[len(r.statements)-1]
+}
+
+// dryRunDB opens a PostgreSQL session that builds statements without
+// connecting to a server
+func dryRunDB(t *testing.T) (*gorm.DB, *sqlRecorder) {
+	t.Helper()
+
+	rec := &sqlRecorder{Interface: logger.Discard}
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost dbname=test"}), &gorm.Config{
+		DryRun:                 true,
+		DisableAutomaticPing:   true,
+		SkipDefaultTransaction: true,
+		Logger:                 rec,
+	})
+	require.NoError(t, err)
+	return db, rec
+}
+
+// =============================================================================
+// GormRepository Tests
+// =============================================================================
+
+func TestGormRepository_Reads(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("FindByID excludes soft-deleted rows", func(t *testing.T) {
+		db, rec := dryRunDB(t)
+		repo := persistence.NewGormRepository[entity.Order](db, "order")
+
+		_, err := repo.FindByID(ctx, uuid.New())
+
+		require.NoError(t, err)
+		assert.Contains(t, rec.last(), `FROM "orders" WHERE id = `)
+		assert.Contains(t, rec.last(), `"orders"."deleted_at" IS NULL`)
+	})
+
+	t.Run("repository scopes apply to every read", func(t *testing.T) {
+		db, rec := dryRunDB(t)
+		tenant := func(db *gorm.DB) *gorm.DB { return db.Where("customer_id IS NOT NULL") }
+		repo := persistence.NewGormRepository[entity.Order](db, "order", tenant)
+
+		_, err := repo.FindByID(ctx, uuid.New())
+		require.NoError(t, err)
+		assert.Contains(t, rec.last(), "customer_id IS NOT NULL")
+
+		_, _, err = repo.FindAll(ctx, 20, 10)
+		require.NoError(t, err)
+		require.Len(t, rec.statements, 3)
+		assert.Contains(t, rec.statements[1], "SELECT count(*)")
+		assert.Contains(t, rec.statements[1], "customer_id IS NOT NULL")
+		assert.Contains(t, rec.statements[2], "customer_id IS NOT NULL")
+		assert.Contains(t, rec.statements[2], "ORDER BY created_at DESC LIMIT 10 OFFSET 20")
+	})
+
+	t.Run("Find applies call scopes after repository scopes", func(t *testing.T) {
+		db, rec := dryRunDB(t)
+		repo := persistence.NewGormRepository[entity.Orderitem](db, "orderitem")
+
+		_, err := repo.Find(ctx, func(db *gorm.DB) *gorm.DB { return db.Where("quantity > ?", 1) })
+
+		require.NoError(t, err)
+		assert.Contains(t, rec.last(), `FROM "order_items" WHERE quantity > `)
+	})
+}
+
+func TestGormRepository_Update(t *testing.T) {
+	db, rec := dryRunDB(t)
+	repo := persistence.NewGormRepository[entity.Order](db, "order")
+
+	order := entity.NewOrder(uuid.New(), usd("10.00"), "pending")
+	order.Version = 3
+
+	err := repo.Update(context.Background(), order)
+
+	// A dry run affects no rows, which is reported as a lost update
+	var conflict *repository.ConflictError
+	require.True(t, errors.As(err, &conflict))
+	assert.Equal(t, int64(3), conflict.Version)
+	assert.Equal(t, int64(3), order.Version, "version is restored on conflict")
+
+	assert.Contains(t, rec.last(), `UPDATE "orders" SET`)
+	assert.Contains(t, rec.last(), `"version"=4`)
+	assert.Contains(t, rec.last(), "version = 3")
+}
+
+func TestGormRepository_Delete(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Delete is soft", func(t *testing.T) {
+		db, rec := dryRunDB(t)
+		repo := persistence.NewGormRepository[entity.Order](db, "order")
+
+		require.NoError(t, repo.Delete(ctx, uuid.New()))
+		assert.Contains(t, rec.last(), `UPDATE "orders" SET "deleted_at"=`)
+	})
+
+	t.Run("HardDelete removes the row", func(t *testing.T) {
+		db, rec := dryRunDB(t)
+		repo := persistence.NewGormRepository[entity.Order](db, "order")
+
+		require.NoError(t, repo.HardDelete(ctx, uuid.New()))
+		assert.Contains(t, rec.last(), `DELETE FROM "orders" WHERE id = `)
+	})
+}
+
+func TestGormRepository_CreateBatch(t *testing.T) {
+	db, rec := dryRunDB(t)
+	repo := persistence.NewGormRepository[entity.Orderitem](db, "orderitem")
+
+	require.NoError(t, repo.CreateBatch(context.Background(), nil))
+	assert.Empty(t, rec.statements, "empty batches are not sent")
+}
+
+// =============================================================================
+// Concrete Repository Tests
+// =============================================================================
+
+func TestOrderRepository_Finders(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("FindByStatus", func(t *testing.T) {
+		db, rec := dryRunDB(t)
+		repo := persistence.NewOrderRepository(db)
+
+		_, err := repo.FindByStatus(ctx, "pending")
+
+		require.NoError(t, err)
+		assert.Contains(t, rec.last(), "status = 'pending'")
+		assert.Contains(t, rec.last(), `"orders"."deleted_at" IS NULL`)
+		assert.Contains(t, rec.last(), "ORDER BY created_at DESC")
+	})
+
+	t.Run("List counts and pages the same filter", func(t *testing.T) {
+		db, rec := dryRunDB(t)
+		repo := persistence.NewOrderRepository(db)
+
+		_, _, err := repo.List(ctx, repository.OrderFilter{Statuses: []string{"paid"}, Offset: 10, Limit: 5})
+
+		require.NoError(t, err)
+		require.Len(t, rec.statements, 2)
+		assert.Contains(t, rec.statements[0], "SELECT count(*)")
+		assert.Contains(t, rec.statements[0], "status IN ('paid')")
+		assert.Contains(t, rec.statements[1], "status IN ('paid')")
+		assert.Contains(t, rec.statements[1], "LIMIT 5 OFFSET 10")
+	})
+}
@@ -0,0 +1,134 @@
+// idempotency_store_test.go - Idempotency Key Store Unit Tests
+//
+// This file contains unit tests for persistence.NewIdempotencyStore, the
+// GORM implementation of middleware.IdempotencyStore.
+//
+// # Test Coverage
+//
+// The tests cover the following behavior:
+//   - Reserve: new keys are reserved and held keys return their record
+//   - Reserve: expired records are replaced
+//   - Reserve: a record vanishing before it is read is retried once, then
+//     reported as in progress
+//
+// # Mocking Strategy
+//
+// The store runs against a migrated SQLite database file. GORM callbacks
+// let another request hold the key before an insert and release it before
+// the read-back, simulating a concurrent release or expiry.
+//
+// Generated by TelemetryFlow RESTful API Generator
+// Copyright (c) 2024-2026 DevOpsCorner Indonesia. All rights reserved.
+package persistence_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/telemetryflow/order-service/internal/infrastructure/persistence"
+	"gorm.io/gorm"
+)
+
+// =============================================================================
+// Test Helpers
+// =============================================================================
+
+// releaseBeforeRead deletes every idempotency record before the next times
+// reads of the idempotency_keys table
+func releaseBeforeRead(t *testing.T, db *gorm.DB, times int) {
+	t.Helper()
+
+	err := db.Callback().Query().Before("gorm:query").Register("test:release_idempotency_key", func(tx *gorm.DB) {
+		if times == 0 || tx.Statement.Table != "idempotency_keys" {
+			return
+		}
+		times--
+		require.NoError(t, tx.Session(&gorm.Session{NewDB: true}).Exec("DELETE FROM idempotency_keys").Error)
+	})
+	require.NoError(t, err)
+}
+
+// holdBeforeInsert reserves key user:key-1 for another request before every
+// insert into the idempotency_keys table
+func holdBeforeInsert(t *testing.T, db *gorm.DB) {
+	t.Helper()
+
+	err := db.Callback().Create().Before("gorm:create").Register("test:hold_idempotency_key", func(tx *gorm.DB) {
+		if tx.Statement.Table != "idempotency_keys" {
+			return
+		}
+		now := time.Now().UTC()
+		require.NoError(t, tx.Session(&gorm.Session{NewDB: true}).Exec(
+			"INSERT INTO idempotency_keys (key, fingerprint, status_code, created_at, expires_at) VALUES (?, ?, 0, ?, ?)",
+			"user:key-1", "fp-1", now, now.Add(time.Hour),
+		).Error)
+	})
+	require.NoError(t, err)
+}
+
+// =============================================================================
+// Reserve Tests
+// =============================================================================
+
+func TestIdempotencyStore_Reserve(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("reserves new keys and returns held ones", func(t *testing.T) {
+		store := persistence.NewIdempotencyStore(openSQLite(t, t.TempDir(), "keys.db"))
+
+		record, err := store.Reserve(ctx, "user:key-1", "fp-1", time.Hour)
+		require.NoError(t, err)
+		assert.Nil(t, record)
+
+		require.NoError(t, store.Complete(ctx, "user:key-1", http.StatusCreated, http.Header{"Location": {"/orders/1"}}, []byte(`{}`)))
+
+		record, err = store.Reserve(ctx, "user:key-1", "fp-2", time.Hour)
+		require.NoError(t, err)
+		require.NotNil(t, record)
+		assert.Equal(t, "fp-1", record.Fingerprint)
+		assert.Equal(t, http.StatusCreated, record.StatusCode)
+		assert.Equal(t, "/orders/1", record.Header.Get("Location"))
+	})
+
+	t.Run("replaces expired records", func(t *testing.T) {
+		store := persistence.NewIdempotencyStore(openSQLite(t, t.TempDir(), "keys.db"))
+
+		_, err := store.Reserve(ctx, "user:key-1", "fp-1", -time.Minute)
+		require.NoError(t, err)
+
+		record, err := store.Reserve(ctx, "user:key-1", "fp-2", time.Hour)
+		require.NoError(t, err)
+		assert.Nil(t, record)
+	})
+
+	t.Run("retries when the held record vanishes before it is read", func(t *testing.T) {
+		db := openSQLite(t, t.TempDir(), "keys.db")
+		store := persistence.NewIdempotencyStore(db)
+		_, err := store.Reserve(ctx, "user:key-1", "fp-1", time.Hour)
+		require.NoError(t, err)
+		releaseBeforeRead(t, db, 1)
+
+		record, err := store.Reserve(ctx, "user:key-1", "fp-2", time.Hour)
+
+		require.NoError(t, err)
+		assert.Nil(t, record, "the retry reserves the key")
+	})
+
+	t.Run("reports the key in progress when it vanishes again", func(t *testing.T) {
+		db := openSQLite(t, t.TempDir(), "keys.db")
+		store := persistence.NewIdempotencyStore(db)
+		holdBeforeInsert(t, db)
+		releaseBeforeRead(t, db, 2)
+
+		record, err := store.Reserve(ctx, "user:key-1", "fp-2", time.Hour)
+
+		require.NoError(t, err)
+		require.NotNil(t, record)
+		assert.False(t, record.Completed())
+		assert.Equal(t, "fp-2", record.Fingerprint, "a repeat is answered as in progress, not as key reuse")
+	})
+}
@@ -0,0 +1,170 @@
+// replicas_test.go - Read Replica Routing Unit Tests
+//
+// This file contains unit tests for persistence.ReplicaSet, which routes
+// the reads of query handlers to read replicas.
+//
+// # Test Coverage
+//
+// The tests cover the following behavior:
+//   - Routing: only reads marked with repository.WithReplicaReads use a replica
+//   - Routing: repository.WithPrimaryReads overrides replica reads
+//   - Routing: writes and unit of work reads always use the primary
+//   - Health: unhealthy replicas are reported and reads fall back to the primary
+//   - Replicas: lookup of the replica set registered on a database
+//
+// # Mocking Strategy
+//
+// The primary and the replica are separate SQLite files. Rows written to
+// the primary are never copied to the replica, so a read shows which
+// database served it, as it would under replication lag.
+//
+// Generated by TelemetryFlow RESTful API Generator
+// Copyright (c) 2024-2026 DevOpsCorner Indonesia. All rights reserved.
+package persistence_test
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"testing"
+
+	"github.com/glebarez/sqlite"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/telemetryflow/order-service/internal/domain"
+	"github.com/telemetryflow/order-service/internal/domain/entity"
+	"github.com/telemetryflow/order-service/internal/domain/repository"
+	"github.com/telemetryflow/order-service/internal/infrastructure/config"
+	"github.com/telemetryflow/order-service/internal/infrastructure/persistence"
+	"gorm.io/gorm"
+)
+
+// =============================================================================
+// Test Helpers
+// =============================================================================
+
+// openSQLite opens a migrated SQLite database file in dir
+func openSQLite(t *testing.T, dir, name string) *gorm.DB {
+	t.Helper()
+
+	db, err := persistence.NewDatabase(config.DatabaseConfig{
+		Driver: persistence.DriverSQLite,
+		Name:   filepath.Join(dir, name),
+	})
+	require.NoError(t, err)
+	require.NoError(t, persistence.AutoMigrate(db, persistence.Models()...))
+	t.Cleanup(func() {
+		if sqlDB, err := db.DB(); err == nil {
+			_ = sqlDB.Close()
+		}
+	})
+	return db
+}
+
+// replicatedDB returns a primary database with one registered replica, and
+// the replica's connection pool
+func replicatedDB(t *testing.T) (*gorm.DB, *sql.DB) {
+	t.Helper()
+
+	dir := t.TempDir()
+	primary := openSQLite(t, dir, "primary.db")
+	replica, err := openSQLite(t, dir, "replica.db").DB()
+	require.NoError(t, err)
+
+	require.NoError(t, primary.Use(persistence.NewReplicaSet(persistence.Replica{
+		Name:      "replica-1",
+		Dialector: &sqlite.Dialector{Conn: replica},
+	})))
+	return primary, replica
+}
+
+// =============================================================================
+// Routing Tests
+// =============================================================================
+
+func TestReplicaSet_Routing(t *testing.T) {
+	db, _ := replicatedDB(t)
+	repo := persistence.NewOrderRepository(db)
+	ctx := context.Background()
+
+	order := entity.NewOrder(uuid.New(), domain.MustParseMoney("10.00", domain.DefaultCurrency), "pending")
+	require.NoError(t, repo.Create(repository.WithReplicaReads(ctx), order), "writes go to the primary")
+
+	t.Run("unmarked reads use the primary", func(t *testing.T) {
+		_, err := repo.FindByID(ctx, order.ID)
+		assert.NoError(t, err)
+	})
+
+	t.Run("replica reads use the replica", func(t *testing.T) {
+		_, err := repo.FindByID(repository.WithReplicaReads(ctx), order.ID)
+		assert.ErrorIs(t, err, domain.ErrEntityNotFound)
+
+		_, total, err := repo.FindAll(repository.WithReplicaReads(ctx), 0, 10)
+		require.NoError(t, err)
+		assert.Zero(t, total)
+	})
+
+	t.Run("primary reads override replica reads", func(t *testing.T) {
+		readCtx := repository.WithPrimaryReads(repository.WithReplicaReads(ctx))
+
+		_, err := repo.FindByID(readCtx, order.ID)
+		assert.NoError(t, err)
+	})
+
+	t.Run("unit of work reads use the primary", func(t *testing.T) {
+		err := persistence.NewUnitOfWork(db).Do(repository.WithReplicaReads(ctx), func(ctx context.Context) error {
+			_, err := repo.FindByID(ctx, order.ID)
+			return err
+		})
+		assert.NoError(t, err)
+	})
+}
+
+// =============================================================================
+// Health Tests
+// =============================================================================
+
+func TestReplicaSet_Health(t *testing.T) {
+	db, replica := replicatedDB(t)
+	repo := persistence.NewOrderRepository(db)
+	ctx := context.Background()
+
+	replicas := persistence.Replicas(db)
+	require.NotNil(t, replicas)
+	assert.True(t, replicas.Available())
+
+	statuses := replicas.Check(ctx)
+	require.Len(t, statuses, 1)
+	assert.Equal(t, "replica-1", statuses[0].Name)
+	assert.NoError(t, statuses[0].Err)
+
+	order := entity.NewOrder(uuid.New(), domain.MustParseMoney("10.00", domain.DefaultCurrency), "pending")
+	require.NoError(t, repo.Create(ctx, order))
+
+	// A failed replica is reported and its reads fall back to the primary
+	require.NoError(t, replica.Close())
+
+	statuses = replicas.Check(ctx)
+	require.Len(t, statuses, 1)
+	assert.Error(t, statuses[0].Err)
+	assert.False(t, replicas.Available())
+
+	_, err := repo.FindByID(repository.WithReplicaReads(ctx), order.ID)
+	assert.NoError(t, err)
+}
+
+func TestReplicas(t *testing.T) {
+	assert.Nil(t, persistence.Replicas(nil))
+	assert.Nil(t, persistence.Replicas(openSQLite(t, t.TempDir(), "orders.db")))
+}
+
+func TestNewDatabase_RejectsSQLiteReplicas(t *testing.T) {
+	_, err := persistence.NewDatabase(config.DatabaseConfig{
+		Driver:   persistence.DriverSQLite,
+		Name:     ":memory:",
+		Replicas: []string{"replica-1:5432"},
+	})
+
+	assert.Error(t, err)
+}
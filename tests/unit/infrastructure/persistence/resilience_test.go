@@ -0,0 +1,208 @@
+// resilience_test.go - Database Resilience Unit Tests
+//
+// This file contains unit tests for persistence.Resilience, which bounds
+// statements with timeouts, retries transient query failures and fails
+// fast through a circuit breaker while the database is down.
+//
+// # Test Coverage
+//
+// The tests cover the following behavior:
+//   - Retries: transient query failures are retried up to the attempt limit
+//   - Retries: locking queries are never retried
+//   - Timeouts: statements run with a deadline that is released afterwards
+//   - Breaker: repeated failures open the breaker and queries fail fast
+//   - Breaker: errors other than unavailability do not count as failures
+//   - Errors: repositories report failures as repository.ErrUnavailable
+//
+// # Mocking Strategy
+//
+// A SQLite database is wrapped in a connection pool that fails a given
+// number of queries with driver.ErrBadConn, or blocks them until their
+// context is done, and counts the queries reaching it.
+//
+// Generated by TelemetryFlow RESTful API Generator
+// Copyright (c) 2024-2026 DevOpsCorner Indonesia. All rights reserved.
+package persistence_test
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/glebarez/sqlite"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/telemetryflow/order-service/internal/domain"
+	"github.com/telemetryflow/order-service/internal/domain/entity"
+	"github.com/telemetryflow/order-service/internal/domain/repository"
+	"github.com/telemetryflow/order-service/internal/infrastructure/config"
+	"github.com/telemetryflow/order-service/internal/infrastructure/persistence"
+	"github.com/telemetryflow/order-service/pkg/circuitbreaker"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// =============================================================================
+// Test Helpers
+// =============================================================================
+
+// flakyPool is a connection pool whose queries fail on demand
+type flakyPool struct {
+	*sql.DB
+
+	failures atomic.Int32 // queries left to fail with driver.ErrBadConn
+	block    atomic.Bool  // block queries until their context is done
+	queries  atomic.Int32 // queries reaching the pool
+}
+
+func (p *flakyPool) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	p.queries.Add(1)
+	if p.block.Load() {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+	if p.failures.Add(-1) >= 0 {
+		return nil, driver.ErrBadConn
+	}
+	return p.DB.QueryContext(ctx, query, args...)
+}
+
+// resilientDB returns a migrated SQLite database protected by a Resilience
+// plugin configured with cfg, and its flaky connection pool
+func resilientDB(t *testing.T, cfg config.ResilienceConfig) (*gorm.DB, *flakyPool) {
+	t.Helper()
+
+	sqlDB, err := sql.Open(sqlite.DriverName, filepath.Join(t.TempDir(), "orders.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = sqlDB.Close() })
+
+	pool := &flakyPool{DB: sqlDB}
+	db, err := gorm.Open(&sqlite.Dialector{Conn: pool}, &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, persistence.AutoMigrate(db, persistence.Models()...))
+	require.NoError(t, db.Use(persistence.NewResilience(cfg)))
+	return db, pool
+}
+
+// =============================================================================
+// Retry Tests
+// =============================================================================
+
+func TestResilience_RetriesTransientFailures(t *testing.T) {
+	db, pool := resilientDB(t, config.ResilienceConfig{RetryAttempts: 3, RetryBackoff: time.Millisecond})
+	repo := persistence.NewOrderRepository(db)
+	ctx := context.Background()
+
+	t.Run("succeeds within the attempt limit", func(t *testing.T) {
+		pool.queries.Store(0)
+		pool.failures.Store(2)
+
+		_, _, err := repo.FindAll(ctx, 0, 10)
+
+		require.NoError(t, err)
+		assert.EqualValues(t, 4, pool.queries.Load(), "two failed attempts, then the page and its count")
+	})
+
+	t.Run("gives up after the attempt limit", func(t *testing.T) {
+		pool.queries.Store(0)
+		pool.failures.Store(3)
+
+		_, _, err := repo.FindAll(ctx, 0, 10)
+
+		assert.ErrorIs(t, err, repository.ErrUnavailable)
+		assert.EqualValues(t, 3, pool.queries.Load())
+	})
+
+	t.Run("does not retry locking queries", func(t *testing.T) {
+		pool.queries.Store(0)
+		pool.failures.Store(1)
+
+		var orders []entity.Order
+		err := db.WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}).Find(&orders).Error
+
+		assert.ErrorIs(t, err, driver.ErrBadConn)
+		assert.EqualValues(t, 1, pool.queries.Load())
+	})
+}
+
+// =============================================================================
+// Timeout Tests
+// =============================================================================
+
+func TestResilience_Timeouts(t *testing.T) {
+	db, pool := resilientDB(t, config.ResilienceConfig{ReadTimeout: 20 * time.Millisecond, RetryAttempts: 3})
+	repo := persistence.NewOrderRepository(db)
+	ctx := context.Background()
+
+	t.Run("deadline is released after the statement", func(t *testing.T) {
+		var orders []entity.Order
+		result := db.WithContext(ctx).Find(&orders)
+
+		require.NoError(t, result.Error)
+		_, hasDeadline := result.Statement.Context.Deadline()
+		assert.False(t, hasDeadline)
+	})
+
+	t.Run("slow queries are unavailable and not retried", func(t *testing.T) {
+		pool.queries.Store(0)
+		pool.block.Store(true)
+		defer pool.block.Store(false)
+
+		_, err := repo.FindByID(ctx, uuid.New())
+
+		assert.ErrorIs(t, err, repository.ErrUnavailable)
+		assert.EqualValues(t, 1, pool.queries.Load())
+	})
+}
+
+// =============================================================================
+// Circuit Breaker Tests
+// =============================================================================
+
+func TestResilience_CircuitBreaker(t *testing.T) {
+	db, pool := resilientDB(t, config.ResilienceConfig{
+		RetryAttempts:    1,
+		BreakerThreshold: 2,
+		BreakerCooldown:  time.Hour,
+	})
+	repo := persistence.NewOrderRepository(db)
+	ctx := context.Background()
+
+	breaker := persistence.CircuitBreaker(db)
+	require.NotNil(t, breaker)
+
+	// A missing order shows that the database is up
+	pool.failures.Store(1)
+	_, err := repo.FindByID(ctx, uuid.New())
+	assert.ErrorIs(t, err, repository.ErrUnavailable)
+	_, err = repo.FindByID(ctx, uuid.New())
+	assert.ErrorIs(t, err, domain.ErrEntityNotFound)
+	pool.failures.Store(1)
+	_, err = repo.FindByID(ctx, uuid.New())
+	assert.ErrorIs(t, err, repository.ErrUnavailable)
+	assert.Equal(t, circuitbreaker.Closed, breaker.State())
+
+	pool.failures.Store(1)
+	_, err = repo.FindByID(ctx, uuid.New())
+	assert.ErrorIs(t, err, repository.ErrUnavailable)
+	assert.Equal(t, circuitbreaker.Open, breaker.State())
+
+	// An open breaker fails fast without reaching the database
+	pool.queries.Store(0)
+	_, err = repo.FindByID(ctx, uuid.New())
+	assert.ErrorIs(t, err, repository.ErrUnavailable)
+	assert.ErrorIs(t, err, circuitbreaker.ErrOpen)
+	assert.Zero(t, pool.queries.Load())
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	assert.Nil(t, persistence.CircuitBreaker(nil))
+
+	db, _ := resilientDB(t, config.ResilienceConfig{})
+	assert.NotNil(t, persistence.CircuitBreaker(db))
+}
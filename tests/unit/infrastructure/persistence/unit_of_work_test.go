@@ -0,0 +1,94 @@
+// unit_of_work_test.go - Unit of Work Unit Tests
+//
+// This file contains unit tests for persistence.NewUnitOfWork, the GORM
+// implementation of repository.UnitOfWork.
+//
+// # Test Coverage
+//
+// The tests cover the following behavior:
+//   - Commit: repository calls made in the unit of work are persisted
+//   - Rollback: nothing is persisted when the work fails
+//   - Nesting: inner units of work join the outer transaction
+//   - After commit: callbacks run once the outermost transaction commits
+//
+// # Mocking Strategy
+//
+// The unit of work runs against a migrated SQLite database file.
+//
+// Generated by TelemetryFlow RESTful API Generator
+// Copyright (c) 2024-2026 DevOpsCorner Indonesia. All rights reserved.
+package persistence_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/telemetryflow/order-service/internal/domain"
+	"github.com/telemetryflow/order-service/internal/domain/entity"
+	"github.com/telemetryflow/order-service/internal/domain/repository"
+	"github.com/telemetryflow/order-service/internal/infrastructure/persistence"
+)
+
+// =============================================================================
+// Unit of Work Tests
+// =============================================================================
+
+func TestUnitOfWork(t *testing.T) {
+	db := openSQLite(t, t.TempDir(), "orders.db")
+	uow := persistence.NewUnitOfWork(db)
+	repo := persistence.NewOrderRepository(db)
+	ctx := context.Background()
+
+	newOrder := func() *entity.Order {
+		return entity.NewOrder(uuid.New(), domain.MustParseMoney("10.00", domain.DefaultCurrency), entity.OrderStatusPending)
+	}
+
+	t.Run("commits and then runs callbacks", func(t *testing.T) {
+		order := newOrder()
+		var found error
+
+		err := uow.Do(ctx, func(ctx context.Context) error {
+			return uow.Do(ctx, func(ctx context.Context) error {
+				repository.AfterCommit(ctx, func(ctx context.Context) {
+					_, found = repo.FindByID(ctx, order.ID)
+				})
+				return repo.Create(ctx, order)
+			})
+		})
+
+		require.NoError(t, err)
+		assert.NoError(t, found, "the callback sees the committed order")
+	})
+
+	t.Run("rolls back and drops callbacks when the work fails", func(t *testing.T) {
+		order := newOrder()
+		called := false
+
+		err := uow.Do(ctx, func(ctx context.Context) error {
+			repository.AfterCommit(ctx, func(context.Context) { called = true })
+			if err := uow.Do(ctx, func(ctx context.Context) error {
+				return repo.Create(ctx, order)
+			}); err != nil {
+				return err
+			}
+			return errors.New("work failed")
+		})
+
+		assert.EqualError(t, err, "work failed")
+		assert.False(t, called)
+		_, err = repo.FindByID(ctx, order.ID)
+		assert.ErrorIs(t, err, domain.ErrEntityNotFound)
+	})
+
+	t.Run("runs callbacks immediately outside a unit of work", func(t *testing.T) {
+		called := false
+
+		repository.AfterCommit(ctx, func(context.Context) { called = true })
+
+		assert.True(t, called)
+	})
+}
@@ -0,0 +1,140 @@
+// breaker_test.go - Circuit Breaker Unit Tests
+//
+// This file contains unit tests for the circuitbreaker package which fails
+// calls fast while a dependency is down.
+//
+// # Test Coverage
+//
+// The tests cover the following behaviors:
+//   - Threshold: The breaker opens after consecutive failures only
+//   - Cooldown: An open breaker turns half open and admits a single probe
+//   - Probes: A successful probe closes the breaker, a failed one reopens it
+//   - Notifications: OnStateChange reports every transition
+//   - Zero threshold: The breaker never opens
+//
+// Generated by TelemetryFlow RESTful API Generator
+// Copyright (c) 2024-2026 DevOpsCorner Indonesia. All rights reserved.
+package circuitbreaker_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/telemetryflow/order-service/pkg/circuitbreaker"
+)
+
+// =============================================================================
+// Threshold Tests
+// =============================================================================
+
+func TestBreaker_OpensAfterThreshold(t *testing.T) {
+	b := circuitbreaker.New(circuitbreaker.Config{Threshold: 3, Cooldown: time.Hour})
+
+	b.Failure()
+	b.Failure()
+	b.Success()
+	b.Failure()
+	b.Failure()
+	assert.Equal(t, circuitbreaker.Closed, b.State(), "a success resets the failure count")
+	assert.NoError(t, b.Allow())
+
+	b.Failure()
+	assert.Equal(t, circuitbreaker.Open, b.State())
+	assert.ErrorIs(t, b.Allow(), circuitbreaker.ErrOpen)
+}
+
+func TestBreaker_ZeroThresholdNeverOpens(t *testing.T) {
+	b := circuitbreaker.New(circuitbreaker.Config{})
+
+	for i := 0; i < 100; i++ {
+		b.Failure()
+	}
+
+	assert.Equal(t, circuitbreaker.Closed, b.State())
+	assert.NoError(t, b.Allow())
+}
+
+// =============================================================================
+// Probe Tests
+// =============================================================================
+
+// openBreaker returns a breaker that is open with an elapsed cooldown
+func openBreaker(t *testing.T) *circuitbreaker.Breaker {
+	t.Helper()
+
+	b := circuitbreaker.New(circuitbreaker.Config{Threshold: 1, Cooldown: time.Millisecond})
+	b.Failure()
+	time.Sleep(5 * time.Millisecond)
+	require.Equal(t, circuitbreaker.HalfOpen, b.State())
+	return b
+}
+
+func TestBreaker_HalfOpenAdmitsSingleProbe(t *testing.T) {
+	b := openBreaker(t)
+
+	require.NoError(t, b.Allow())
+	assert.ErrorIs(t, b.Allow(), circuitbreaker.ErrOpen, "only one probe runs at a time")
+}
+
+func TestBreaker_SuccessfulProbeCloses(t *testing.T) {
+	b := openBreaker(t)
+
+	require.NoError(t, b.Allow())
+	b.Success()
+
+	assert.Equal(t, circuitbreaker.Closed, b.State())
+	assert.NoError(t, b.Allow())
+	assert.NoError(t, b.Allow())
+}
+
+func TestBreaker_FailedProbeReopens(t *testing.T) {
+	b := circuitbreaker.New(circuitbreaker.Config{Threshold: 5, Cooldown: 20 * time.Millisecond})
+	for i := 0; i < 5; i++ {
+		b.Failure()
+	}
+	time.Sleep(30 * time.Millisecond)
+
+	require.NoError(t, b.Allow())
+	b.Failure()
+
+	assert.Equal(t, circuitbreaker.Open, b.State(), "a single failed probe reopens the breaker")
+	assert.ErrorIs(t, b.Allow(), circuitbreaker.ErrOpen)
+}
+
+// =============================================================================
+// Notification Tests
+// =============================================================================
+
+func TestBreaker_OnStateChange(t *testing.T) {
+	type transition struct{ from, to circuitbreaker.State }
+	var transitions []transition
+
+	b := circuitbreaker.New(circuitbreaker.Config{
+		Threshold: 1,
+		Cooldown:  time.Millisecond,
+		OnStateChange: func(from, to circuitbreaker.State) {
+			transitions = append(transitions, transition{from, to})
+		},
+	})
+
+	b.Success()
+	b.Failure()
+	time.Sleep(5 * time.Millisecond)
+	require.NoError(t, b.Allow())
+	b.Success()
+
+	assert.Equal(t, []transition{
+		{circuitbreaker.Closed, circuitbreaker.Open},
+		{circuitbreaker.Open, circuitbreaker.HalfOpen},
+		{circuitbreaker.HalfOpen, circuitbreaker.Closed},
+	}, transitions)
+}
+
+func TestState_String(t *testing.T) {
+	assert.Equal(t, "closed", circuitbreaker.Closed.String())
+	assert.Equal(t, "half-open", circuitbreaker.HalfOpen.String())
+	assert.Equal(t, "open", circuitbreaker.Open.String())
+	assert.Equal(t, "unknown", circuitbreaker.State(42).String())
+}
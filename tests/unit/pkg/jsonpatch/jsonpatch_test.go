@@ -0,0 +1,150 @@
+// jsonpatch_test.go - JSON Patch Unit Tests
+//
+// This file contains unit tests for the jsonpatch package which applies
+// PATCH documents to JSON representations.
+//
+// # Test Coverage
+//
+// The tests cover the following behaviors:
+//   - Merge patch: Members are replaced, merged recursively or removed by null
+//   - JSON Patch: add, remove, replace, move, copy and test operations
+//   - Pointers: Escaped tokens and array indexes, including "-"
+//   - Errors: Malformed patches, missing paths and failed tests
+//
+// Generated by TelemetryFlow RESTful API Generator
+// Copyright (c) 2024-2026 DevOpsCorner Indonesia. All rights reserved.
+package jsonpatch_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/telemetryflow/order-service/pkg/jsonpatch"
+)
+
+const doc = `{"status":"pending","notes":"","tags":["a","b"],"price":"10.00","quantity":2,"meta":{"x":1,"a/b":2,"m~n":3}}`
+
+// =============================================================================
+// Merge Patch Tests
+// =============================================================================
+
+func TestMergePatch(t *testing.T) {
+	tests := []struct {
+		name  string
+		patch string
+		want  string
+	}{
+		{
+			name:  "replaces a member",
+			patch: `{"status":"confirmed"}`,
+			want:  `{"status":"confirmed","notes":"","tags":["a","b"],"price":"10.00","quantity":2,"meta":{"x":1,"a/b":2,"m~n":3}}`,
+		},
+		{
+			name:  "removes a member set to null",
+			patch: `{"notes":null}`,
+			want:  `{"status":"pending","tags":["a","b"],"price":"10.00","quantity":2,"meta":{"x":1,"a/b":2,"m~n":3}}`,
+		},
+		{
+			name:  "merges objects recursively",
+			patch: `{"meta":{"x":null,"y":2}}`,
+			want:  `{"status":"pending","notes":"","tags":["a","b"],"price":"10.00","quantity":2,"meta":{"y":2,"a/b":2,"m~n":3}}`,
+		},
+		{
+			name:  "replaces arrays as a whole",
+			patch: `{"tags":["c"]}`,
+			want:  `{"status":"pending","notes":"","tags":["c"],"price":"10.00","quantity":2,"meta":{"x":1,"a/b":2,"m~n":3}}`,
+		},
+		{
+			name:  "keeps the document for an empty patch",
+			patch: `{}`,
+			want:  doc,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := jsonpatch.MergePatch([]byte(doc), []byte(tt.patch))
+			require.NoError(t, err)
+			assert.JSONEq(t, tt.want, string(got))
+		})
+	}
+
+	t.Run("rejects malformed patches", func(t *testing.T) {
+		_, err := jsonpatch.MergePatch([]byte(doc), []byte(`{"status":`))
+		assert.ErrorIs(t, err, jsonpatch.ErrInvalidPatch)
+	})
+}
+
+// =============================================================================
+// JSON Patch Tests
+// =============================================================================
+
+func TestApply(t *testing.T) {
+	tests := []struct {
+		name  string
+		patch string
+		want  string
+	}{
+		{
+			name:  "replaces a member",
+			patch: `[{"op":"replace","path":"/status","value":"confirmed"}]`,
+			want:  `{"status":"confirmed","notes":"","tags":["a","b"],"price":"10.00","quantity":2,"meta":{"x":1,"a/b":2,"m~n":3}}`,
+		},
+		{
+			name:  "adds to an array by index and with -",
+			patch: `[{"op":"add","path":"/tags/0","value":"z"},{"op":"add","path":"/tags/-","value":"c"}]`,
+			want:  `{"status":"pending","notes":"","tags":["z","a","b","c"],"price":"10.00","quantity":2,"meta":{"x":1,"a/b":2,"m~n":3}}`,
+		},
+		{
+			name:  "removes array elements and members",
+			patch: `[{"op":"remove","path":"/tags/0"},{"op":"remove","path":"/meta/a~1b"},{"op":"remove","path":"/meta/m~0n"}]`,
+			want:  `{"status":"pending","notes":"","tags":["b"],"price":"10.00","quantity":2,"meta":{"x":1}}`,
+		},
+		{
+			name:  "moves and copies values",
+			patch: `[{"op":"move","from":"/meta/x","path":"/quantity"},{"op":"copy","from":"/status","path":"/notes"}]`,
+			want:  `{"status":"pending","notes":"pending","tags":["a","b"],"price":"10.00","quantity":1,"meta":{"a/b":2,"m~n":3}}`,
+		},
+		{
+			name:  "applies operations after a passing test",
+			patch: `[{"op":"test","path":"/quantity","value":2.0},{"op":"replace","path":"/quantity","value":3}]`,
+			want:  `{"status":"pending","notes":"","tags":["a","b"],"price":"10.00","quantity":3,"meta":{"x":1,"a/b":2,"m~n":3}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := jsonpatch.Apply([]byte(doc), []byte(tt.patch))
+			require.NoError(t, err)
+			assert.JSONEq(t, tt.want, string(got))
+		})
+	}
+}
+
+func TestApply_Errors(t *testing.T) {
+	tests := []struct {
+		name  string
+		patch string
+		want  error
+	}{
+		{name: "patch is not an array", patch: `{"op":"remove","path":"/notes"}`, want: jsonpatch.ErrInvalidPatch},
+		{name: "unknown op", patch: `[{"op":"merge","path":"/notes"}]`, want: jsonpatch.ErrInvalidPatch},
+		{name: "missing value", patch: `[{"op":"replace","path":"/notes"}]`, want: jsonpatch.ErrInvalidPatch},
+		{name: "relative path", patch: `[{"op":"remove","path":"notes"}]`, want: jsonpatch.ErrInvalidPatch},
+		{name: "invalid array index", patch: `[{"op":"remove","path":"/tags/01"}]`, want: jsonpatch.ErrInvalidPatch},
+		{name: "move into itself", patch: `[{"op":"move","from":"/meta","path":"/meta/x"}]`, want: jsonpatch.ErrInvalidPatch},
+		{name: "replace missing member", patch: `[{"op":"replace","path":"/missing","value":1}]`, want: jsonpatch.ErrPathNotFound},
+		{name: "remove past the array end", patch: `[{"op":"remove","path":"/tags/2"}]`, want: jsonpatch.ErrPathNotFound},
+		{name: "add below a missing member", patch: `[{"op":"add","path":"/missing/x","value":1}]`, want: jsonpatch.ErrPathNotFound},
+		{name: "failing test", patch: `[{"op":"test","path":"/status","value":"shipped"}]`, want: jsonpatch.ErrTestFailed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := jsonpatch.Apply([]byte(doc), []byte(tt.patch))
+			assert.ErrorIs(t, err, tt.want)
+			assert.Nil(t, got)
+		})
+	}
+}
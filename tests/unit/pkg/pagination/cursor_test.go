@@ -0,0 +1,96 @@
+// cursor_test.go - Pagination Cursor Unit Tests
+//
+// This file contains unit tests for the pagination package which provides
+// opaque, signed cursors for keyset pagination.
+//
+// # Test Coverage
+//
+// The tests cover the following behaviors:
+//   - Round trip: Encoded cursors decode to the same position
+//   - Opacity: Tokens are URL safe
+//   - Scope: Cursors carry the scope they were issued for
+//   - Tampering: Modified payloads and signatures are rejected
+//   - Secrets: Cursors signed with another secret are rejected
+//   - Malformed input: Garbage tokens are rejected
+//
+// Generated by TelemetryFlow RESTful API Generator
+// Copyright (c) 2024-2026 DevOpsCorner Indonesia. All rights reserved.
+package pagination_test
+
+import (
+	"encoding/base64"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/telemetryflow/order-service/pkg/pagination"
+)
+
+// =============================================================================
+// Codec Tests
+// =============================================================================
+
+func TestCodec_RoundTrip(t *testing.T) {
+	codec := pagination.NewCodec("secret")
+
+	for _, backward := range []bool{false, true} {
+		cur := pagination.Cursor{
+			CreatedAt: time.Date(2026, 3, 4, 5, 6, 7, 891011000, time.UTC),
+			ID:        uuid.New(),
+			Backward:  backward,
+			Scope:     pagination.Scope("orders", "desc"),
+		}
+
+		token := codec.Encode(cur)
+		assert.Equal(t, url.QueryEscape(token), token, "token must be URL safe")
+
+		decoded, err := codec.Decode(token)
+		require.NoError(t, err)
+		assert.True(t, cur.CreatedAt.Equal(decoded.CreatedAt))
+		assert.Equal(t, cur.ID, decoded.ID)
+		assert.Equal(t, backward, decoded.Backward)
+		assert.Equal(t, cur.Scope, decoded.Scope)
+	}
+}
+
+func TestScope(t *testing.T) {
+	assert.Equal(t, pagination.Scope("orders", "desc"), pagination.Scope("orders", "desc"))
+	assert.NotEqual(t, pagination.Scope("orders", "desc"), pagination.Scope("orders", "asc"))
+	assert.NotEqual(t, pagination.Scope("orders", "ab", ""), pagination.Scope("orders", "a", "b"), "parts are delimited")
+}
+
+func TestCodec_RejectsTamperedCursor(t *testing.T) {
+	codec := pagination.NewCodec("secret")
+	token := codec.Encode(pagination.Cursor{CreatedAt: time.Now().UTC(), ID: uuid.New()})
+	payload, sig, _ := strings.Cut(token, ".")
+
+	t.Run("modified payload", func(t *testing.T) {
+		forged := base64.RawURLEncoding.EncodeToString([]byte(`{"t":"2020-01-01T00:00:00Z","id":"` + uuid.NewString() + `"}`))
+
+		_, err := codec.Decode(forged + "." + sig)
+		assert.ErrorIs(t, err, pagination.ErrInvalidCursor)
+	})
+
+	t.Run("modified signature", func(t *testing.T) {
+		_, err := codec.Decode(payload + "." + strings.Repeat("A", len(sig)))
+		assert.ErrorIs(t, err, pagination.ErrInvalidCursor)
+	})
+
+	t.Run("different secret", func(t *testing.T) {
+		_, err := pagination.NewCodec("other").Decode(token)
+		assert.ErrorIs(t, err, pagination.ErrInvalidCursor)
+	})
+}
+
+func TestCodec_RejectsMalformedCursor(t *testing.T) {
+	codec := pagination.NewCodec("secret")
+
+	for _, token := range []string{"", "abc", "abc.def", "!!!.???", "." + codec.Encode(pagination.Cursor{ID: uuid.New()})} {
+		_, err := codec.Decode(token)
+		assert.ErrorIs(t, err, pagination.ErrInvalidCursor, "token %q", token)
+	}
+}
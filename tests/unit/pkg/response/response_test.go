@@ -10,7 +10,9 @@
 //   - Created: 201 Created responses
 //   - NoContent: 204 No Content responses
 //   - Paginated: Responses with pagination metadata
+//   - CursorPaginated: Responses with next/previous cursors
 //   - Error responses: BadRequest, Unauthorized, Forbidden, NotFound, etc.
+//   - ServerError: 500 responses that never expose the underlying error
 //   - ValidationError: 400 with field-level error details
 //
 // # Response Format
@@ -24,6 +26,7 @@ package response_test
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -221,6 +224,40 @@ func TestPaginated(t *testing.T) {
 	}
 }
 
+func TestCursorPaginated(t *testing.T) {
+	e := echo.New()
+
+	t.Run("includes cursors", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		err := response.CursorPaginated(c, []string{"item1"}, 10, "next", "prev")
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		resp := parseResponse(t, rec)
+		require.NotNil(t, resp.Meta)
+		assert.Equal(t, 10, resp.Meta.PageSize)
+		assert.Equal(t, "next", resp.Meta.NextCursor)
+		assert.Equal(t, "prev", resp.Meta.PrevCursor)
+		assert.Zero(t, resp.Meta.TotalCount)
+	})
+
+	t.Run("omits empty cursors", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		err := response.CursorPaginated(c, []string{}, 10, "", "")
+
+		assert.NoError(t, err)
+		assert.NotContains(t, rec.Body.String(), "next_cursor")
+		assert.NotContains(t, rec.Body.String(), "prev_cursor")
+	})
+}
+
 // =============================================================================
 // Error Response Tests
 // =============================================================================
@@ -391,6 +428,26 @@ func TestInternalError(t *testing.T) {
 	})
 }
 
+func TestServerError(t *testing.T) {
+	e := echo.New()
+
+	t.Run("returns 500 without the error message", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		err := response.ServerError(c, errors.New(`pq: relation "orders" does not exist`))
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+		assert.NotContains(t, rec.Body.String(), "relation")
+
+		resp := parseResponse(t, rec)
+		assert.False(t, resp.Success)
+		assert.Equal(t, "INTERNAL_ERROR", resp.Error.Code)
+	})
+}
+
 func TestValidationError(t *testing.T) {
 	e := echo.New()
 